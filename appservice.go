@@ -2,9 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"foxyapply/internal/browser"
 	"foxyapply/internal/store"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -12,26 +20,195 @@ import (
 type AppService struct {
 	app        *application.App
 	store      *store.Store
-	browser    *browser.BrowserManager
 	downloader *browser.ChromeDownloader
+	fileConfig *browser.Config // From config.json in the data directory; nil without one
+
+	browsersMu sync.Mutex
+	browsers   map[int64]*browser.BrowserManager // One per profile, see browserFor
+
+	downloadMu     sync.Mutex
+	cancelDownload context.CancelFunc
+
+	stopMaintenance context.CancelFunc
 }
 
 func (s *AppService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
 	s.app = application.Get()
-	s.browser = browser.NewBrowserManager(nil)
 	s.downloader = browser.NewChromeDownloader()
+	s.downloader.OnExtract = func(extracted, total int) {
+		s.app.Event.Emit("browser:extract-progress", map[string]interface{}{
+			"extracted": extracted,
+			"total":     total,
+		})
+	}
 
-	store, err := store.New()
+	db, err := store.New()
 	fmt.Println("✅ App started")
 	if err != nil {
 		fmt.Println("❌ Failed to initialize store:", err)
 	} else {
-		s.store = store
+		s.store = db
+		if version, ok, err := db.GetSetting(store.SettingChromeVersion); err == nil && ok {
+			s.downloader.Version = version
+		}
+		s.useKeychain()
 	}
+
+	s.loadConfigFile()
+	s.startMaintenance()
 	return nil
 }
 
+// loadConfigFile loads the optional config.json from the data directory.
+// Its values are the defaults that saved settings override.
+func (s *AppService) loadConfigFile() {
+	dataDir, err := store.GetDataDir()
+	if err != nil {
+		return
+	}
+	cfg, err := browser.LoadConfig(filepath.Join(dataDir, "config.json"))
+	if err != nil {
+		fmt.Println("❌ Failed to load config.json:", err)
+		return
+	}
+	if cfg != nil {
+		fmt.Println("✅ Loaded config.json")
+	}
+	s.fileConfig = cfg
+}
+
+// useKeychain keeps profile passwords in the OS keychain when there is
+// one, moving any still stored in the database. Without a keychain they
+// stay in the database, encrypted.
+func (s *AppService) useKeychain() {
+	keychain, err := store.NewKeychain()
+	hasKeychain := err == nil
+	if hasKeychain {
+		s.store.SetCredentialStore(keychain)
+	} else {
+		fmt.Println("⚠️ Keeping passwords encrypted in the database:", err)
+	}
+	secured, err := s.store.SecurePasswords()
+	if err != nil {
+		fmt.Println("❌ Failed to secure stored passwords:", err)
+	} else if secured > 0 && hasKeychain {
+		fmt.Printf("🔐 Moved %d passwords to the keychain\n", secured)
+	} else if secured > 0 {
+		fmt.Printf("🔐 Encrypted %d stored passwords\n", secured)
+	}
+}
+
+// startMaintenance runs store maintenance on the interval configured by
+// SettingMaintenanceIntervalHours, if any
+func (s *AppService) startMaintenance() {
+	if s.store == nil {
+		return
+	}
+	hours, err := s.store.GetIntSetting(store.SettingMaintenanceIntervalHours, 0)
+	if err != nil || hours <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopMaintenance = cancel
+	go func() {
+		ticker := time.NewTicker(time.Duration(hours) * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.store.Maintenance(); err != nil {
+					fmt.Println("❌ Database maintenance failed:", err)
+				}
+			}
+		}
+	}()
+}
+
+// browserConfig builds the browser configuration from persisted settings
+// on top of base, the config file loaded at startup (nil without one), and
+// picks up challenge selector overrides from challenge-selectors.json in
+// the data directory. Settings that were never saved keep base's values.
+// db may be nil if the store failed to open.
+func browserConfig(db *store.Store, base *browser.Config) *browser.Config {
+	cfg := &browser.Config{}
+	if base != nil {
+		*cfg = *base
+	}
+
+	if db != nil {
+		if headless, err := db.GetBoolSetting(store.SettingHeadless, cfg.Headless); err == nil {
+			cfg.Headless = headless
+		}
+		if max, err := db.GetIntSetting(store.SettingMaxApplications, cfg.MaxApplications); err == nil {
+			cfg.MaxApplications = max
+		}
+		if delay, err := db.GetIntSetting(store.SettingApplyDelaySeconds, int(cfg.ApplyDelay/time.Second)); err == nil {
+			cfg.ApplyDelay = time.Duration(delay) * time.Second
+		}
+		if follow, err := db.GetBoolSetting(store.SettingFollowCompany, cfg.FollowCompany); err == nil {
+			cfg.FollowCompany = follow
+		}
+		if spoof, err := db.GetBoolSetting(store.SettingSpoofLocation, cfg.SpoofLocation); err == nil {
+			cfg.SpoofLocation = spoof
+		}
+		if minimized, err := db.GetBoolSetting(store.SettingStartMinimized, cfg.StartMinimized); err == nil {
+			cfg.StartMinimized = minimized
+		}
+		if perHour, err := db.GetIntSetting(store.SettingApplicationsPerHour, cfg.ApplicationsPerHour); err == nil {
+			cfg.ApplicationsPerHour = perHour
+		}
+		if failures, err := db.GetIntSetting(store.SettingMaxConsecutiveFailures, cfg.MaxConsecutiveFailures); err == nil {
+			cfg.MaxConsecutiveFailures = failures
+		}
+		if manual, err := db.GetBoolSetting(store.SettingManualLogin, cfg.ManualLogin); err == nil {
+			cfg.ManualLogin = manual
+		}
+		if minutes, err := db.GetIntSetting(store.SettingManualLoginMinutes, int(cfg.ManualLoginTimeout/time.Minute)); err == nil {
+			cfg.ManualLoginTimeout = time.Duration(minutes) * time.Minute
+		}
+		if human, err := db.GetBoolSetting(store.SettingHumanTyping, cfg.Timing.HumanTyping); err == nil {
+			cfg.Timing.HumanTyping = human
+		}
+		if minutes, err := db.GetIntSetting(store.SettingSessionTimeoutMinutes, int(cfg.SessionTimeout/time.Minute)); err == nil {
+			cfg.SessionTimeout = time.Duration(minutes) * time.Minute
+		}
+		if value, ok, err := db.GetSetting(store.SettingPosterPreference); err == nil && ok {
+			if pref, err := browser.ParsePosterPreference(value); err == nil {
+				cfg.PosterPreference = pref
+			} else {
+				fmt.Println("❌ Ignoring poster preference:", err)
+			}
+		}
+		if max, err := db.GetIntSetting(store.SettingMaxPerCompany, cfg.MaxPerCompany); err == nil {
+			cfg.MaxPerCompany = max
+		}
+		if days, err := db.GetIntSetting(store.SettingCompanyLimitDays, int(cfg.CompanyLimitWindow/(24*time.Hour))); err == nil {
+			cfg.CompanyLimitWindow = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	dataDir, err := store.GetDataDir()
+	if err != nil {
+		return cfg
+	}
+	selectors, err := browser.LoadChallengeSelectors(filepath.Join(dataDir, "challenge-selectors.json"))
+	if err != nil {
+		fmt.Println("❌ Failed to load challenge selectors:", err)
+		return cfg
+	}
+	if selectors != nil {
+		cfg.ChallengeSelectors = selectors
+	}
+	return cfg
+}
+
 func (s *AppService) ServiceShutdown(ctx context.Context, options application.ServiceOptions) error {
+	if s.stopMaintenance != nil {
+		s.stopMaintenance()
+	}
 	if s.store != nil {
 		s.store.Close()
 	}
@@ -42,65 +219,486 @@ func (s *AppService) ServiceShutdown(ctx context.Context, options application.Se
 type BrowserStatus struct {
 	Running    bool   `json:"running"`
 	Applying   bool   `json:"applying"`
+	Paused     bool   `json:"paused"`
 	Headless   bool   `json:"headless"`
 	Downloaded bool   `json:"downloaded"`
 	Version    string `json:"version"`
+
+	// Download is the progress of an in-progress DownloadBrowser call
+	Download browser.DownloadProgress `json:"download"`
+
+	// CooldownUntil is when applying resumes after LinkedIn rate limiting,
+	// or the zero time if it isn't paused
+	CooldownUntil time.Time `json:"cooldownUntil"`
+
+	// ApplicationsPerHour is the configured submission rate, or 0 if
+	// submissions aren't rate limited
+	ApplicationsPerHour int `json:"applicationsPerHour"`
+
+	// ActiveProfiles lists the profiles whose browsers are open
+	ActiveProfiles []int64 `json:"activeProfiles"`
 }
 
+// GetBrowserStatus reports the combined state of every profile's browser.
+// Use GetProfileBrowserStatus for a single profile.
 func (s *AppService) GetBrowserStatus() BrowserStatus {
-	return BrowserStatus{
-		Running:    s.browser.IsRunning(),
-		Applying:   s.browser.IsApplying(),
+	status := s.downloadStatus()
+	status.ActiveProfiles = []int64{}
+	for id, bm := range s.allBrowsers() {
+		if bm.IsRunning() {
+			status.Running = true
+			status.ActiveProfiles = append(status.ActiveProfiles, id)
+		}
+		status.Applying = status.Applying || bm.IsApplying()
+		status.Paused = status.Paused || bm.IsPaused()
+		if until := bm.CooldownUntil(); until.After(status.CooldownUntil) {
+			status.CooldownUntil = until
+		}
+	}
+	slices.Sort(status.ActiveProfiles)
+	return status
+}
+
+// downloadStatus fills in the parts of BrowserStatus shared by every profile
+func (s *AppService) downloadStatus() BrowserStatus {
+	status := BrowserStatus{
 		Downloaded: s.downloader.IsDownloaded(),
 		Version:    s.downloader.Version,
+		Download:   s.downloader.Progress(),
+	}
+	if s.store != nil {
+		if perHour, err := s.store.GetIntSetting(store.SettingApplicationsPerHour, 0); err == nil && perHour > 0 {
+			status.ApplicationsPerHour = perHour
+		}
+	}
+	return status
+}
+
+// checkBrowserAvailable fails up front when bm has no Chrome to launch,
+// naming the download path along with the bundled and system ones checked
+func (s *AppService) checkBrowserAvailable(bm *browser.BrowserManager) error {
+	if s.downloader.IsDownloaded() {
+		return nil
+	}
+	if err := bm.CheckBrowserAvailable(); err != nil {
+		return fmt.Errorf("%w; no downloaded Chrome at %s", err, s.downloader.GetBrowserPath())
 	}
+	return nil
+}
+
+// userMessages are shown in place of browser errors the user can act on,
+// checked in order so specific errors come before the ones they wrap
+var userMessages = []struct {
+	err     error
+	message string
+}{
+	{browser.ErrInvalidCredentials, "LinkedIn didn't accept the email or password. Check the profile's login details."},
+	{browser.ErrManualLoginTimeout, "Timed out waiting for you to sign in to LinkedIn. Start again when you're ready."},
+	{browser.ErrLoginFailed, "Couldn't sign in to LinkedIn. Check the profile's login details and try again."},
+	{browser.ErrSecurityChallenge, "LinkedIn asked for a security check. Solve it in the browser window, then try again."},
+	{browser.ErrRateLimited, "LinkedIn is limiting applications from this account. Try again later."},
+	{browser.ErrNoJobsFound, "No jobs matched the profile's search. Try broader positions or locations."},
+	{browser.ErrEasyApplyNotFound, "This job can't be applied to with Easy Apply."},
+	{browser.ErrBrowserNotRunning, "The browser isn't running. Start it and try again."},
 }
 
+// userError carries a message for the UI in place of the error it wraps,
+// which stays reachable with errors.Is
+type userError struct {
+	message string
+	err     error
+}
+
+func (e *userError) Error() string { return e.message }
+func (e *userError) Unwrap() error { return e.err }
+
+// userFacingError swaps err's message for one from userMessages when it
+// matches one of their errors. Other errors, and nil, are returned as is.
+func userFacingError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, m := range userMessages {
+		if errors.Is(err, m.err) {
+			fmt.Println("❌", err)
+			return &userError{message: m.message, err: err}
+		}
+	}
+	return err
+}
+
+// StartBrowser logs in to LinkedIn in a new browser, reporting whether the
+// login succeeded. With manual login on, the session the user signs in to
+// is kept in the profile's user data directory, or for an email with no
+// profile yet, in one the profile takes over once it's created.
 func (s *AppService) StartBrowser(email, password string) (bool, error) {
-	err := s.browser.Launch()
+	cfg := browserConfig(s.store, s.fileConfig)
+	var bm *browser.BrowserManager
+	if profileID, ok := s.profileIDForEmail(email); ok && cfg.ManualLogin {
+		bm = s.browserFor(profileID)
+	} else {
+		if cfg.ManualLogin {
+			if dataDir, err := store.GetDataDir(); err == nil {
+				cfg.UserData = loginDataDir(dataDir, email)
+			}
+		}
+		bm = browser.NewBrowserManager(cfg)
+		if s.downloader.IsDownloaded() {
+			bm.SetBrowserBin(s.downloader.GetBrowserPath())
+		}
+		bm.SetEventHandler(func(name string, data map[string]interface{}) {
+			s.app.Event.Emit(name, data)
+		})
+	}
+	if err := s.checkBrowserAvailable(bm); err != nil {
+		return false, err
+	}
+	err := bm.Launch()
+	if err != nil {
+		return false, err
+	}
+	successfulLogin, _, err := bm.Login(email, password)
+	bm.Close()
 	if err != nil {
 		return false, err
 	}
-	successfulLogin, _, err := s.browser.Login(email, password)
-	s.browser.Close()
 	s.app.Event.Emit("browser:started", nil)
 	return successfulLogin, nil
 }
 
-func (s *AppService) StartApplying(profileId int) error {
+// AuditStealth launches a browser configured like an apply run and reports
+// which automation fingerprints it hides (true) or gives away (false)
+func (s *AppService) AuditStealth() (map[string]bool, error) {
+	bm := browser.NewBrowserManager(browserConfig(s.store, s.fileConfig))
+	if s.downloader.IsDownloaded() {
+		bm.SetBrowserBin(s.downloader.GetBrowserPath())
+	}
+	if err := bm.Launch(); err != nil {
+		return nil, err
+	}
+	defer bm.Close()
+	return bm.AuditStealth()
+}
+
+// TestFormFill fills a saved Easy Apply form with a profile's answers in a
+// headless browser, returning what each field was filled with and why. It
+// gives a quick offline check of the fill heuristics.
+func (s *AppService) TestFormFill(profileID int64, htmlPath string) ([]browser.FilledField, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	profile, err := s.store.GetLinkedInProfile(profileID)
+	if err != nil {
+		return nil, err
+	}
+	html, err := os.ReadFile(htmlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read form snapshot: %w", err)
+	}
+
+	cfg := browserConfig(s.store, s.fileConfig)
+	cfg.Headless = true
+	cfg.ManualLogin = false // Nothing is signed in to
+	bm := browser.NewBrowserManager(cfg)
+	if s.downloader.IsDownloaded() {
+		bm.SetBrowserBin(s.downloader.GetBrowserPath())
+	}
+	bm.SetAnswerMemory(s.store)
+	if err := bm.Launch(); err != nil {
+		return nil, err
+	}
+	defer bm.Close()
+	return bm.TestFormFill(profile, string(html))
+}
+
+// VerifyCredentials checks a LinkedIn login in a separate headless browser
+// without starting to apply. The browser is set up like an apply run's, so
+// LinkedIn sees the same fingerprint. A rejected login returns false along
+// with an error matching browser.ErrInvalidCredentials or
+// browser.ErrChallengeRequired.
+func (s *AppService) VerifyCredentials(email, password string) (bool, error) {
+	cfg := browserConfig(s.store, s.fileConfig)
+	cfg.Headless = true
+	cfg.ManualLogin = false // The check submits the login form itself
+	if s.downloader.IsDownloaded() {
+		cfg.BrowserBin = s.downloader.GetBrowserPath()
+	}
+	verifier := browser.NewBrowserManager(cfg)
+	if err := s.checkBrowserAvailable(verifier); err != nil {
+		return false, err
+	}
+	if err := verifier.VerifyCredentials(email, password); err != nil {
+		return false, userFacingError(err)
+	}
+	return true, nil
+}
+
+// StartApplying logs the profile in and applies to jobs until it runs out
+// or reaches a limit. maxJobs stops this run after that many submitted
+// applications, on top of SettingMaxApplications; 0 is unlimited.
+func (s *AppService) StartApplying(profileId int, maxJobs int) error {
+	return s.startApplying(profileId, browser.ApplyOptions{MaxJobsThisSession: maxJobs})
+}
+
+// ContinueApplying is StartApplying picking up from the search page where
+// the profile's last unfinished run stopped, as reported by
+// GetApplyCheckpoint. Without a checkpoint it starts a fresh search.
+func (s *AppService) ContinueApplying(profileId int) error {
+	return s.startApplying(profileId, browser.ApplyOptions{Resume: true})
+}
+
+// GetApplyCheckpoint returns where the profile's last unfinished apply run
+// stopped, or nil if the last run finished, so the UI can offer to resume
+func (s *AppService) GetApplyCheckpoint(profileID int64) (*store.ApplyCheckpoint, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.LoadApplyCheckpoint(profileID)
+}
+
+// startApplying logs in with the profile and runs an apply session with opts
+func (s *AppService) startApplying(profileId int, opts browser.ApplyOptions) error {
+	bm := s.browserFor(int64(profileId))
+	release, err := bm.ClaimApplying()
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := bm.CheckCooldown(); err != nil {
+		return err
+	}
+	if err := s.checkBrowserAvailable(bm); err != nil {
+		return err
+	}
 	profile, err := s.store.GetLinkedInProfile(int64(profileId))
 	if err != nil {
 		return fmt.Errorf("failed to get LinkedIn profile: %w", err)
 	}
-	err = s.browser.Launch()
+	err = bm.Launch()
 	if err != nil {
 		return err
 	}
 
-	successfulLogin, page, err := s.browser.Login(profile.Email, profile.Password)
+	successfulLogin, page, err := bm.Login(profile.Email, profile.Password)
 	if err != nil {
-		return err
+		return userFacingError(err)
 	}
 	if !successfulLogin {
-		return fmt.Errorf("failed to log in to LinkedIn")
+		return userFacingError(browser.ErrLoginFailed)
 	}
 	fmt.Println("✅ Logged in to LinkedIn")
-	s.browser.StartApplying(profile, page)
-	return nil
+	applied, err := bm.StartApplyingWith(profile, page, opts)
+	s.saveCooldown(profile.ID, bm)
+	s.app.Event.Emit("browser:completed", map[string]interface{}{
+		"profileId": profile.ID,
+		"applied":   applied,
+	})
+	return userFacingError(err)
 }
 
-func (s *AppService) StopBrowser() error {
-	err := s.browser.Close()
+// ApplyToJob logs in and applies to the single job at jobURL, returning the
+// recorded application status. It runs the same steps as StartApplying,
+// so it reproduces form-filling problems with a particular posting.
+func (s *AppService) ApplyToJob(profileID int64, jobURL string) (string, error) {
+	if s.store == nil {
+		return "", fmt.Errorf("store not initialized")
+	}
+	if _, ok := browser.JobIDFromURL(jobURL); !ok {
+		return "", fmt.Errorf("not a LinkedIn job URL: %s", jobURL)
+	}
+	bm := s.browserFor(profileID)
+	release, err := bm.ClaimApplying()
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	if err := bm.CheckCooldown(); err != nil {
+		return "", err
+	}
+	profile, err := s.store.GetLinkedInProfile(profileID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get LinkedIn profile: %w", err)
+	}
+	if err := bm.Launch(); err != nil {
+		return "", err
+	}
+
+	successfulLogin, page, err := bm.Login(profile.Email, profile.Password)
+	if err != nil {
+		return "", userFacingError(err)
+	}
+	if !successfulLogin {
+		return "", userFacingError(browser.ErrLoginFailed)
+	}
+	status, err := bm.ApplyToJobURL(profile, page, jobURL)
+	s.saveCooldown(profile.ID, bm)
+	return status, userFacingError(err)
+}
+
+// RetryFailedApplications logs in and re-attempts the profile's failed
+// applications
+func (s *AppService) RetryFailedApplications(profileId int) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	bm := s.browserFor(int64(profileId))
+	release, err := bm.ClaimApplying()
 	if err != nil {
 		return err
 	}
+	defer release()
+	if err := bm.CheckCooldown(); err != nil {
+		return err
+	}
+	profile, err := s.store.GetLinkedInProfile(int64(profileId))
+	if err != nil {
+		return fmt.Errorf("failed to get LinkedIn profile: %w", err)
+	}
+	if err := bm.Launch(); err != nil {
+		return err
+	}
+
+	successfulLogin, page, err := bm.Login(profile.Email, profile.Password)
+	if err != nil {
+		return userFacingError(err)
+	}
+	if !successfulLogin {
+		return userFacingError(browser.ErrLoginFailed)
+	}
+	applied, err := bm.RetryFailed(profile, page)
+	s.saveCooldown(profile.ID, bm)
+	s.app.Event.Emit("browser:completed", map[string]interface{}{
+		"profileId": profile.ID,
+		"applied":   applied,
+		"retry":     true,
+	})
+	return userFacingError(err)
+}
+
+// ListFailedApplications returns the profile's failed applications that
+// can be retried
+func (s *AppService) ListFailedApplications(profileID int64) ([]store.Application, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.ListFailedApplications(profileID)
+}
+
+// StopBrowser closes every profile's browser, ending their apply runs.
+// Use StopProfileBrowser to stop a single profile.
+func (s *AppService) StopBrowser() error {
+	var errs []error
+	for _, bm := range s.allBrowsers() {
+		if err := stopBrowser(bm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
 
-	s.browser.SetApplying(false)
 	s.app.Event.Emit("browser:stopped", nil)
 	return nil
 }
 
+// PauseApplying suspends every active apply run before its next job
+func (s *AppService) PauseApplying() {
+	for _, bm := range s.allBrowsers() {
+		bm.Pause()
+	}
+	s.app.Event.Emit("browser:paused", nil)
+}
+
+// SetBrowserWindowState minimizes, maximizes or restores every open
+// browser window ("normal", "minimized", "maximized" or "fullscreen")
+func (s *AppService) SetBrowserWindowState(state string) error {
+	var errs []error
+	running := false
+	for _, bm := range s.allBrowsers() {
+		if !bm.IsRunning() {
+			continue
+		}
+		running = true
+		if err := bm.SetWindowState(state); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !running {
+		return userFacingError(browser.ErrBrowserNotRunning)
+	}
+	return errors.Join(errs...)
+}
+
+// CaptureSnapshot saves the HTML and a full-page screenshot of each open
+// browser's page to the data directory's snapshots folder, for when
+// applying looks stuck. It returns the folders the snapshots went to.
+func (s *AppService) CaptureSnapshot() ([]string, error) {
+	dataDir, err := store.GetDataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	dir := filepath.Join(dataDir, "snapshots")
+
+	var folders []string
+	var errs []error
+	for _, bm := range s.allBrowsers() {
+		if !bm.IsRunning() {
+			continue
+		}
+		page, err := bm.SharedPage()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		htmlPath, _, err := bm.Snapshot(page, dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Println("📸 Saved a snapshot to", filepath.Dir(htmlPath))
+		folders = append(folders, filepath.Dir(htmlPath))
+	}
+	if len(folders) == 0 && len(errs) == 0 {
+		return nil, userFacingError(browser.ErrBrowserNotRunning)
+	}
+	return folders, errors.Join(errs...)
+}
+
+// GetSessionStats returns the combined outcome tallies of every profile's
+// current or last apply run
+func (s *AppService) GetSessionStats() browser.ApplySession {
+	var sessions []browser.ApplySession
+	for _, bm := range s.allBrowsers() {
+		sessions = append(sessions, bm.SessionStats())
+	}
+	return browser.MergeSessions(sessions...)
+}
+
+// ResumeApplying continues every paused apply run
+func (s *AppService) ResumeApplying() {
+	for _, bm := range s.allBrowsers() {
+		bm.Resume()
+	}
+	s.app.Event.Emit("browser:resumed", nil)
+}
+
 func (s *AppService) DownloadBrowser() error {
+	s.downloadMu.Lock()
+	if s.cancelDownload != nil {
+		s.downloadMu.Unlock()
+		return fmt.Errorf("a download is already in progress")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelDownload = cancel
+	s.downloadMu.Unlock()
+
+	defer func() {
+		s.downloadMu.Lock()
+		s.cancelDownload = nil
+		s.downloadMu.Unlock()
+		cancel()
+	}()
+
 	// Emit progress events
 	progressFn := func(downloaded, total int64) {
 		percent := float64(downloaded) / float64(total) * 100
@@ -111,8 +709,11 @@ func (s *AppService) DownloadBrowser() error {
 		})
 	}
 
-	err := s.downloader.Download(progressFn)
+	err := s.downloader.Download(ctx, progressFn)
 	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			s.app.Event.Emit("browser:download-cancelled", nil)
+		}
 		return err
 	}
 
@@ -120,6 +721,69 @@ func (s *AppService) DownloadBrowser() error {
 	return nil
 }
 
+// CancelDownload stops an in-progress DownloadBrowser call. It is a no-op
+// when nothing is downloading.
+func (s *AppService) CancelDownload() {
+	s.downloadMu.Lock()
+	defer s.downloadMu.Unlock()
+	if s.cancelDownload != nil {
+		s.cancelDownload()
+	}
+}
+
+// SetChromeVersion switches to the given Chrome for Testing version,
+// downloading it if needed, and remembers the choice across restarts
+func (s *AppService) SetChromeVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("chrome version is required")
+	}
+	if s.anyBrowserRunning() {
+		return fmt.Errorf("cannot change Chrome version while the browser is running")
+	}
+
+	previous := s.downloader.Version
+	s.downloader.Version = version
+	if !s.downloader.IsDownloaded() {
+		if err := s.DownloadBrowser(); err != nil {
+			s.downloader.Version = previous
+			return err
+		}
+	}
+	for _, bm := range s.allBrowsers() {
+		if err := bm.SetBrowserBin(s.downloader.GetBrowserPath()); err != nil {
+			s.downloader.Version = previous
+			return err
+		}
+	}
+
+	if s.store != nil {
+		if err := s.store.SetSetting(store.SettingChromeVersion, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListChromeVersions returns the Chrome for Testing versions available for
+// this platform, newest first
+func (s *AppService) ListChromeVersions() ([]string, error) {
+	return s.downloader.ListAvailableVersions()
+}
+
+// ListDownloadedChromeVersions returns the Chrome versions on disk
+func (s *AppService) ListDownloadedChromeVersions() ([]string, error) {
+	return s.downloader.ListDownloadedVersions()
+}
+
+// DeleteChromeVersion removes a downloaded Chrome version. The version in
+// use can't be removed while the browser is running.
+func (s *AppService) DeleteChromeVersion(version string) error {
+	if version == s.downloader.Version && s.anyBrowserRunning() {
+		return fmt.Errorf("cannot delete Chrome %s while the browser is running", version)
+	}
+	return s.downloader.CleanupVersion(version)
+}
+
 // ============================================================================
 // Store Methods (Persistence)
 // ============================================================================
@@ -129,7 +793,12 @@ func (s *AppService) CreateLinkedInProfile(email, password string) (*store.Linke
 	if s.store == nil {
 		return nil, fmt.Errorf("store not initialized")
 	}
-	return s.store.CreateLinkedInProfile(email, password)
+	profile, err := s.store.CreateLinkedInProfile(email, password)
+	if err != nil {
+		return nil, err
+	}
+	s.emitProfileEvent("profile:created", profile)
+	return profile, nil
 }
 
 // GetLinkedInProfile retrieves a LinkedIn profile by ID
@@ -148,12 +817,60 @@ func (s *AppService) ListLinkedInProfiles() ([]*store.LinkedInProfile, error) {
 	return s.store.ListLinkedInProfiles()
 }
 
+// CountProfiles returns how many LinkedIn profiles there are
+func (s *AppService) CountProfiles() (int, error) {
+	if s.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	return s.store.CountProfiles()
+}
+
+// SearchLinkedInProfiles retrieves LinkedIn profiles matching a filter
+func (s *AppService) SearchLinkedInProfiles(filter store.ProfileFilter) ([]*store.LinkedInProfile, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.SearchLinkedInProfiles(filter)
+}
+
 // UpdateLinkedInProfile updates an existing LinkedIn profile
 func (s *AppService) UpdateLinkedInProfile(id int64, update store.LinkedInProfileUpdate) (*store.LinkedInProfile, error) {
 	if s.store == nil {
 		return nil, fmt.Errorf("store not initialized")
 	}
-	return s.store.UpdateLinkedInProfile(id, update)
+	profile, err := s.store.UpdateLinkedInProfile(id, update)
+	if err != nil {
+		return nil, err
+	}
+	s.emitProfileEvent("profile:updated", profile)
+	return profile, nil
+}
+
+// PatchLinkedInProfileFields updates only the given profile fields, keyed
+// by their JSON names
+func (s *AppService) PatchLinkedInProfileFields(id int64, fields map[string]any) (*store.LinkedInProfile, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	profile, err := s.store.PatchLinkedInProfileFields(id, fields)
+	if err != nil {
+		return nil, err
+	}
+	s.emitProfileEvent("profile:updated", profile)
+	return profile, nil
+}
+
+// PatchLinkedInProfile updates only the fields set in patch
+func (s *AppService) PatchLinkedInProfile(id int64, patch store.LinkedInProfilePatch) (*store.LinkedInProfile, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	profile, err := s.store.PatchLinkedInProfile(id, patch)
+	if err != nil {
+		return nil, err
+	}
+	s.emitProfileEvent("profile:updated", profile)
+	return profile, nil
 }
 
 // DeleteLinkedInProfile deletes a LinkedIn profile
@@ -161,9 +878,343 @@ func (s *AppService) DeleteLinkedInProfile(id int64) error {
 	if s.store == nil {
 		return fmt.Errorf("store not initialized")
 	}
-	return s.store.DeleteLinkedInProfile(id)
+	if err := s.store.DeleteLinkedInProfile(id); err != nil {
+		return err
+	}
+	s.emitProfilesDeleted([]int64{id})
+	return nil
+}
+
+// DeleteLinkedInProfiles deletes several LinkedIn profiles at once
+func (s *AppService) DeleteLinkedInProfiles(ids []int64) (int, error) {
+	if s.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	deleted, err := s.store.DeleteLinkedInProfilesReturningIDs(ids)
+	if err != nil {
+		return 0, err
+	}
+	if len(deleted) > 0 {
+		s.emitProfilesDeleted(deleted)
+	}
+	return len(deleted), nil
+}
+
+// emitProfilesDeleted tells every window which profiles were deleted. One
+// profile or many, the event carries the same {"ids": [...]} payload.
+func (s *AppService) emitProfilesDeleted(ids []int64) {
+	s.app.Event.Emit("profile:deleted", map[string]interface{}{
+		"ids": ids,
+	})
+}
+
+// ListDeletedLinkedInProfiles returns the deleted LinkedIn profiles that can
+// still be restored
+func (s *AppService) ListDeletedLinkedInProfiles() ([]*store.LinkedInProfile, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.ListDeletedLinkedInProfiles()
+}
+
+// RestoreLinkedInProfile restores a deleted LinkedIn profile
+func (s *AppService) RestoreLinkedInProfile(id int64) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	if err := s.store.RestoreLinkedInProfile(id); err != nil {
+		return err
+	}
+	if profile, err := s.store.GetLinkedInProfile(id); err == nil {
+		s.emitProfileEvent("profile:restored", profile)
+	}
+	return nil
+}
+
+// emitProfileEvent tells every window that a profile changed so profile
+// lists can refresh
+func (s *AppService) emitProfileEvent(name string, profile *store.LinkedInProfile) {
+	s.app.Event.Emit(name, map[string]interface{}{
+		"id":      profile.ID,
+		"profile": profile,
+	})
+}
+
+// PurgeDeletedProfiles permanently removes profiles deleted more than
+// olderThanDays ago, along with their browsers' data and LinkedIn sessions
+func (s *AppService) PurgeDeletedProfiles(olderThanDays int) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	purged, err := s.store.PurgeDeletedProfiles(time.Duration(olderThanDays) * 24 * time.Hour)
+	// The purged profiles are gone even if removing a password failed
+	if dropErr := s.dropBrowsers(purged); dropErr != nil {
+		return errors.Join(err, dropErr)
+	}
+	if dataDir, dirErr := store.GetDataDir(); dirErr == nil {
+		removeBrowserData(dataDir, purged)
+	}
+	return err
+}
+
+// ExportProfiles serializes all LinkedIn profiles to JSON
+func (s *AppService) ExportProfiles(includePasswords bool) (string, error) {
+	if s.store == nil {
+		return "", fmt.Errorf("store not initialized")
+	}
+	data, err := s.store.ExportProfiles(includePasswords)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ImportProfiles creates LinkedIn profiles from exported JSON
+func (s *AppService) ImportProfiles(data string) (int, error) {
+	if s.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	return s.store.ImportProfiles([]byte(data))
+}
+
+// ExportData writes every profile and its application history to a JSON
+// file at path
+func (s *AppService) ExportData(path string, includePasswords bool) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	data, err := s.store.ExportData(includePasswords)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// ExportApplicationsCSV writes a profile's application history to a CSV
+// file at path, for opening in a spreadsheet
+func (s *AppService) ExportApplicationsCSV(profileID int64, path string) (err error) {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to write export: %w", cerr)
+		}
+	}()
+	return s.store.ExportApplicationsCSV(profileID, f)
+}
+
+// ImportData loads a file written by ExportData, updating profiles with
+// matching emails only when overwrite is set
+func (s *AppService) ImportData(path string, overwrite bool) (*store.ImportResult, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	return s.store.ImportData(data, overwrite)
+}
+
+// GetSetting returns a persisted setting, or "" if it isn't set
+func (s *AppService) GetSetting(key string) (string, error) {
+	if s.store == nil {
+		return "", fmt.Errorf("store not initialized")
+	}
+	value, _, err := s.store.GetSetting(key)
+	return value, err
+}
+
+// SetSetting persists a setting. Browser settings take effect on the next start.
+func (s *AppService) SetSetting(key, value string) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return s.store.SetSetting(key, value)
+}
+
+// ListResumes returns a profile's resumes, the default first
+func (s *AppService) ListResumes(profileID int64) ([]*store.Resume, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.ListResumes(profileID)
+}
+
+// CreateResume adds a resume to a profile
+func (s *AppService) CreateResume(resume store.Resume) (*store.Resume, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.CreateResume(resume)
+}
+
+// UpdateResume updates a resume's label, path, keywords and default flag
+func (s *AppService) UpdateResume(id int64, update store.Resume) (*store.Resume, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.UpdateResume(id, update)
+}
+
+// DeleteResume removes a resume
+func (s *AppService) DeleteResume(id int64) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return s.store.DeleteResume(id)
+}
+
+// ListQuestionAnswers returns a profile's remembered answers to application
+// questions
+func (s *AppService) ListQuestionAnswers(profileID int64) ([]*store.QuestionAnswer, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.ListQuestionAnswers(profileID)
+}
+
+// SaveQuestionAnswer remembers a profile's answer to a question, replacing
+// any answer stored for the same question
+func (s *AppService) SaveQuestionAnswer(profileID int64, question, inputType, answer string) (*store.QuestionAnswer, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.SaveQuestionAnswer(profileID, question, inputType, answer)
+}
+
+// UpdateQuestionAnswer changes a remembered answer
+func (s *AppService) UpdateQuestionAnswer(id int64, answer string) (*store.QuestionAnswer, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.UpdateQuestionAnswer(id, answer)
+}
+
+// DeleteQuestionAnswer forgets a remembered answer
+func (s *AppService) DeleteQuestionAnswer(id int64) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return s.store.DeleteQuestionAnswer(id)
+}
+
+// ListQueuedJobs returns a profile's queued jobs, optionally by status
+func (s *AppService) ListQueuedJobs(profileID int64, status string) ([]*store.QueuedJob, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.ListQueuedJobs(profileID, status)
+}
+
+// ListApplications returns a page of a profile's application history
+func (s *AppService) ListApplications(profileID int64, status string, limit, offset int) ([]store.Application, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.ListApplications(profileID, status, limit, offset)
+}
+
+// CountApplications returns how many applications a profile submitted
+// since a time, such as the start of the month
+func (s *AppService) CountApplications(profileID int64, since time.Time) (int, error) {
+	if s.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	return s.store.CountApplications(profileID, since)
+}
+
+// SearchApplications finds a profile's applications by job title or company
+func (s *AppService) SearchApplications(profileID int64, query string) ([]store.Application, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return s.store.SearchApplications(profileID, query)
+}
+
+// BackupDatabase writes a copy of the database to destPath
+func (s *AppService) BackupDatabase(destPath string) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return s.store.Backup(destPath)
+}
+
+// RestoreDatabase replaces the database with the backup at srcPath. Every
+// browser is closed first. The restored profiles may give an ID to another
+// account, so browser sessions whose profile changed are removed.
+func (s *AppService) RestoreDatabase(srcPath string) error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	if err := s.dropBrowsers(slices.Collect(maps.Keys(s.allBrowsers()))); err != nil {
+		return err
+	}
+	before, err := s.profileEmails()
+	if err != nil {
+		return err
+	}
+	if err := s.store.Restore(srcPath); err != nil {
+		return err
+	}
+	after, err := s.profileEmails()
+	if err != nil {
+		return err
+	}
+
+	var changed []int64
+	for id, email := range before {
+		if !strings.EqualFold(after[id], email) {
+			changed = append(changed, id)
+		}
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			changed = append(changed, id)
+		}
+	}
+	if dataDir, err := store.GetDataDir(); err == nil {
+		removeBrowserData(dataDir, changed)
+	}
+	return nil
+}
+
+// profileEmails maps the ID of every profile, deleted ones included, to
+// the email it signs in with
+func (s *AppService) profileEmails() (map[int64]string, error) {
+	profiles, err := s.store.ListLinkedInProfiles()
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := s.store.ListDeletedLinkedInProfiles()
+	if err != nil {
+		return nil, err
+	}
+	emails := map[int64]string{}
+	for _, profile := range append(profiles, deleted...) {
+		emails[profile.ID] = profile.Email
+	}
+	return emails, nil
+}
+
+// RunMaintenance checkpoints the database WAL and checks its integrity
+func (s *AppService) RunMaintenance() error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return s.store.Maintenance()
 }
 
 func (s *AppService) SetApplying(applying bool) {
-	s.browser.SetApplying(applying)
+	for _, bm := range s.allBrowsers() {
+		bm.SetApplying(applying)
+	}
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"foxyapply/internal/browser"
+	"foxyapply/internal/store"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestMain stands in for a Chrome that takes a second to fail when a test
+// launches this binary as the browser. Launching passes Chrome's remote
+// debugging flag, which go test never does.
+func TestMain(m *testing.M) {
+	if slices.Contains(os.Args[1:], "--remote-debugging-port=0") {
+		time.Sleep(time.Second)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// newTestService returns a service with a store and Chrome downloads in a
+// temporary data directory
+func newTestService(t *testing.T) *AppService {
+	t.Helper()
+	dataDir := t.TempDir()
+	t.Setenv(store.DataDirEnv, dataDir)
+	db, err := store.NewInDataDir(dataDir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &AppService{store: db, downloader: browser.NewChromeDownloaderIn(dataDir)}
+}
+
+func TestStartApplyingRefusesSecondCall(t *testing.T) {
+	s := newTestService(t)
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("failed to find the test binary: %v", err)
+	}
+	s.fileConfig = &browser.Config{BrowserBin: exe, Headless: true}
+	profile, err := s.store.CreateLinkedInProfile("test@example.com", "password")
+	if err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	bm := s.browserFor(profile.ID)
+
+	first := make(chan error, 1)
+	go func() { first <- s.StartApplying(int(profile.ID), 0) }()
+	for !bm.IsApplying() {
+		select {
+		case err := <-first:
+			t.Fatalf("expected the first call to hold its claim while launching, got %v", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	// The second call is refused while the first is still launching
+	if err := s.StartApplying(int(profile.ID), 0); !errors.Is(err, browser.ErrAlreadyApplying) {
+		t.Errorf("expected ErrAlreadyApplying, got %v", err)
+	}
+	if err := <-first; err == nil || errors.Is(err, browser.ErrAlreadyApplying) {
+		t.Errorf("expected the first call to fail launching the fake browser, got %v", err)
+	}
+	if bm.IsApplying() {
+		t.Error("expected the failed launch to release its claim")
+	}
+}
+
+func TestSetChromeVersionSkipsDownloadedVersion(t *testing.T) {
+	s := newTestService(t)
+	s.downloader.BaseURL = "http://127.0.0.1:0" // Any download attempt fails
+
+	const version = "120.0.6099.109"
+	s.downloader.Version = version
+	path := s.downloader.GetBrowserPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create version directory: %v", err)
+	}
+	if err := os.WriteFile(path, nil, 0755); err != nil {
+		t.Fatalf("failed to write browser: %v", err)
+	}
+	s.downloader.Version = browser.LatestStableVersion
+
+	if err := s.SetChromeVersion(version); err != nil {
+		t.Fatalf("expected the downloaded version to be used without downloading, got %v", err)
+	}
+	if s.downloader.Version != version {
+		t.Errorf("expected version %s, got %s", version, s.downloader.Version)
+	}
+	if saved, ok, err := s.store.GetSetting(store.SettingChromeVersion); err != nil || !ok || saved != version {
+		t.Errorf("expected version %s to be saved, got %q (%v)", version, saved, err)
+	}
+}
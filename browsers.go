@@ -0,0 +1,296 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"foxyapply/internal/browser"
+	"foxyapply/internal/store"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxConcurrentBrowsers caps StartApplyingAll when
+// SettingMaxConcurrentBrowsers isn't set
+const defaultMaxConcurrentBrowsers = 2
+
+// browserFor returns the profile's browser manager, creating it on first
+// use. Each profile gets its own Chrome user data directory so LinkedIn
+// sessions never leak between accounts. A manager whose browser isn't
+// running picks up settings changed since it was created.
+func (s *AppService) browserFor(profileID int64) *browser.BrowserManager {
+	s.browsersMu.Lock()
+	bm, ok := s.browsers[profileID]
+	s.browsersMu.Unlock()
+
+	// Building the config reads settings and may move a login's user data
+	// directory, so it's done without holding browsersMu
+	cfg := s.profileBrowserConfig(profileID)
+	if ok {
+		// A running browser keeps the settings it was launched with
+		bm.SetConfig(cfg)
+		return bm
+	}
+
+	bm = browser.NewBrowserManager(cfg)
+	bm.SetEventHandler(func(name string, data map[string]interface{}) {
+		if data == nil {
+			data = map[string]interface{}{}
+		}
+		data["profileId"] = profileID
+		s.app.Event.Emit(name, data)
+	})
+	if s.store != nil {
+		bm.SetRecorder(s.store)
+		bm.SetResumeLister(s.store)
+		bm.SetAnswerMemory(s.store)
+		bm.SetCooldownUntil(s.loadCooldown(profileID))
+	}
+
+	s.browsersMu.Lock()
+	defer s.browsersMu.Unlock()
+	if existing, ok := s.browsers[profileID]; ok {
+		return existing // Another caller created it first
+	}
+	if s.browsers == nil {
+		s.browsers = map[int64]*browser.BrowserManager{}
+	}
+	s.browsers[profileID] = bm
+	return bm
+}
+
+// profileBrowserConfig builds the browser configuration for the profile
+// from the current settings
+func (s *AppService) profileBrowserConfig(profileID int64) *browser.Config {
+	cfg := browserConfig(s.store, s.fileConfig)
+	if dataDir, err := store.GetDataDir(); err == nil {
+		cfg.UserData = userDataDir(dataDir, profileID)
+		s.adoptLoginDataDir(dataDir, profileID, cfg.UserData)
+		cfg.SnapshotDir = filepath.Join(dataDir, "snapshots")
+	}
+	if s.downloader.IsDownloaded() {
+		cfg.BrowserBin = s.downloader.GetBrowserPath()
+	}
+	return cfg
+}
+
+// userDataDir is the Chrome user data directory of the profile's browser
+func userDataDir(dataDir string, profileID int64) string {
+	return filepath.Join(dataDir, "browser-profiles", strconv.FormatInt(profileID, 10))
+}
+
+// loginDataDir is the Chrome user data directory a manual login keeps its
+// session in when no profile signs in with email yet
+func loginDataDir(dataDir, email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return filepath.Join(dataDir, "browser-profiles", "login-"+hex.EncodeToString(sum[:8]))
+}
+
+// adoptLoginDataDir moves a manual login's user data directory for the
+// profile's email to userData, so the profile starts signed in. A profile
+// that already has a user data directory keeps it.
+func (s *AppService) adoptLoginDataDir(dataDir string, profileID int64, userData string) {
+	if s.store == nil {
+		return
+	}
+	profile, err := s.store.GetLinkedInProfile(profileID)
+	if err != nil {
+		return
+	}
+	from := loginDataDir(dataDir, profile.Email)
+	if _, err := os.Stat(from); err != nil {
+		return
+	}
+	if _, err := os.Stat(userData); err == nil {
+		return
+	}
+	if err := os.Rename(from, userData); err != nil {
+		fmt.Printf("⚠️ Could not keep the login session for %s: %v\n", profile.Email, err)
+	}
+}
+
+// profileIDForEmail finds the profile that signs in with email
+func (s *AppService) profileIDForEmail(email string) (int64, bool) {
+	if s.store == nil || email == "" {
+		return 0, false
+	}
+	profiles, err := s.store.SearchLinkedInProfiles(store.ProfileFilter{EmailContains: email})
+	if err != nil {
+		return 0, false
+	}
+	for _, profile := range profiles {
+		if strings.EqualFold(profile.Email, email) {
+			return profile.ID, true
+		}
+	}
+	return 0, false
+}
+
+// allBrowsers returns every profile's browser manager created so far
+func (s *AppService) allBrowsers() map[int64]*browser.BrowserManager {
+	s.browsersMu.Lock()
+	defer s.browsersMu.Unlock()
+	return maps.Clone(s.browsers)
+}
+
+// dropBrowsers closes the profiles' browsers, ending their apply runs, and
+// forgets their managers so the next use builds them afresh
+func (s *AppService) dropBrowsers(profileIDs []int64) error {
+	for _, id := range profileIDs {
+		if err := s.StopProfileBrowser(id); err != nil {
+			return fmt.Errorf("failed to close browser for profile %d: %w", id, err)
+		}
+	}
+	s.browsersMu.Lock()
+	defer s.browsersMu.Unlock()
+	for _, id := range profileIDs {
+		delete(s.browsers, id)
+	}
+	return nil
+}
+
+// removeBrowserData deletes the profiles' Chrome user data directories,
+// along with the LinkedIn sessions kept in them
+func removeBrowserData(dataDir string, profileIDs []int64) {
+	for _, id := range profileIDs {
+		if err := os.RemoveAll(userDataDir(dataDir, id)); err != nil {
+			fmt.Printf("⚠️ Could not remove browser data for profile %d: %v\n", id, err)
+		}
+	}
+}
+
+// anyBrowserRunning reports whether any profile's browser is open
+func (s *AppService) anyBrowserRunning() bool {
+	for _, bm := range s.allBrowsers() {
+		if bm.IsRunning() {
+			return true
+		}
+	}
+	return false
+}
+
+// loadCooldown returns when the profile's persisted rate-limit cooldown
+// ends. A cooldown saved before cooldowns were tracked per profile applies
+// to every profile.
+func (s *AppService) loadCooldown(profileID int64) time.Time {
+	var until time.Time
+	for _, key := range []string{store.SettingRateLimitedUntil, store.ProfileSetting(store.SettingRateLimitedUntil, profileID)} {
+		value, ok, err := s.store.GetSetting(key)
+		if err != nil || !ok {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil && t.After(until) {
+			until = t
+		}
+	}
+	return until
+}
+
+// saveCooldown persists the profile browser's rate-limit cooldown so a
+// restart doesn't trip LinkedIn's limit again
+func (s *AppService) saveCooldown(profileID int64, bm *browser.BrowserManager) {
+	until := bm.CooldownUntil()
+	if s.store == nil || until.IsZero() {
+		return
+	}
+	key := store.ProfileSetting(store.SettingRateLimitedUntil, profileID)
+	if err := s.store.SetSetting(key, until.Format(time.RFC3339)); err != nil {
+		fmt.Println("❌ Failed to save rate-limit cooldown:", err)
+	}
+}
+
+// StartApplyingAll applies for every profile at once, each in its own
+// browser, with at most SettingMaxConcurrentBrowsers browsers open at a
+// time. It returns once every profile has finished, joining their errors.
+func (s *AppService) StartApplyingAll() error {
+	if s.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	profiles, err := s.store.ListLinkedInProfiles()
+	if err != nil {
+		return err
+	}
+	limit := defaultMaxConcurrentBrowsers
+	if n, err := s.store.GetIntSetting(store.SettingMaxConcurrentBrowsers, 0); err == nil && n > 0 {
+		limit = n
+	}
+
+	fmt.Printf("⚪ Applying for %d profiles, %d at a time\n", len(profiles), limit)
+	slots := make(chan struct{}, limit)
+	errs := make([]error, len(profiles))
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			bm := s.browserFor(profile.ID)
+			wasRunning := bm.IsRunning()
+			err := s.StartApplying(int(profile.ID), 0)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", profile.Email, err)
+			}
+			// Free the slot's browser for the next profile, leaving one
+			// another run opened, such as a run started from the UI
+			if wasRunning || errors.Is(err, browser.ErrAlreadyApplying) {
+				return
+			}
+			if err := bm.Close(); err != nil {
+				fmt.Printf("❌ Failed to close browser for %s: %v\n", profile.Email, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.app.Event.Emit("browser:all-completed", nil)
+	return errors.Join(errs...)
+}
+
+// StopProfileBrowser closes one profile's browser, ending its apply run
+func (s *AppService) StopProfileBrowser(profileID int64) error {
+	bm, ok := s.allBrowsers()[profileID]
+	if !ok {
+		return nil
+	}
+	if err := stopBrowser(bm); err != nil {
+		return err
+	}
+	s.app.Event.Emit("browser:stopped", map[string]interface{}{
+		"profileId": profileID,
+	})
+	return nil
+}
+
+// stopBrowser closes bm and clears its apply session
+func stopBrowser(bm *browser.BrowserManager) error {
+	if err := bm.Close(); err != nil {
+		return err
+	}
+	bm.SetApplying(false)
+	return nil
+}
+
+// GetProfileBrowserStatus reports the state of one profile's browser
+func (s *AppService) GetProfileBrowserStatus(profileID int64) BrowserStatus {
+	status := s.downloadStatus()
+	if bm, ok := s.allBrowsers()[profileID]; ok {
+		status.Running = bm.IsRunning()
+		status.Applying = bm.IsApplying()
+		status.Paused = bm.IsPaused()
+		status.CooldownUntil = bm.CooldownUntil()
+	} else if s.store != nil {
+		status.CooldownUntil = s.loadCooldown(profileID)
+		if status.CooldownUntil.Before(time.Now()) {
+			status.CooldownUntil = time.Time{}
+		}
+	}
+	return status
+}
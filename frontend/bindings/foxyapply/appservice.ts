@@ -61,8 +61,8 @@ export function SetApplying(applying: boolean): $CancellablePromise<void> {
     return $Call.ByID(2473383070, applying);
 }
 
-export function StartApplying(profileId: number): $CancellablePromise<void> {
-    return $Call.ByID(2152928934, profileId);
+export function StartApplying(profileId: number, maxJobs: number): $CancellablePromise<void> {
+    return $Call.ByID(2152928934, profileId, maxJobs);
 }
 
 export function StartBrowser(email: string, password: string): $CancellablePromise<boolean> {
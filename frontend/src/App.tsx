@@ -102,7 +102,7 @@ function App() {
       }
       await SetApplying(true)
       await refreshStatus()
-      await StartApplying(selectedProfile)
+      await StartApplying(selectedProfile, 0)
     } catch (e) {
       setError(`Failed to start applying: ${e}`)
     }
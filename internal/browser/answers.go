@@ -0,0 +1,135 @@
+package browser
+
+import (
+	"foxyapply/internal/store"
+	"log"
+	"strings"
+	"sync"
+)
+
+// AnswerCache remembers AnswerProvider answers so a question asked on many
+// postings is only answered once. Implementations must be safe for
+// concurrent use.
+type AnswerCache interface {
+	Get(key string) (string, bool)
+	Set(key, answer string)
+}
+
+// memoryAnswerCache is the default AnswerCache, kept for one apply run
+type memoryAnswerCache struct {
+	mu      sync.Mutex
+	answers map[string]string
+}
+
+// NewAnswerCache returns an empty in-memory AnswerCache
+func NewAnswerCache() AnswerCache {
+	return &memoryAnswerCache{answers: map[string]string{}}
+}
+
+func (c *memoryAnswerCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	answer, ok := c.answers[key]
+	return answer, ok
+}
+
+func (c *memoryAnswerCache) Set(key, answer string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.answers[key] = answer
+}
+
+// answerKey identifies a question by its input type and normalized label
+func answerKey(label, inputType string) string {
+	return strings.ToLower(strings.TrimSpace(inputType)) + "|" + store.NormalizeQuestion(label)
+}
+
+// CachedAnswerProvider wraps provider so each question is answered once
+// per cache. Errors and blank answers aren't cached, so those questions
+// are asked again next time.
+func CachedAnswerProvider(provider AnswerProvider, cache AnswerCache) AnswerProvider {
+	if provider == nil || cache == nil {
+		return provider
+	}
+	return func(label, inputType string) (string, error) {
+		key := answerKey(label, inputType)
+		if answer, ok := cache.Get(key); ok {
+			return answer, nil
+		}
+		answer, err := provider(label, inputType)
+		if err != nil || strings.TrimSpace(answer) == "" {
+			return answer, err
+		}
+		cache.Set(key, answer)
+		return answer, nil
+	}
+}
+
+// AnswerMemory stores a profile's answers to questions between runs.
+// *store.Store implements it.
+type AnswerMemory interface {
+	GetQuestionAnswer(profileID int64, question, inputType string) (*store.QuestionAnswer, error)
+	SaveQuestionAnswer(profileID int64, question, inputType, answer string) (*store.QuestionAnswer, error)
+}
+
+// reasonAnswerProvider is the fill reason for answers from the AnswerProvider
+const reasonAnswerProvider = "answer provider"
+
+// rememberedAnswer returns the profile's stored answer to q, falling back
+// to choose. What choose returns is stored only when remember says so, so
+// values read from the profile follow later edits to it. Without a memory
+// or a saved profile it just calls choose. reason says where the answer
+// came from.
+func rememberedAnswer(memory AnswerMemory, profile *store.LinkedInProfile, q Question, choose func() (string, string), remember func(reason string) bool) (answer, reason string) {
+	if memory == nil || profile == nil || profile.ID == 0 {
+		return choose()
+	}
+
+	qa, err := memory.GetQuestionAnswer(profile.ID, q.Label, q.InputType)
+	if err != nil {
+		log.Printf("Failed to look up answer for '%s': %v", q.Label, err)
+	}
+	if qa != nil {
+		return qa.Answer, "remembered answer"
+	}
+
+	answer, reason = choose()
+	if err == nil && strings.TrimSpace(answer) != "" && remember(reason) {
+		if _, err := memory.SaveQuestionAnswer(profile.ID, q.Label, q.InputType, answer); err != nil {
+			log.Printf("Failed to remember answer for '%s': %v", q.Label, err)
+		}
+	}
+	return answer, reason
+}
+
+// SetAnswerMemory sets where answers to application questions are
+// remembered between runs
+func (bm *BrowserManager) SetAnswerMemory(memory AnswerMemory) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.answerMemory = memory
+}
+
+// SetAnswerProvider sets how questions the profile can't answer are
+// answered, typically by an LLM. nil leaves them to the built-in defaults.
+func (bm *BrowserManager) SetAnswerProvider(provider AnswerProvider) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.answers = provider
+}
+
+// SetAnswerCache makes every apply run share cache, e.g. one persisted
+// between runs. By default each run starts with an empty cache.
+func (bm *BrowserManager) SetAnswerCache(cache AnswerCache) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.answerCache = cache
+}
+
+// runAnswerProvider returns the current run's cached AnswerProvider, or
+// nil if none is set
+func (bm *BrowserManager) runAnswerProvider() AnswerProvider {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return bm.runAnswers
+}
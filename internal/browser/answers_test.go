@@ -0,0 +1,212 @@
+package browser
+
+import (
+	"errors"
+	"foxyapply/internal/store"
+	"testing"
+)
+
+// countingProvider is an AnswerProvider that records how often it's asked
+type countingProvider struct {
+	calls  int
+	answer string
+	err    error
+}
+
+func (p *countingProvider) ask(label, inputType string) (string, error) {
+	p.calls++
+	return p.answer, p.err
+}
+
+func TestCachedAnswerProviderReusesAnswers(t *testing.T) {
+	provider := &countingProvider{answer: "Because I love the product"}
+	cached := CachedAnswerProvider(provider.ask, NewAnswerCache())
+
+	for _, label := range []string{
+		"Why do you want to work here?",
+		"  why do you WANT to work   here ",
+		"Why do you want to work here? *",
+	} {
+		answer, err := cached(label, "textarea")
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", label, err)
+		}
+		if answer != provider.answer {
+			t.Errorf("%q: expected %q, got %q", label, provider.answer, answer)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected provider to be asked once, got %d", provider.calls)
+	}
+
+	// The same label on a different input type is a different question
+	if _, err := cached("Why do you want to work here?", "text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("expected provider to be asked for a new input type, got %d calls", provider.calls)
+	}
+}
+
+func TestCachedAnswerProviderSkipsFailures(t *testing.T) {
+	provider := &countingProvider{err: errors.New("rate limited")}
+	cached := CachedAnswerProvider(provider.ask, NewAnswerCache())
+
+	if _, err := cached("Salary expectations", "text"); err == nil {
+		t.Fatal("expected provider error to be returned")
+	}
+	provider.err = nil
+	if _, err := cached("Salary expectations", "text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	provider.answer = "120000"
+	for i := 0; i < 2; i++ {
+		if answer, _ := cached("Salary expectations", "text"); answer != "120000" {
+			t.Errorf("expected %q, got %q", "120000", answer)
+		}
+	}
+	// Error and blank answers are retried, then the real answer is cached
+	if provider.calls != 3 {
+		t.Errorf("expected 3 provider calls, got %d", provider.calls)
+	}
+}
+
+func TestApplyRunsShareInjectedCache(t *testing.T) {
+	provider := &countingProvider{answer: "Yes"}
+	bm := NewBrowserManager(nil)
+	bm.SetAnswerProvider(provider.ask)
+
+	ask := func() {
+		t.Helper()
+		if !bm.beginApplying() {
+			t.Fatal("expected to claim the apply session")
+		}
+		if _, err := bm.runAnswerProvider()("Are you willing to relocate?", "text"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		bm.SetApplying(false)
+	}
+
+	// Without an injected cache each run starts empty
+	ask()
+	ask()
+	if provider.calls != 2 {
+		t.Fatalf("expected one provider call per run, got %d", provider.calls)
+	}
+
+	provider.calls = 0
+	bm.SetAnswerCache(NewAnswerCache())
+	ask()
+	ask()
+	if provider.calls != 1 {
+		t.Errorf("expected an injected cache to be shared across runs, got %d calls", provider.calls)
+	}
+}
+
+func TestFillInvalidsAsksProviderOncePerQuestion(t *testing.T) {
+	provider := &countingProvider{answer: "I build tools people use every day"}
+	cached := CachedAnswerProvider(provider.ask, NewAnswerCache())
+
+	// The same question on two steps of the Easy Apply form
+	for _, label := range []string{"What excites you about this role?", "What excites you about this role? *"} {
+		textarea := &fakeElement{attrs: map[string]string{"required": ""}, label: label}
+		form := &fakeForm{textareas: []*fakeElement{textarea}}
+		if err := fillInvalids(form, &store.LinkedInProfile{}, fillOptions{answers: cached}); err != nil {
+			t.Fatalf("fillInvalids failed: %v", err)
+		}
+		if len(textarea.typed) != 1 || textarea.typed[0] != provider.answer {
+			t.Errorf("%q: typed %q, want %q", label, textarea.typed, provider.answer)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected provider to be asked once, got %d", provider.calls)
+	}
+}
+
+// fakeMemory is an AnswerMemory backed by a map of answerKey keys
+type fakeMemory struct {
+	answers map[string]string
+}
+
+func (m *fakeMemory) GetQuestionAnswer(profileID int64, question, inputType string) (*store.QuestionAnswer, error) {
+	answer, ok := m.answers[answerKey(question, inputType)]
+	if !ok {
+		return nil, nil
+	}
+	return &store.QuestionAnswer{ProfileID: profileID, QuestionText: question, InputType: inputType, Answer: answer}, nil
+}
+
+func (m *fakeMemory) SaveQuestionAnswer(profileID int64, question, inputType, answer string) (*store.QuestionAnswer, error) {
+	m.answers[answerKey(question, inputType)] = answer
+	return &store.QuestionAnswer{ProfileID: profileID, QuestionText: question, InputType: inputType, Answer: answer}, nil
+}
+
+func TestFillInvalidsRemembersAnswers(t *testing.T) {
+	profile := &store.LinkedInProfile{ID: 1, YearsExperience: 7}
+	memory := &fakeMemory{answers: map[string]string{
+		"text|how many years of experience do you have with kubernetes": "3",
+	}}
+	provider := &countingProvider{answer: "Through a friend"}
+
+	remembered := &fakeElement{
+		attrs: map[string]string{"required": "", "type": "text"},
+		label: "How many years of experience do you have with Kubernetes?",
+	}
+	computed := &fakeElement{
+		attrs: map[string]string{"required": ""},
+		label: "How did you hear about this role?",
+	}
+	form := &fakeForm{
+		inputs:    []*fakeElement{remembered},
+		textareas: []*fakeElement{computed},
+	}
+	if err := fillInvalids(form, profile, fillOptions{answers: provider.ask, memory: memory}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+
+	// The stored answer wins over the profile's years of experience
+	if len(remembered.typed) != 1 || remembered.typed[0] != "3" {
+		t.Errorf("expected remembered answer %q, typed %q", "3", remembered.typed)
+	}
+	if len(computed.typed) != 1 || computed.typed[0] != provider.answer {
+		t.Errorf("expected provider answer %q, typed %q", provider.answer, computed.typed)
+	}
+	if got := memory.answers["textarea|how did you hear about this role"]; got != provider.answer {
+		t.Errorf("expected new answer to be remembered, got %q", got)
+	}
+
+	// The next form with the same question doesn't ask the provider again
+	again := &fakeElement{attrs: map[string]string{"required": ""}, label: "How did you hear about this role? *"}
+	if err := fillInvalids(&fakeForm{textareas: []*fakeElement{again}}, profile, fillOptions{answers: provider.ask, memory: memory}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if provider.calls != 1 || len(again.typed) != 1 || again.typed[0] != provider.answer {
+		t.Errorf("expected remembered answer without asking again, got %d calls, typed %q", provider.calls, again.typed)
+	}
+
+	// Values read from the profile aren't remembered, so editing the
+	// profile changes them; answers to questions it doesn't cover are
+	profile.PhoneNumber = "555 123 4567"
+	phone := &fakeElement{attrs: map[string]string{"required": "", "type": "text"}, label: "Phone number"}
+	unknown := &fakeElement{attrs: map[string]string{"required": "", "type": "text"}, label: "Ethnicity"}
+	numeric := &fakeElement{attrs: map[string]string{"required": "", "type": "number"}, label: "Ethnicity"}
+	form = &fakeForm{inputs: []*fakeElement{phone, unknown}}
+	if err := fillInvalids(form, profile, fillOptions{memory: memory}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if _, ok := memory.answers["text|phone number"]; ok {
+		t.Errorf("expected the profile's phone number not to be remembered, got %v", memory.answers)
+	}
+	if got := memory.answers["text|ethnicity"]; got != "7" {
+		t.Errorf("expected the unrecognised question's answer to be remembered, got %q", got)
+	}
+
+	// The same label in another kind of field is a different question
+	memory.answers["text|ethnicity"] = "Prefer not to say"
+	if err := fillInvalids(&fakeForm{inputs: []*fakeElement{numeric}}, profile, fillOptions{memory: memory}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if len(numeric.typed) != 1 || numeric.typed[0] != "7" {
+		t.Errorf("expected the number field not to reuse the text answer, typed %q", numeric.typed)
+	}
+}
@@ -0,0 +1,71 @@
+package browser
+
+import (
+	"fmt"
+	"foxyapply/internal/store"
+	"log"
+	"slices"
+)
+
+// ApplyCheckpointer remembers where in the job search a profile's apply
+// run got to. *store.Store implements it; StartApplying uses it through
+// the recorder.
+type ApplyCheckpointer interface {
+	SaveApplyCheckpoint(profileID int64, checkpoint store.ApplyCheckpoint) error
+	LoadApplyCheckpoint(profileID int64) (*store.ApplyCheckpoint, error)
+	ClearApplyCheckpoint(profileID int64) error
+}
+
+// checkpointer returns the recorder as an ApplyCheckpointer, or nil if it
+// can't save checkpoints
+func (bm *BrowserManager) checkpointer() ApplyCheckpointer {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	checkpointer, _ := bm.recorder.(ApplyCheckpointer)
+	return checkpointer
+}
+
+// resumePoint returns the profile's saved checkpoint if the run can pick up
+// from it, or nil. A checkpoint whose position or location has since been
+// removed from the profile is ignored.
+func (bm *BrowserManager) resumePoint(profile *store.LinkedInProfile) *store.ApplyCheckpoint {
+	checkpointer := bm.checkpointer()
+	if checkpointer == nil {
+		return nil
+	}
+	checkpoint, err := checkpointer.LoadApplyCheckpoint(profile.ID)
+	if err != nil {
+		log.Printf("Failed to load apply checkpoint: %v", err)
+		return nil
+	}
+	if checkpoint == nil || checkpoint.Start < 0 ||
+		!slices.Contains(profile.Positions, checkpoint.Position) ||
+		!slices.Contains(profile.Locations, checkpoint.Location) {
+		return nil
+	}
+	return checkpoint
+}
+
+// saveCheckpoint records that the run has worked through the search
+// results before start. Failures are logged, not fatal.
+func (bm *BrowserManager) saveCheckpoint(profileID int64, position, location string, start int) {
+	checkpointer := bm.checkpointer()
+	if checkpointer == nil {
+		return
+	}
+	err := checkpointer.SaveApplyCheckpoint(profileID, store.ApplyCheckpoint{Position: position, Location: location, Start: start})
+	if err != nil {
+		fmt.Printf("⚠️ Could not save apply progress: %v\n", err)
+	}
+}
+
+// clearCheckpoint forgets the profile's checkpoint once a run finishes
+func (bm *BrowserManager) clearCheckpoint(profileID int64) {
+	checkpointer := bm.checkpointer()
+	if checkpointer == nil {
+		return
+	}
+	if err := checkpointer.ClearApplyCheckpoint(profileID); err != nil {
+		fmt.Printf("⚠️ Could not clear apply progress: %v\n", err)
+	}
+}
@@ -0,0 +1,90 @@
+package browser
+
+import (
+	"errors"
+	"foxyapply/internal/store"
+	"testing"
+)
+
+// fakeCheckpointer is a recorder that keeps apply checkpoints in memory
+type fakeCheckpointer struct {
+	fakeRecorder
+	checkpoints map[int64]store.ApplyCheckpoint
+}
+
+func (c *fakeCheckpointer) SaveApplyCheckpoint(profileID int64, checkpoint store.ApplyCheckpoint) error {
+	c.checkpoints[profileID] = checkpoint
+	return nil
+}
+
+func (c *fakeCheckpointer) LoadApplyCheckpoint(profileID int64) (*store.ApplyCheckpoint, error) {
+	checkpoint, ok := c.checkpoints[profileID]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (c *fakeCheckpointer) ClearApplyCheckpoint(profileID int64) error {
+	delete(c.checkpoints, profileID)
+	return nil
+}
+
+func TestResumePoint(t *testing.T) {
+	checkpoints := &fakeCheckpointer{checkpoints: map[int64]store.ApplyCheckpoint{}}
+	bm := NewBrowserManager(nil)
+	bm.SetRecorder(checkpoints)
+	profile := &store.LinkedInProfile{ID: 1, Positions: []string{"Go Engineer"}, Locations: []string{"Berlin", "Remote"}}
+
+	if checkpoint := bm.resumePoint(profile); checkpoint != nil {
+		t.Errorf("expected nothing to resume, got %+v", checkpoint)
+	}
+
+	bm.saveCheckpoint(profile.ID, "Go Engineer", "Remote", 50)
+	checkpoint := bm.resumePoint(profile)
+	if checkpoint == nil || checkpoint.Position != "Go Engineer" || checkpoint.Location != "Remote" || checkpoint.Start != 50 {
+		t.Errorf("expected to resume Go Engineer in Remote at 50, got %+v", checkpoint)
+	}
+
+	// A search the profile no longer runs starts over
+	profile.Locations = []string{"Berlin"}
+	if checkpoint := bm.resumePoint(profile); checkpoint != nil {
+		t.Errorf("expected a removed location not to resume, got %+v", checkpoint)
+	}
+
+	bm.clearCheckpoint(profile.ID)
+	if len(checkpoints.checkpoints) != 0 {
+		t.Errorf("expected the checkpoint cleared, got %v", checkpoints.checkpoints)
+	}
+
+	// Recorders without checkpoints are ignored
+	bm.SetRecorder(&fakeRecorder{})
+	bm.saveCheckpoint(profile.ID, "Go Engineer", "Berlin", 25)
+	if checkpoint := bm.resumePoint(profile); checkpoint != nil {
+		t.Errorf("expected no checkpoint without a checkpointer, got %+v", checkpoint)
+	}
+}
+
+func TestEndOfResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		listed, fresh int
+		done          bool
+		err           error
+	}{
+		{"more jobs", 25, 10, false, nil},
+		{"empty results page", 0, 0, true, ErrNoJobsFound},
+		{"only seen jobs", 25, 0, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, err := endOfResults(tt.listed, tt.fresh)
+			if done != tt.done {
+				t.Errorf("expected done=%v, got %v", tt.done, done)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
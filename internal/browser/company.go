@@ -0,0 +1,90 @@
+package browser
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// CompanyKey normalizes a company name scraped by ParseJobDetails so the
+// same employer compares equal however LinkedIn spaced or capitalized it.
+// Anything that matches jobs by company should compare these keys.
+func CompanyKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// companyThrottle counts applications per company and refuses more once a
+// company reaches max. A nil *companyThrottle allows everything. It's only
+// used from the apply loop, so it isn't locked.
+type companyThrottle struct {
+	max     int
+	applied map[string]int // Applications per CompanyKey
+}
+
+// newCompanyThrottle returns a throttle allowing max applications per
+// company, or nil when max <= 0
+func newCompanyThrottle(max int) *companyThrottle {
+	if max <= 0 {
+		return nil
+	}
+	return &companyThrottle{max: max, applied: map[string]int{}}
+}
+
+// allow reports whether another application to company fits the limit.
+// Jobs whose company couldn't be read are always allowed.
+func (c *companyThrottle) allow(company string) bool {
+	key := CompanyKey(company)
+	if c == nil || key == "" {
+		return true
+	}
+	return c.applied[key] < c.max
+}
+
+// record counts n more applications to company
+func (c *companyThrottle) record(company string, n int) {
+	key := CompanyKey(company)
+	if c == nil || key == "" {
+		return
+	}
+	c.applied[key] += n
+}
+
+// startCompanyThrottle starts Config.MaxPerCompany for an apply session.
+// With Config.CompanyLimitWindow set, applications recorded in that window
+// before the session count too. The returned func ends it with the session.
+func (bm *BrowserManager) startCompanyThrottle(profileID int64) func() {
+	companies := newCompanyThrottle(bm.cfg.MaxPerCompany)
+	if companies != nil && bm.cfg.CompanyLimitWindow > 0 {
+		bm.mu.RLock()
+		counter, ok := bm.recorder.(CompanyApplicationCounter)
+		bm.mu.RUnlock()
+		if ok {
+			counts, err := counter.CompanyApplicationCounts(profileID, time.Now().Add(-bm.cfg.CompanyLimitWindow))
+			if err != nil {
+				log.Printf("Failed to count recent applications by company: %v", err)
+			}
+			for company, n := range counts {
+				companies.record(company, n)
+			}
+		}
+	}
+
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.companies = companies
+	return func() {
+		bm.mu.Lock()
+		defer bm.mu.Unlock()
+		if bm.companies == companies {
+			bm.companies = nil
+		}
+	}
+}
+
+// companyThrottle returns the running session's per-company throttle, or
+// nil outside a session or without a limit
+func (bm *BrowserManager) companyThrottle() *companyThrottle {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return bm.companies
+}
@@ -0,0 +1,106 @@
+package browser
+
+import (
+	"foxyapply/internal/store"
+	"testing"
+	"time"
+)
+
+func TestCompanyKey(t *testing.T) {
+	if CompanyKey("  Acme   Corp ") != CompanyKey("acme corp") {
+		t.Errorf("expected spacing and case to be ignored, got %q", CompanyKey("  Acme   Corp "))
+	}
+}
+
+func TestCompanyThrottle(t *testing.T) {
+	companies := newCompanyThrottle(2)
+	for i := 0; i < 2; i++ {
+		if !companies.allow("Acme") {
+			t.Fatalf("expected application %d to Acme to be allowed", i+1)
+		}
+		companies.record("Acme", 1)
+	}
+	if companies.allow("ACME ") {
+		t.Error("expected Acme to be throttled after 2 applications")
+	}
+	if !companies.allow("Globex") {
+		t.Error("expected other companies to be unaffected")
+	}
+	if !companies.allow("") {
+		t.Error("expected jobs without a company to be allowed")
+	}
+
+	// Earlier applications count toward the limit
+	companies = newCompanyThrottle(2)
+	companies.record("Globex", 2)
+	if companies.allow("Globex") {
+		t.Error("expected recorded history to count")
+	}
+
+	unlimited := newCompanyThrottle(0)
+	unlimited.record("Acme", 10)
+	if unlimited != nil || !unlimited.allow("Acme") {
+		t.Error("expected no throttle without a limit")
+	}
+}
+
+// fakeCompanyCounter is a recorder that also reports recent applications
+// per company
+type fakeCompanyCounter struct {
+	fakeRecorder
+	counts map[string]int
+	since  time.Time
+}
+
+func (c *fakeCompanyCounter) CompanyApplicationCounts(profileID int64, since time.Time) (map[string]int, error) {
+	c.since = since
+	return c.counts, nil
+}
+
+func TestApplyToJobSkipsAtCompanyLimit(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	detail := func(company string) string {
+		return `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>
+			<div class="job-details-jobs-unified-top-card__company-name"><a>` + company + `</a></div>`
+	}
+	page := &fakePage{
+		pages:     map[string]string{jobURL(1): detail("Acme"), jobURL(2): detail("Acme"), jobURL(3): detail("Globex"), jobURL(4): detail("Initech")},
+		clickable: map[string]bool{EasyApplyButtonSelector: true},
+	}
+	recorder := &fakeCompanyCounter{counts: map[string]int{"initech": 1}}
+	bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond, MaxPerCompany: 1, CompanyLimitWindow: 7 * 24 * time.Hour})
+	bm.SetRecorder(recorder)
+	stop := bm.startCompanyThrottle(1)
+	defer stop()
+	if time.Since(recorder.since) < 7*24*time.Hour-time.Minute {
+		t.Errorf("expected history counted from a week back, got %v", recorder.since)
+	}
+
+	want := map[int]string{
+		1: store.ApplicationStatusApplied,
+		2: store.ApplicationStatusSkipped,
+		3: store.ApplicationStatusApplied,
+		4: store.ApplicationStatusSkipped, // Applied to earlier in the window
+	}
+	for jobID := 1; jobID <= 4; jobID++ {
+		status, err := bm.applyToJob(page, &store.LinkedInProfile{}, jobID, func(string) (bool, error) {
+			return true, nil
+		})
+		if err != nil || status != want[jobID] {
+			t.Errorf("job %d: expected %q, got %q, %v", jobID, want[jobID], status, err)
+		}
+	}
+	for _, app := range recorder.apps {
+		if app.Status == store.ApplicationStatusSkipped && app.Reason != store.SkipReasonCompanyLimit {
+			t.Errorf("expected skips recorded as the company limit, got %+v", app)
+		}
+	}
+
+	// The limit ends with the session
+	stop()
+	if bm.companyThrottle() != nil {
+		t.Error("expected the throttle cleared after the session")
+	}
+}
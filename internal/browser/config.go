@@ -0,0 +1,185 @@
+package browser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// fileConfig is the JSON form of Config read by LoadConfig. Durations are
+// whole milliseconds, seconds or minutes so the file stays readable, and the per-profile
+// fields (IsApplying, UserData) are left out.
+type fileConfig struct {
+	Headless        bool   `json:"headless"`
+	BrowserBin      string `json:"browserBin"`
+	MaxApplications int    `json:"maxApplications"`
+
+	NavigationRetries        int `json:"navigationRetries"`
+	NavigationBackoffSeconds int `json:"navigationBackoffSeconds"`
+
+	ChallengeSelectors []string `json:"challengeSelectors"`
+
+	MaxRestarts       int `json:"maxRestarts"`
+	ApplyDelaySeconds int `json:"applyDelaySeconds"`
+
+	FollowCompany bool `json:"followCompany"`
+	SpoofLocation bool `json:"spoofLocation"`
+
+	OperationTimeoutSeconds int `json:"operationTimeoutSeconds"`
+
+	StartMinimized bool `json:"startMinimized"`
+
+	RateLimitCooldownMinutes int `json:"rateLimitCooldownMinutes"`
+
+	ApplicationsPerHour int `json:"applicationsPerHour"`
+
+	MaxConsecutiveFailures int `json:"maxConsecutiveFailures"`
+
+	ManualLogin        bool `json:"manualLogin"`
+	ManualLoginMinutes int  `json:"manualLoginMinutes"`
+
+	HumanTyping       bool `json:"humanTyping"`
+	MinKeyDelayMillis int  `json:"minKeyDelayMillis"`
+	MaxKeyDelayMillis int  `json:"maxKeyDelayMillis"`
+
+	Locale string `json:"locale"`
+
+	SessionTimeoutMinutes int `json:"sessionTimeoutMinutes"`
+
+	PosterPreference    string   `json:"posterPreference"`
+	HiringTeamSelectors []string `json:"hiringTeamSelectors"`
+
+	MaxPerCompany    int `json:"maxPerCompany"`
+	CompanyLimitDays int `json:"companyLimitDays"`
+
+	UserAgent string `json:"userAgent"`
+}
+
+// ConfigFieldError reports a config file field with an invalid value
+type ConfigFieldError struct {
+	Field  string // The field's JSON name
+	Reason string
+}
+
+func (e *ConfigFieldError) Error() string {
+	return fmt.Sprintf("invalid config field %q: %s", e.Field, e.Reason)
+}
+
+// LoadConfig reads browser settings from the JSON file at path, rejecting
+// unknown fields and out-of-range values with a ConfigFieldError naming the
+// field. A missing file returns nil so callers fall back to the defaults.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var fc fileConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&fc); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, &ConfigFieldError{Field: typeErr.Field, Reason: "must be " + typeErr.Type.String()}
+		}
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return nil, &ConfigFieldError{Field: strings.Trim(field, `"`), Reason: "unknown field"}
+		}
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := fc.validate(); err != nil {
+		return nil, err
+	}
+	return fc.config(), nil
+}
+
+// validate checks the values JSON decoding can't
+func (fc fileConfig) validate() error {
+	for _, f := range []struct {
+		name  string
+		value int
+	}{
+		{"maxApplications", fc.MaxApplications},
+		{"navigationRetries", fc.NavigationRetries},
+		{"navigationBackoffSeconds", fc.NavigationBackoffSeconds},
+		{"maxRestarts", fc.MaxRestarts},
+		{"applyDelaySeconds", fc.ApplyDelaySeconds},
+		{"operationTimeoutSeconds", fc.OperationTimeoutSeconds},
+		{"rateLimitCooldownMinutes", fc.RateLimitCooldownMinutes},
+		{"applicationsPerHour", fc.ApplicationsPerHour},
+		{"maxConsecutiveFailures", fc.MaxConsecutiveFailures},
+		{"manualLoginMinutes", fc.ManualLoginMinutes},
+		{"minKeyDelayMillis", fc.MinKeyDelayMillis},
+		{"maxKeyDelayMillis", fc.MaxKeyDelayMillis},
+		{"sessionTimeoutMinutes", fc.SessionTimeoutMinutes},
+		{"maxPerCompany", fc.MaxPerCompany},
+		{"companyLimitDays", fc.CompanyLimitDays},
+	} {
+		if f.value < 0 {
+			return &ConfigFieldError{Field: f.name, Reason: fmt.Sprintf("must not be negative, got %d", f.value)}
+		}
+	}
+
+	if fc.BrowserBin != "" && !fileExists(fc.BrowserBin) {
+		return &ConfigFieldError{Field: "browserBin", Reason: fmt.Sprintf("no browser at %s", fc.BrowserBin)}
+	}
+	for i, sel := range fc.ChallengeSelectors {
+		if strings.TrimSpace(sel) == "" {
+			return &ConfigFieldError{Field: "challengeSelectors", Reason: fmt.Sprintf("selector %d is empty", i)}
+		}
+	}
+	for i, sel := range fc.HiringTeamSelectors {
+		if strings.TrimSpace(sel) == "" {
+			return &ConfigFieldError{Field: "hiringTeamSelectors", Reason: fmt.Sprintf("selector %d is empty", i)}
+		}
+	}
+	if _, err := ParsePosterPreference(fc.PosterPreference); err != nil {
+		return &ConfigFieldError{Field: "posterPreference", Reason: `must be "", "recruiter" or "company"`}
+	}
+	return nil
+}
+
+// config converts fc to a Config
+func (fc fileConfig) config() *Config {
+	return &Config{
+		Headless:               fc.Headless,
+		BrowserBin:             fc.BrowserBin,
+		MaxApplications:        fc.MaxApplications,
+		NavigationRetries:      fc.NavigationRetries,
+		NavigationBackoff:      time.Duration(fc.NavigationBackoffSeconds) * time.Second,
+		ChallengeSelectors:     fc.ChallengeSelectors,
+		MaxRestarts:            fc.MaxRestarts,
+		ApplyDelay:             time.Duration(fc.ApplyDelaySeconds) * time.Second,
+		FollowCompany:          fc.FollowCompany,
+		SpoofLocation:          fc.SpoofLocation,
+		OperationTimeout:       time.Duration(fc.OperationTimeoutSeconds) * time.Second,
+		StartMinimized:         fc.StartMinimized,
+		RateLimitCooldown:      time.Duration(fc.RateLimitCooldownMinutes) * time.Minute,
+		ApplicationsPerHour:    fc.ApplicationsPerHour,
+		MaxConsecutiveFailures: fc.MaxConsecutiveFailures,
+		ManualLogin:            fc.ManualLogin,
+		ManualLoginTimeout:     time.Duration(fc.ManualLoginMinutes) * time.Minute,
+		Timing: Timing{
+			HumanTyping: fc.HumanTyping,
+			MinKeyDelay: time.Duration(fc.MinKeyDelayMillis) * time.Millisecond,
+			MaxKeyDelay: time.Duration(fc.MaxKeyDelayMillis) * time.Millisecond,
+		},
+		Locale:         fc.Locale,
+		SessionTimeout: time.Duration(fc.SessionTimeoutMinutes) * time.Minute,
+
+		PosterPreference:    PosterPreference(fc.PosterPreference),
+		HiringTeamSelectors: fc.HiringTeamSelectors,
+
+		MaxPerCompany:      fc.MaxPerCompany,
+		CompanyLimitWindow: time.Duration(fc.CompanyLimitDays) * 24 * time.Hour,
+
+		UserAgent: fc.UserAgent,
+	}
+}
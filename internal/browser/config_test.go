@@ -0,0 +1,115 @@
+package browser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a config file with the given contents to a temporary
+// directory and returns its path
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, `{
+		"headless": true,
+		"maxApplications": 25,
+		"applyDelaySeconds": 3,
+		"operationTimeoutSeconds": 45,
+		"rateLimitCooldownMinutes": 30,
+		"challengeSelectors": ["#captcha"],
+		"maxConsecutiveFailures": 4,
+		"manualLoginMinutes": 10,
+		"humanTyping": true,
+		"minKeyDelayMillis": 30,
+		"maxKeyDelayMillis": 90,
+		"sessionTimeoutMinutes": 120,
+		"posterPreference": "recruiter",
+		"hiringTeamSelectors": [".hirer-card"],
+		"maxPerCompany": 2,
+		"companyLimitDays": 7,
+		"userAgent": "Mozilla/5.0 Custom"
+	}`))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !cfg.Headless || cfg.MaxApplications != 25 || cfg.MaxConsecutiveFailures != 4 || cfg.MaxPerCompany != 2 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if cfg.ApplyDelay != 3*time.Second || cfg.OperationTimeout != 45*time.Second ||
+		cfg.RateLimitCooldown != 30*time.Minute || cfg.ManualLoginTimeout != 10*time.Minute ||
+		cfg.SessionTimeout != 2*time.Hour || cfg.CompanyLimitWindow != 7*24*time.Hour {
+		t.Errorf("expected durations in their units, got %+v", cfg)
+	}
+	if want := (Timing{HumanTyping: true, MinKeyDelay: 30 * time.Millisecond, MaxKeyDelay: 90 * time.Millisecond}); cfg.Timing != want {
+		t.Errorf("expected typing %+v, got %+v", want, cfg.Timing)
+	}
+	if len(cfg.ChallengeSelectors) != 1 || cfg.ChallengeSelectors[0] != "#captcha" {
+		t.Errorf("expected challenge selectors, got %v", cfg.ChallengeSelectors)
+	}
+	if cfg.UserAgent != "Mozilla/5.0 Custom" {
+		t.Errorf("expected the User-Agent, got %q", cfg.UserAgent)
+	}
+	if cfg.PosterPreference != PosterRecruiter || len(cfg.HiringTeamSelectors) != 1 {
+		t.Errorf("expected a recruiter preference with one selector, got %q, %v", cfg.PosterPreference, cfg.HiringTeamSelectors)
+	}
+
+	// Everything is optional
+	cfg, err = LoadConfig(writeConfig(t, `{}`))
+	if err != nil || cfg == nil || cfg.MaxApplications != 0 {
+		t.Errorf("expected an empty config to load with defaults, got %+v, %v", cfg, err)
+	}
+
+	cfg, err = LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	if cfg != nil || err != nil {
+		t.Errorf("expected nil for a missing file, got %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		field    string // Expected ConfigFieldError field, "" for other errors
+	}{
+		{"negative count", `{"maxApplications": -1}`, "maxApplications"},
+		{"negative duration", `{"applyDelaySeconds": -5}`, "applyDelaySeconds"},
+		{"negative key delay", `{"minKeyDelayMillis": -1}`, "minKeyDelayMillis"},
+		{"negative company limit", `{"maxPerCompany": -1}`, "maxPerCompany"},
+		{"wrong type", `{"headless": "yes"}`, "headless"},
+		{"fractional count", `{"applicationsPerHour": 1.5}`, "applicationsPerHour"},
+		{"unknown field", `{"maxAplications": 5}`, "maxAplications"},
+		{"missing browser", `{"browserBin": "/no/such/chrome"}`, "browserBin"},
+		{"empty selector", `{"challengeSelectors": ["#captcha", " "]}`, "challengeSelectors"},
+		{"empty hiring team selector", `{"hiringTeamSelectors": [""]}`, "hiringTeamSelectors"},
+		{"unknown poster preference", `{"posterPreference": "agency"}`, "posterPreference"},
+		{"malformed", `{"headless": true`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfig(writeConfig(t, tt.contents))
+			if err == nil {
+				t.Fatalf("expected an error, got %+v", cfg)
+			}
+			var fieldErr *ConfigFieldError
+			if tt.field == "" {
+				if errors.As(err, &fieldErr) {
+					t.Errorf("expected a parse error, got %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.field {
+				t.Errorf("expected an error for field %q, got %v", tt.field, err)
+			}
+		})
+	}
+}
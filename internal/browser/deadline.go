@@ -0,0 +1,82 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSessionTimeout is the cause of an apply session's context being
+// cancelled once Config.SessionTimeout has passed
+var ErrSessionTimeout = errors.New("apply session timed out")
+
+// StopReasonSessionTimeout is ApplySession.StopReason for a run stopped by
+// Config.SessionTimeout
+const StopReasonSessionTimeout = "session-timeout"
+
+// sessionDeadline cancels an apply session's context with
+// ErrSessionTimeout once the session has run for its timeout. Time spent
+// paused doesn't count. A nil *sessionDeadline never fires.
+type sessionDeadline struct {
+	mu        sync.Mutex
+	cancel    context.CancelCauseFunc
+	timer     *time.Timer // nil while paused
+	remaining time.Duration
+	resumed   time.Time // When the timer was last started
+}
+
+// newSessionDeadline returns a context derived from parent that is
+// cancelled after timeout of unpaused time. With a timeout <= 0 the
+// context only ends with parent and the deadline is nil. Call cancel to
+// release the context when the session ends.
+func newSessionDeadline(parent context.Context, timeout time.Duration) (ctx context.Context, d *sessionDeadline, cancel context.CancelFunc) {
+	ctx, cancelCause := context.WithCancelCause(parent)
+	if timeout <= 0 {
+		return ctx, nil, func() { cancelCause(context.Canceled) }
+	}
+	d = &sessionDeadline{cancel: cancelCause, remaining: timeout}
+	d.resume()
+	return ctx, d, func() {
+		d.pause()
+		cancelCause(context.Canceled)
+	}
+}
+
+// pause stops the clock until resume is called
+func (d *sessionDeadline) pause() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer == nil {
+		return
+	}
+	d.timer.Stop()
+	d.timer = nil
+	d.remaining -= time.Since(d.resumed)
+}
+
+// resume restarts the clock with the time left before the pause
+func (d *sessionDeadline) resume() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		return
+	}
+	d.resumed = time.Now()
+	d.timer = time.AfterFunc(max(d.remaining, 0), func() { d.cancel(ErrSessionTimeout) })
+}
+
+// sessionErr returns why ctx ended, such as ErrSessionTimeout, or nil
+// while it is still running
+func sessionErr(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
@@ -0,0 +1,104 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foxyapply/internal/store"
+	"testing"
+	"time"
+)
+
+func TestSessionDeadlineIgnoresPausedTime(t *testing.T) {
+	bm := NewBrowserManager(&Config{SessionTimeout: 50 * time.Millisecond})
+	ctx, cancel := bm.startSessionDeadline()
+	defer cancel()
+
+	bm.Pause()
+	time.Sleep(100 * time.Millisecond)
+	if err := sessionErr(ctx); err != nil {
+		t.Fatalf("expected paused time not to count, got %v", err)
+	}
+	bm.Resume()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the session to time out after resuming")
+	}
+	if err := sessionErr(ctx); !errors.Is(err, ErrSessionTimeout) {
+		t.Errorf("expected ErrSessionTimeout, got %v", err)
+	}
+}
+
+func TestSessionDeadlineStartsPaused(t *testing.T) {
+	bm := NewBrowserManager(&Config{SessionTimeout: 20 * time.Millisecond})
+	bm.Pause()
+	ctx, cancel := bm.startSessionDeadline()
+	defer cancel()
+
+	time.Sleep(60 * time.Millisecond)
+	if err := sessionErr(ctx); err != nil {
+		t.Errorf("expected a session started while paused not to run its clock, got %v", err)
+	}
+}
+
+func TestSessionDeadlineUnset(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	ctx, cancel := bm.startSessionDeadline()
+	bm.Pause()
+	bm.Resume()
+	if bm.deadline != nil || sessionErr(ctx) != nil {
+		t.Errorf("expected no deadline without a timeout, got %v", sessionErr(ctx))
+	}
+
+	// Ending the session isn't a timeout
+	cancel()
+	if err := sessionErr(ctx); errors.Is(err, ErrSessionTimeout) {
+		t.Errorf("expected a plain cancellation, got %v", err)
+	}
+}
+
+func TestApplyToJobsStopsAtSessionTimeout(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	detail := `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>`
+	page := &fakePage{
+		pages:     map[string]string{jobURL(1): detail, jobURL(2): detail, jobURL(3): detail},
+		clickable: map[string]bool{EasyApplyButtonSelector: true},
+	}
+	bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond, SessionTimeout: 50 * time.Millisecond})
+	bm.SetRecorder(&fakeRecorder{})
+	ctx, cancel := bm.startSessionDeadline()
+	defer cancel()
+
+	// The form never submits; it only gives up once the page's context ends
+	var attempted []int
+	apply := func(jobID int) (string, error) {
+		attempted = append(attempted, jobID)
+		return bm.applyToJob(page, &store.LinkedInProfile{}, jobID, func(string) (bool, error) {
+			<-ctx.Done()
+			return false, context.Cause(ctx)
+		})
+	}
+	wait := func() error { return sessionErr(ctx) }
+
+	var session ApplySession
+	done := make(chan error, 1)
+	go func() {
+		_, err := applyToJobs([]int{1, 2, 3}, &session, 0, nil, wait, apply)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrSessionTimeout) {
+			t.Errorf("expected ErrSessionTimeout, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the session timeout to stop a wedged job")
+	}
+	if fmt.Sprint(attempted) != "[1]" {
+		t.Errorf("expected no jobs after the timeout, attempted %v", attempted)
+	}
+}
@@ -2,41 +2,118 @@ package browser
 
 import (
 	"archive/zip"
+	"context"
+	"encoding/json"
 	"fmt"
+	"foxyapply/internal/store"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 // ChromeDownloader handles downloading Chrome for Testing
 type ChromeDownloader struct {
 	Version     string
 	DownloadDir string
+	BaseURL     string // Download host, defaults to ChromeForTestingBaseURL
+	VersionsURL string // Version list, defaults to ChromeForTestingVersionsURL
+
+	// OnExtract, when set, is called as archive entries are extracted
+	OnExtract func(extracted, total int)
+
+	mu       sync.Mutex
+	progress DownloadProgress
+}
+
+// Download phases reported in DownloadProgress
+const (
+	DownloadPhaseIdle        = ""
+	DownloadPhaseDownloading = "downloading"
+	DownloadPhaseExtracting  = "extracting"
+)
+
+// DownloadProgress is a snapshot of an in-progress download. While
+// extracting, Downloaded and Total count archive entries instead of bytes.
+type DownloadProgress struct {
+	Phase      string  `json:"phase"`
+	Downloaded int64   `json:"downloaded"`
+	Total      int64   `json:"total"`
+	Percent    float64 `json:"percent"`
+}
+
+// Progress returns the latest progress of the running download, with an
+// empty phase when nothing is downloading
+func (cd *ChromeDownloader) Progress() DownloadProgress {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	return cd.progress
+}
+
+// setProgress records the current phase and counters
+func (cd *ChromeDownloader) setProgress(phase string, done, total int64) {
+	var percent float64
+	if total > 0 {
+		percent = float64(done) / float64(total) * 100
+	}
+
+	cd.mu.Lock()
+	cd.progress = DownloadProgress{Phase: phase, Downloaded: done, Total: total, Percent: percent}
+	cd.mu.Unlock()
+}
+
+// ChromeForTestingBaseURL is the default host for Chrome for Testing builds
+const ChromeForTestingBaseURL = "https://storage.googleapis.com/chrome-for-testing-public"
+
+// ChromeForTestingPaths contains download paths (relative to BaseURL) for each platform
+var ChromeForTestingPaths = map[string]string{
+	"darwin-arm64":  "/%s/mac-arm64/chrome-mac-arm64.zip",
+	"darwin-amd64":  "/%s/mac-x64/chrome-mac-x64.zip",
+	"linux-amd64":   "/%s/linux64/chrome-linux64.zip",
+	"windows-amd64": "/%s/win64/chrome-win64.zip",
 }
 
-// ChromeForTestingURLs contains download URLs for each platform
-var ChromeForTestingURLs = map[string]string{
-	"darwin-arm64":  "https://storage.googleapis.com/chrome-for-testing-public/%s/mac-arm64/chrome-mac-arm64.zip",
-	"darwin-amd64":  "https://storage.googleapis.com/chrome-for-testing-public/%s/mac-x64/chrome-mac-x64.zip",
-	"linux-amd64":   "https://storage.googleapis.com/chrome-for-testing-public/%s/linux64/chrome-linux64.zip",
-	"windows-amd64": "https://storage.googleapis.com/chrome-for-testing-public/%s/win64/chrome-win64.zip",
+// ChromeForTestingVersionsURL lists every known-good version with its downloads
+const ChromeForTestingVersionsURL = "https://googlechromelabs.github.io/chrome-for-testing/known-good-versions-with-downloads.json"
+
+// ChromeForTestingPlatforms maps platform keys to Chrome for Testing platform names
+var ChromeForTestingPlatforms = map[string]string{
+	"darwin-arm64":  "mac-arm64",
+	"darwin-amd64":  "mac-x64",
+	"linux-amd64":   "linux64",
+	"windows-amd64": "win64",
 }
 
 // LatestStableVersion is the Chrome for Testing version to use
 // Update this when testing against new Chrome versions
 const LatestStableVersion = "131.0.6778.85"
 
-// NewChromeDownloader creates a downloader with default settings
+// NewChromeDownloader creates a downloader with default settings. Chrome is
+// kept under the data directory when store.DataDirEnv is set.
 func NewChromeDownloader() *ChromeDownloader {
+	if dir := store.DataDirOverride(); dir != "" {
+		return NewChromeDownloaderIn(dir)
+	}
+
 	homeDir, _ := os.UserHomeDir()
-	downloadDir := filepath.Join(homeDir, ".applyfox", "chrome")
+	return newChromeDownloader(filepath.Join(homeDir, ".applyfox", "chrome"))
+}
 
+// NewChromeDownloaderIn creates a downloader that keeps Chrome builds in a
+// "chrome" directory under dataDir
+func NewChromeDownloaderIn(dataDir string) *ChromeDownloader {
+	return newChromeDownloader(filepath.Join(dataDir, "chrome"))
+}
+
+func newChromeDownloader(downloadDir string) *ChromeDownloader {
 	return &ChromeDownloader{
 		Version:     LatestStableVersion,
 		DownloadDir: downloadDir,
+		BaseURL:     ChromeForTestingBaseURL,
+		VersionsURL: ChromeForTestingVersionsURL,
 	}
 }
 
@@ -48,11 +125,76 @@ func GetPlatformKey() string {
 // GetDownloadURL returns the download URL for the current platform
 func (cd *ChromeDownloader) GetDownloadURL() (string, error) {
 	platform := GetPlatformKey()
-	urlTemplate, ok := ChromeForTestingURLs[platform]
+	pathTemplate, ok := ChromeForTestingPaths[platform]
 	if !ok {
 		return "", fmt.Errorf("unsupported platform: %s", platform)
 	}
-	return fmt.Sprintf(urlTemplate, cd.Version), nil
+	baseURL := cd.BaseURL
+	if baseURL == "" {
+		baseURL = ChromeForTestingBaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + fmt.Sprintf(pathTemplate, cd.Version), nil
+}
+
+// ListAvailableVersions returns the Chrome for Testing versions that have a
+// Chrome build for the current platform, newest first
+func (cd *ChromeDownloader) ListAvailableVersions() ([]string, error) {
+	platform, ok := ChromeForTestingPlatforms[GetPlatformKey()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported platform: %s", GetPlatformKey())
+	}
+
+	versionsURL := cd.VersionsURL
+	if versionsURL == "" {
+		versionsURL = ChromeForTestingVersionsURL
+	}
+
+	resp, err := http.Get(versionsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch versions: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read versions: %w", err)
+	}
+	return parseAvailableVersions(data, platform)
+}
+
+// parseAvailableVersions extracts the versions with a Chrome download for
+// platform from a known-good-versions-with-downloads.json document. The
+// document lists versions oldest first; the result is newest first.
+func parseAvailableVersions(data []byte, platform string) ([]string, error) {
+	var doc struct {
+		Versions []struct {
+			Version   string `json:"version"`
+			Downloads struct {
+				Chrome []struct {
+					Platform string `json:"platform"`
+				} `json:"chrome"`
+			} `json:"downloads"`
+		} `json:"versions"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse versions: %w", err)
+	}
+
+	versions := []string{}
+	for i := len(doc.Versions) - 1; i >= 0; i-- {
+		v := doc.Versions[i]
+		for _, download := range v.Downloads.Chrome {
+			if download.Platform == platform {
+				versions = append(versions, v.Version)
+				break
+			}
+		}
+	}
+	return versions, nil
 }
 
 // GetBrowserPath returns the path to the downloaded browser executable
@@ -85,8 +227,9 @@ func (cd *ChromeDownloader) IsDownloaded() bool {
 	return err == nil
 }
 
-// Download downloads and extracts Chrome for Testing
-func (cd *ChromeDownloader) Download(progressFn func(downloaded, total int64)) error {
+// Download downloads and extracts Chrome for Testing. Cancelling ctx aborts
+// the transfer and removes the partial archive.
+func (cd *ChromeDownloader) Download(ctx context.Context, progressFn func(downloaded, total int64)) error {
 	if cd.IsDownloaded() {
 		return nil // Already downloaded
 	}
@@ -96,6 +239,9 @@ func (cd *ChromeDownloader) Download(progressFn func(downloaded, total int64)) e
 		return err
 	}
 
+	cd.setProgress(DownloadPhaseDownloading, 0, 0)
+	defer cd.setProgress(DownloadPhaseIdle, 0, 0)
+
 	// Create download directory
 	versionDir := filepath.Join(cd.DownloadDir, cd.Version)
 	if err := os.MkdirAll(versionDir, 0755); err != nil {
@@ -104,12 +250,25 @@ func (cd *ChromeDownloader) Download(progressFn func(downloaded, total int64)) e
 
 	// Download zip file
 	zipPath := filepath.Join(versionDir, "chrome.zip")
-	if err := cd.downloadFile(url, zipPath, progressFn); err != nil {
+	trackProgress := func(downloaded, total int64) {
+		cd.setProgress(DownloadPhaseDownloading, downloaded, total)
+		if progressFn != nil {
+			progressFn(downloaded, total)
+		}
+	}
+	if err := cd.downloadFile(ctx, url, zipPath, trackProgress); err != nil {
+		os.Remove(zipPath)
 		return fmt.Errorf("failed to download: %w", err)
 	}
 
 	// Extract zip
-	if err := cd.extractZip(zipPath, versionDir); err != nil {
+	trackExtract := func(extracted, total int) {
+		cd.setProgress(DownloadPhaseExtracting, int64(extracted), int64(total))
+		if cd.OnExtract != nil {
+			cd.OnExtract(extracted, total)
+		}
+	}
+	if err := cd.extractZip(zipPath, versionDir, trackExtract); err != nil {
 		return fmt.Errorf("failed to extract: %w", err)
 	}
 
@@ -127,9 +286,14 @@ func (cd *ChromeDownloader) Download(progressFn func(downloaded, total int64)) e
 	return nil
 }
 
-// downloadFile downloads a file from URL to destination
-func (cd *ChromeDownloader) downloadFile(url, dest string, progressFn func(downloaded, total int64)) error {
-	resp, err := http.Get(url)
+// downloadFile downloads a file from URL to destination, stopping between
+// reads once ctx is cancelled
+func (cd *ChromeDownloader) downloadFile(ctx context.Context, url, dest string, progressFn func(downloaded, total int64)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -145,21 +309,33 @@ func (cd *ChromeDownloader) downloadFile(url, dest string, progressFn func(downl
 	}
 	defer out.Close()
 
+	var reader io.Reader = &contextReader{ctx: ctx, reader: resp.Body}
 	if progressFn != nil {
 		// Wrap with progress tracking
-		reader := &progressReader{
-			reader:     resp.Body,
+		reader = &progressReader{
+			reader:     reader,
 			total:      resp.ContentLength,
 			progressFn: progressFn,
 		}
-		_, err = io.Copy(out, reader)
-	} else {
-		_, err = io.Copy(out, resp.Body)
 	}
+	_, err = io.Copy(out, reader)
 
 	return err
 }
 
+// contextReader wraps an io.Reader so reads fail once ctx is done
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.reader.Read(p)
+}
+
 // progressReader wraps an io.Reader to track progress
 type progressReader struct {
 	reader     io.Reader
@@ -177,15 +353,24 @@ func (pr *progressReader) Read(p []byte) (int, error) {
 	return n, err
 }
 
-// extractZip extracts a zip file to destination directory
-func (cd *ChromeDownloader) extractZip(src, dest string) error {
+// extractZip extracts a zip file to destination directory. If progressFn is
+// non-nil it receives the number of entries extracted so far out of the total.
+func (cd *ChromeDownloader) extractZip(src, dest string, progressFn func(extracted, total int)) error {
 	r, err := zip.OpenReader(src)
 	if err != nil {
 		return err
 	}
 	defer r.Close()
 
-	for _, f := range r.File {
+	total := len(r.File)
+	lastPercent := -1
+	for i, f := range r.File {
+		// Report each whole percent so large archives don't flood listeners
+		if percent := i * 100 / total; progressFn != nil && percent != lastPercent {
+			lastPercent = percent
+			progressFn(i, total)
+		}
+
 		fpath := filepath.Join(dest, f.Name)
 
 		// Check for zip slip vulnerability
@@ -222,6 +407,9 @@ func (cd *ChromeDownloader) extractZip(src, dest string) error {
 		}
 	}
 
+	if progressFn != nil {
+		progressFn(total, total)
+	}
 	return nil
 }
 
@@ -229,3 +417,41 @@ func (cd *ChromeDownloader) extractZip(src, dest string) error {
 func (cd *ChromeDownloader) Cleanup() error {
 	return os.RemoveAll(cd.DownloadDir)
 }
+
+// validVersionDir reports whether version is safe to use as a single
+// directory name under DownloadDir
+func validVersionDir(version string) bool {
+	return version != "" && version != "." && version != ".." &&
+		!strings.ContainsAny(version, `/\`) && filepath.Base(version) == version
+}
+
+// CleanupVersion removes a single downloaded version, leaving the others
+func (cd *ChromeDownloader) CleanupVersion(version string) error {
+	if !validVersionDir(version) {
+		return fmt.Errorf("invalid chrome version: %q", version)
+	}
+	if err := os.RemoveAll(filepath.Join(cd.DownloadDir, version)); err != nil {
+		return fmt.Errorf("failed to remove chrome %s: %w", version, err)
+	}
+	return nil
+}
+
+// ListDownloadedVersions returns the versions present in DownloadDir,
+// including incomplete downloads
+func (cd *ChromeDownloader) ListDownloadedVersions() ([]string, error) {
+	entries, err := os.ReadDir(cd.DownloadDir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list downloaded versions: %w", err)
+	}
+
+	versions := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,389 @@
+package browser
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"foxyapply/internal/store"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// buildZip returns an in-memory zip archive containing the given files
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write zip entry: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDownloadFromFixtureServer(t *testing.T) {
+	if _, ok := ChromeForTestingPaths[GetPlatformKey()]; !ok {
+		t.Skipf("unsupported platform: %s", GetPlatformKey())
+	}
+
+	cd := &ChromeDownloader{
+		Version:     "1.2.3",
+		DownloadDir: t.TempDir(),
+	}
+
+	versionDir := filepath.Join(cd.DownloadDir, cd.Version)
+	rel, err := filepath.Rel(versionDir, cd.GetBrowserPath())
+	if err != nil {
+		t.Fatalf("failed to compute browser path: %v", err)
+	}
+	fixture := buildZip(t, map[string]string{filepath.ToSlash(rel): "#!/bin/sh\n"})
+
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Write(fixture)
+	}))
+	defer server.Close()
+	cd.BaseURL = server.URL
+
+	var lastDownloaded, lastTotal int64
+	var midPhase string
+	var extracted, entries int
+	cd.OnExtract = func(done, total int) {
+		extracted, entries = done, total
+	}
+	err = cd.Download(context.Background(), func(downloaded, total int64) {
+		lastDownloaded, lastTotal = downloaded, total
+		midPhase = cd.Progress().Phase
+	})
+	if err != nil {
+		t.Fatalf("failed to download: %v", err)
+	}
+
+	if !strings.HasPrefix(requested, "/1.2.3/") {
+		t.Errorf("expected request for version 1.2.3, got %s", requested)
+	}
+	if !cd.IsDownloaded() {
+		t.Error("expected browser to be downloaded")
+	}
+	if lastDownloaded != int64(len(fixture)) || lastTotal != int64(len(fixture)) {
+		t.Errorf("expected final progress %d/%d, got %d/%d", len(fixture), len(fixture), lastDownloaded, lastTotal)
+	}
+	if _, err := os.Stat(filepath.Join(versionDir, "chrome.zip")); !os.IsNotExist(err) {
+		t.Error("expected zip file to be removed after extraction")
+	}
+	if midPhase != DownloadPhaseDownloading {
+		t.Errorf("expected phase %q during download, got %q", DownloadPhaseDownloading, midPhase)
+	}
+	if extracted != 1 || entries != 1 {
+		t.Errorf("expected extraction to report 1/1 entries, got %d/%d", extracted, entries)
+	}
+	if progress := cd.Progress(); progress.Phase != DownloadPhaseIdle {
+		t.Errorf("expected idle phase after download, got %q", progress.Phase)
+	}
+}
+
+func TestDownloadBadStatus(t *testing.T) {
+	if _, ok := ChromeForTestingPaths[GetPlatformKey()]; !ok {
+		t.Skipf("unsupported platform: %s", GetPlatformKey())
+	}
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	cd := &ChromeDownloader{
+		Version:     "1.2.3",
+		DownloadDir: t.TempDir(),
+		BaseURL:     server.URL,
+	}
+
+	if err := cd.Download(context.Background(), nil); err == nil {
+		t.Error("expected error for 404 response")
+	}
+}
+
+func TestDownloadCancelled(t *testing.T) {
+	if _, ok := ChromeForTestingPaths[GetPlatformKey()]; !ok {
+		t.Skipf("unsupported platform: %s", GetPlatformKey())
+	}
+
+	// Send a first chunk, then stall until the client goes away
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000000")
+		w.Write(bytes.Repeat([]byte("x"), 1024))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cd := &ChromeDownloader{
+		Version:     "1.2.3",
+		DownloadDir: t.TempDir(),
+		BaseURL:     server.URL,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var once sync.Once
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cd.Download(ctx, func(downloaded, total int64) {
+			once.Do(func() { close(started) })
+		})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("download never started")
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected download to return promptly after cancel")
+	}
+
+	zipPath := filepath.Join(cd.DownloadDir, cd.Version, "chrome.zip")
+	if _, err := os.Stat(zipPath); !os.IsNotExist(err) {
+		t.Error("expected partial zip file to be removed")
+	}
+}
+
+func TestExtractZipRejectsZipSlip(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "evil.zip")
+	data := buildZip(t, map[string]string{"../evil.txt": "pwned"})
+	if err := os.WriteFile(zipPath, data, 0644); err != nil {
+		t.Fatalf("failed to write zip: %v", err)
+	}
+
+	dest := filepath.Join(dir, "out")
+	cd := &ChromeDownloader{}
+	if err := cd.extractZip(zipPath, dest, nil); err == nil {
+		t.Fatal("expected zip slip entry to be rejected")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "evil.txt")); !os.IsNotExist(err) {
+		t.Error("expected no file to be written outside destination")
+	}
+}
+
+func TestExtractZipReportsProgress(t *testing.T) {
+	files := map[string]string{}
+	for i := 0; i < 250; i++ {
+		files[fmt.Sprintf("chrome/file-%03d.txt", i)] = "data"
+	}
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "chrome.zip")
+	if err := os.WriteFile(zipPath, buildZip(t, files), 0644); err != nil {
+		t.Fatalf("failed to write zip: %v", err)
+	}
+
+	var reports []int
+	cd := &ChromeDownloader{}
+	err := cd.extractZip(zipPath, filepath.Join(dir, "out"), func(extracted, total int) {
+		if total != len(files) {
+			t.Errorf("expected total %d, got %d", len(files), total)
+		}
+		reports = append(reports, extracted)
+	})
+	if err != nil {
+		t.Fatalf("failed to extract: %v", err)
+	}
+
+	// One report per whole percent plus the final count
+	if len(reports) > 101 {
+		t.Errorf("expected progress to be throttled, got %d reports", len(reports))
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i] < reports[i-1] {
+			t.Fatalf("progress went backwards: %d -> %d", reports[i-1], reports[i])
+		}
+	}
+	if last := reports[len(reports)-1]; last != len(files) {
+		t.Errorf("expected final progress %d, got %d", len(files), last)
+	}
+}
+
+func TestCleanupVersion(t *testing.T) {
+	root := t.TempDir()
+	cd := &ChromeDownloader{DownloadDir: filepath.Join(root, "chrome")}
+
+	versions, err := cd.ListDownloadedVersions()
+	if err != nil {
+		t.Fatalf("failed to list versions: %v", err)
+	}
+	if len(versions) != 0 {
+		t.Errorf("expected no versions before download dir exists, got %v", versions)
+	}
+
+	for _, v := range []string{"115.0.5763.0", "131.0.6778.85"} {
+		if err := os.MkdirAll(filepath.Join(cd.DownloadDir, v, "chrome-linux64"), 0755); err != nil {
+			t.Fatalf("failed to create version dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(cd.DownloadDir, "stray.txt"), nil, 0644); err != nil {
+		t.Fatalf("failed to write stray file: %v", err)
+	}
+	// A sibling of DownloadDir that traversal must not reach
+	if err := os.WriteFile(filepath.Join(root, "keep.txt"), nil, 0644); err != nil {
+		t.Fatalf("failed to write sibling file: %v", err)
+	}
+
+	versions, err = cd.ListDownloadedVersions()
+	if err != nil {
+		t.Fatalf("failed to list versions: %v", err)
+	}
+	if strings.Join(versions, ",") != "115.0.5763.0,131.0.6778.85" {
+		t.Errorf("expected both versions, got %v", versions)
+	}
+
+	for _, bad := range []string{"", ".", "..", "../keep.txt", "131/../..", `..\keep.txt`} {
+		if err := cd.CleanupVersion(bad); err == nil {
+			t.Errorf("expected CleanupVersion(%q) to be rejected", bad)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "keep.txt")); err != nil {
+		t.Errorf("expected sibling file to survive: %v", err)
+	}
+
+	if err := cd.CleanupVersion("115.0.5763.0"); err != nil {
+		t.Fatalf("failed to clean up version: %v", err)
+	}
+	versions, _ = cd.ListDownloadedVersions()
+	if strings.Join(versions, ",") != "131.0.6778.85" {
+		t.Errorf("expected only 131.0.6778.85 to remain, got %v", versions)
+	}
+}
+
+func TestProgressReaderIsMonotonic(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 10_000)
+
+	var reports []int64
+	reader := &progressReader{
+		reader: bytes.NewReader(payload),
+		total:  int64(len(payload)),
+		progressFn: func(downloaded, total int64) {
+			if total != int64(len(payload)) {
+				t.Errorf("expected total %d, got %d", len(payload), total)
+			}
+			reports = append(reports, downloaded)
+		},
+	}
+
+	buf := make([]byte, 777)
+	for {
+		if _, err := reader.Read(buf); err != nil {
+			break
+		}
+	}
+
+	if len(reports) == 0 {
+		t.Fatal("expected progress to be reported")
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i] < reports[i-1] {
+			t.Fatalf("progress went backwards: %d -> %d", reports[i-1], reports[i])
+		}
+	}
+	if last := reports[len(reports)-1]; last != int64(len(payload)) {
+		t.Errorf("expected final progress %d, got %d", len(payload), last)
+	}
+}
+
+func TestParseAvailableVersions(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "known-good-versions-with-downloads.json"))
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+
+	tests := []struct {
+		platform string
+		want     []string
+	}{
+		{"linux64", []string{"131.0.6778.85", "115.0.5763.0"}},
+		{"mac-arm64", []string{"131.0.6778.85"}},
+		{"win64", []string{"131.0.6778.85", "115.0.5763.0"}},
+		{"linux-riscv", []string{}},
+	}
+	for _, tt := range tests {
+		got, err := parseAvailableVersions(data, tt.platform)
+		if err != nil {
+			t.Fatalf("failed to parse versions: %v", err)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("parseAvailableVersions(%s) = %v, want %v", tt.platform, got, tt.want)
+		}
+	}
+
+	if _, err := parseAvailableVersions([]byte("not json"), "linux64"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestListAvailableVersions(t *testing.T) {
+	if _, ok := ChromeForTestingPlatforms[GetPlatformKey()]; !ok {
+		t.Skipf("unsupported platform: %s", GetPlatformKey())
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "known-good-versions-with-downloads.json"))
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	cd := &ChromeDownloader{VersionsURL: server.URL}
+	versions, err := cd.ListAvailableVersions()
+	if err != nil {
+		t.Fatalf("failed to list versions: %v", err)
+	}
+	if len(versions) == 0 || versions[0] != "131.0.6778.85" {
+		t.Errorf("expected newest version first, got %v", versions)
+	}
+}
+
+func TestNewChromeDownloaderHonorsDataDir(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv(store.DataDirEnv, dataDir)
+
+	cd := NewChromeDownloader()
+	if want := filepath.Join(dataDir, "chrome"); cd.DownloadDir != want {
+		t.Errorf("expected download dir %s, got %s", want, cd.DownloadDir)
+	}
+
+	t.Setenv(store.DataDirEnv, "")
+	if cd := NewChromeDownloader(); strings.HasPrefix(cd.DownloadDir, dataDir) {
+		t.Errorf("expected default download dir without override, got %s", cd.DownloadDir)
+	}
+}
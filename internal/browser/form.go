@@ -0,0 +1,190 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/input"
+)
+
+// attributer is anything with HTML attributes. Both *rod.Element and
+// FormElement satisfy it, so the field heuristics work on either.
+type attributer interface {
+	Attribute(name string) (*string, error)
+}
+
+// FormElement is the narrow set of element operations the form filler
+// depends on. rodElement implements it over a real DOM node; tests swap in
+// a fake to exercise the fill decisions without Chrome.
+type FormElement interface {
+	// Attribute returns the named HTML attribute, or nil if it isn't set
+	Attribute(name string) (*string, error)
+	// Text returns the element's visible text
+	Text() (string, error)
+	// Value returns the control's live value
+	Value() string
+	// Input replaces the control's value with text
+	Input(text string) error
+	// Click clicks the element
+	Click() error
+	// PressEnter presses Enter in the control
+	PressEnter() error
+}
+
+// FormContainer is the part of a form the filler searches for fields
+type FormContainer interface {
+	// ElementsX returns the elements matching the XPath expression
+	ElementsX(xpath string) ([]FormElement, error)
+	// Elements returns the elements matching the CSS selector
+	Elements(selector string) ([]FormElement, error)
+	// Label returns the best human-readable label for el
+	Label(el FormElement) string
+	// ElementByID finds the element with the given id attribute
+	ElementByID(id string) (FormElement, error)
+	// Suggestions waits up to typeaheadWait for the options a typeahead
+	// control offers, returning none if no listbox appears
+	Suggestions(el FormElement) ([]FormElement, error)
+}
+
+// requeryTimeout bounds how long a stale element is looked up again
+const requeryTimeout = 2 * time.Second
+
+// isStaleElement reports whether err came from using an element LinkedIn
+// removed from the page, typically by re-rendering the Easy Apply modal
+func isStaleElement(err error) bool {
+	if errors.Is(err, cdp.ErrObjNotFound) || errors.Is(err, cdp.ErrCtxNotFound) || errors.Is(err, cdp.ErrCtxDestroyed) {
+		return true
+	}
+	var cdpErr *cdp.Error
+	if !errors.As(err, &cdpErr) {
+		return false
+	}
+	msg := strings.ToLower(cdpErr.Message)
+	return strings.Contains(msg, "detached") || strings.Contains(msg, "could not find node") ||
+		strings.Contains(msg, "no node with given id")
+}
+
+// requerier returns a function that finds el in form again by its id, or
+// nil when el has no id to find it by
+func requerier(form FormContainer, el FormElement) func() (FormElement, error) {
+	id := attr(el, "id")
+	if id == "" {
+		return nil
+	}
+	return func() (FormElement, error) { return form.ElementByID(id) }
+}
+
+// withRequery runs op on el. If el has gone stale and requery is set, it
+// finds the element again and retries op once.
+func withRequery(el FormElement, requery func() (FormElement, error), op func(FormElement) error) error {
+	err := op(el)
+	if err == nil || requery == nil || !isStaleElement(err) {
+		return err
+	}
+	fresh, qerr := requery()
+	if qerr != nil {
+		return fmt.Errorf("%w (element not found again: %v)", err, qerr)
+	}
+	return op(fresh)
+}
+
+// rodElement is the production FormElement
+type rodElement struct {
+	el     *rod.Element
+	typing Timing
+}
+
+func (e rodElement) Attribute(name string) (*string, error) { return e.el.Attribute(name) }
+
+func (e rodElement) Text() (string, error) { return e.el.Text() }
+
+func (e rodElement) Value() string { return currentValue(e.el) }
+
+func (e rodElement) Input(text string) error { return clearAndType(e.el, text, e.typing) }
+
+func (e rodElement) Click() error { return click(e.el) }
+
+func (e rodElement) PressEnter() error { return e.el.Type(input.Enter) }
+
+// rodForm is the production FormContainer, rooted at a form element or
+// shadow root
+type rodForm struct {
+	root   *rod.Element
+	typing Timing // Passed on to the form's elements
+}
+
+func (f rodForm) ElementsX(xpath string) ([]FormElement, error) {
+	els, err := f.root.ElementsX(xpath)
+	if err != nil {
+		return nil, err
+	}
+	return f.wrap(els), nil
+}
+
+func (f rodForm) Elements(selector string) ([]FormElement, error) {
+	els, err := f.root.Elements(selector)
+	if err != nil {
+		return nil, err
+	}
+	return f.wrap(els), nil
+}
+
+func (f rodForm) Label(el FormElement) string {
+	re, ok := el.(rodElement)
+	if !ok {
+		return ""
+	}
+	return getBestLabelText(f.root, re.el)
+}
+
+func (f rodForm) ElementByID(id string) (FormElement, error) {
+	el, err := f.root.Timeout(requeryTimeout).Element("#" + cssEscape(id))
+	if err != nil {
+		return nil, err
+	}
+	return rodElement{el: el.CancelTimeout(), typing: f.typing}, nil
+}
+
+func (f rodForm) Suggestions(el FormElement) ([]FormElement, error) {
+	re, ok := el.(rodElement)
+	if !ok {
+		return nil, nil
+	}
+	// The listbox is often rendered outside the form, so search the page
+	selector := `[role="listbox"] [role="option"]`
+	if id := attr(el, "aria-controls"); id != "" {
+		selector = "#" + cssEscape(id) + ` [role="option"]`
+	}
+	page := re.el.Page()
+	if _, err := page.Timeout(typeaheadWait).Element(selector); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	options, err := page.Elements(selector)
+	if err != nil {
+		return nil, err
+	}
+	var visible rod.Elements
+	for _, option := range options {
+		if ok, err := option.Visible(); err == nil && ok {
+			visible = append(visible, option)
+		}
+	}
+	return f.wrap(visible), nil
+}
+
+// wrap turns els into the form's FormElements
+func (f rodForm) wrap(els rod.Elements) []FormElement {
+	wrapped := make([]FormElement, len(els))
+	for i, el := range els {
+		wrapped[i] = rodElement{el: el, typing: f.typing}
+	}
+	return wrapped
+}
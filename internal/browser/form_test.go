@@ -0,0 +1,314 @@
+package browser
+
+import (
+	"errors"
+	"foxyapply/internal/store"
+	"testing"
+
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+// fakeElement is a FormElement backed by an attribute map. Each Input call
+// first returns the next error in inputErrs, if any are left.
+type fakeElement struct {
+	attrs     map[string]string
+	label     string
+	value     string
+	typed     []string
+	inputErrs []error
+	clicks    int
+	enters    int
+}
+
+func (e *fakeElement) Attribute(name string) (*string, error) {
+	v, ok := e.attrs[name]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (e *fakeElement) Text() (string, error) { return e.value, nil }
+
+func (e *fakeElement) Value() string { return e.value }
+
+func (e *fakeElement) Input(text string) error {
+	if len(e.inputErrs) > 0 {
+		err := e.inputErrs[0]
+		e.inputErrs = e.inputErrs[1:]
+		return err
+	}
+	e.typed = append(e.typed, text)
+	e.value = text
+	return nil
+}
+
+func (e *fakeElement) Click() error {
+	e.clicks++
+	return nil
+}
+
+func (e *fakeElement) PressEnter() error {
+	e.enters++
+	return nil
+}
+
+// fakeForm is a FormContainer holding fixed text inputs and textareas.
+// ElementByID finds elements in rerendered, standing in for a form that
+// LinkedIn re-rendered after the elements were first found. Every
+// typeahead offers the same suggestions.
+type fakeForm struct {
+	inputs      []*fakeElement
+	textareas   []*fakeElement
+	rerendered  []*fakeElement
+	suggestions []*fakeElement
+}
+
+func (f *fakeForm) ElementsX(xpath string) ([]FormElement, error) {
+	return toFormElements(f.inputs), nil
+}
+
+func (f *fakeForm) Elements(selector string) ([]FormElement, error) {
+	if selector != "textarea" {
+		return nil, nil
+	}
+	return toFormElements(f.textareas), nil
+}
+
+func (f *fakeForm) Label(el FormElement) string { return el.(*fakeElement).label }
+
+func (f *fakeForm) ElementByID(id string) (FormElement, error) {
+	for _, el := range f.rerendered {
+		if el.attrs["id"] == id {
+			return el, nil
+		}
+	}
+	return nil, errors.New("element not found")
+}
+
+func (f *fakeForm) Suggestions(el FormElement) ([]FormElement, error) {
+	return toFormElements(f.suggestions), nil
+}
+
+func toFormElements(els []*fakeElement) []FormElement {
+	out := make([]FormElement, len(els))
+	for i, el := range els {
+		out[i] = el
+	}
+	return out
+}
+
+func TestFillInvalidsOnlyFillsRequiredEmptyFields(t *testing.T) {
+	profile := &store.LinkedInProfile{
+		UserCity:        "Austin",
+		UserState:       "TX",
+		YearsExperience: 7,
+		CoverLetter:     "Hello there",
+	}
+
+	required := &fakeElement{
+		attrs: map[string]string{"required": "", "type": "text"},
+		label: "How many years of experience do you have?",
+	}
+	ariaRequired := &fakeElement{
+		attrs: map[string]string{"aria-required": "true"},
+		label: "Current city",
+	}
+	optional := &fakeElement{
+		attrs: map[string]string{"type": "text"},
+		label: "Years of experience",
+	}
+	prefilled := &fakeElement{
+		attrs: map[string]string{"required": "", "value": "12"},
+		label: "Years of experience",
+	}
+	clamped := &fakeElement{
+		attrs: map[string]string{"required": "", "type": "number", "max": "5"},
+		label: "Years of experience with Go",
+	}
+	yesNo := &fakeElement{
+		attrs: map[string]string{"required": "", "type": "text"},
+		label: "Do you have an active security clearance?",
+	}
+	requiredTextarea := &fakeElement{
+		attrs: map[string]string{"required": ""},
+		label: "Why do you want to work here?",
+	}
+	filledTextarea := &fakeElement{
+		attrs: map[string]string{"required": ""},
+		label: "Tell us about yourself",
+		value: "Already answered",
+	}
+
+	form := &fakeForm{
+		inputs:    []*fakeElement{required, ariaRequired, optional, prefilled, clamped, yesNo},
+		textareas: []*fakeElement{requiredTextarea, filledTextarea},
+	}
+	if err := fillInvalids(form, profile, fillOptions{}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		el   *fakeElement
+		want []string
+	}{
+		"required":          {required, []string{"7"}},
+		"aria-required":     {ariaRequired, []string{"Austin, TX"}},
+		"optional":          {optional, nil},
+		"prefilled":         {prefilled, nil},
+		"clamped":           {clamped, []string{"5"}},
+		"unanswered yes/no": {yesNo, nil},
+		"required textarea": {requiredTextarea, []string{"Hello there"}},
+		"filled textarea":   {filledTextarea, nil},
+	} {
+		if len(tc.el.typed) != len(tc.want) {
+			t.Errorf("%s: typed %q, want %q", name, tc.el.typed, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if tc.el.typed[i] != tc.want[i] {
+				t.Errorf("%s: typed %q, want %q", name, tc.el.typed, tc.want)
+			}
+		}
+	}
+}
+
+func TestWithRequeryRetriesStaleElements(t *testing.T) {
+	stale := &fakeElement{inputErrs: []error{cdp.ErrObjNotFound}}
+	fresh := &fakeElement{}
+	requeries := 0
+	requery := func() (FormElement, error) {
+		requeries++
+		return fresh, nil
+	}
+	input := func(el FormElement) error { return el.Input("7") }
+
+	if err := withRequery(stale, requery, input); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if requeries != 1 || len(fresh.typed) != 1 || fresh.typed[0] != "7" {
+		t.Errorf("expected one requery and the fresh element filled, got %d requeries, typed %q", requeries, fresh.typed)
+	}
+
+	// Only stale elements are retried
+	requeries = 0
+	broken := &fakeElement{inputErrs: []error{errors.New("element not interactable")}}
+	if err := withRequery(broken, requery, input); err == nil || requeries != 0 {
+		t.Errorf("expected other errors to fail without a requery, got %v after %d requeries", err, requeries)
+	}
+
+	// Retries once, then gives up
+	twice := &fakeElement{inputErrs: []error{cdp.ErrObjNotFound, cdp.ErrObjNotFound}}
+	if err := withRequery(twice, func() (FormElement, error) { return twice, nil }, input); !errors.Is(err, cdp.ErrObjNotFound) {
+		t.Errorf("expected the second stale error, got %v", err)
+	}
+
+	// An element that can't be found again keeps the stale error
+	stale.inputErrs = []error{&cdp.Error{Code: -32000, Message: "Node is detached from document"}}
+	if err := withRequery(stale, func() (FormElement, error) { return nil, errors.New("gone") }, input); !isStaleElement(err) {
+		t.Errorf("expected the stale error when the element can't be found again, got %v", err)
+	}
+}
+
+func TestFillInvalidsRequeriesStaleFields(t *testing.T) {
+	const id = "single-line-text-form-component-formElement-urn-li-jobs-applyformcommon-easyApplyFormElement-1"
+	stale := &fakeElement{
+		attrs:     map[string]string{"id": id, "required": ""},
+		label:     "Years of experience",
+		inputErrs: []error{cdp.ErrObjNotFound},
+	}
+	fresh := &fakeElement{attrs: map[string]string{"id": id, "required": ""}, label: "Years of experience"}
+	form := &fakeForm{inputs: []*fakeElement{stale}, rerendered: []*fakeElement{fresh}}
+
+	if err := fillInvalids(form, &store.LinkedInProfile{YearsExperience: 4}, fillOptions{}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if len(stale.typed) != 0 || len(fresh.typed) != 1 || fresh.typed[0] != "4" {
+		t.Errorf("expected the re-rendered field to be filled, got stale %q, fresh %q", stale.typed, fresh.typed)
+	}
+}
+
+func TestChooseFreeText(t *testing.T) {
+	profile := &store.LinkedInProfile{CoverLetter: "  I'd love to help Acme ship.\n"}
+	answer := func(ans string, err error) AnswerProvider {
+		return func(label, inputType string) (string, error) {
+			if inputType != "textarea" {
+				t.Errorf("expected the provider to be asked about a textarea, got %q", inputType)
+			}
+			return ans, err
+		}
+	}
+
+	tests := []struct {
+		name     string
+		profile  *store.LinkedInProfile
+		provider AnswerProvider
+		value    string
+		reason   string
+	}{
+		{"provider", profile, answer(" I build tools people use. ", nil), "I build tools people use.", reasonAnswerProvider},
+		{"provider error", profile, answer("", errors.New("rate limited")), "I'd love to help Acme ship.", "cover letter"},
+		{"blank provider answer", profile, answer("  ", nil), "I'd love to help Acme ship.", "cover letter"},
+		{"cover letter", profile, nil, "I'd love to help Acme ship.", "cover letter"},
+		{"blank cover letter", &store.LinkedInProfile{CoverLetter: " \n "}, nil, fallbackFreeTextAnswer, "generic answer"},
+		{"no profile", nil, nil, fallbackFreeTextAnswer, "generic answer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, reason := chooseFreeText("Why do you want to work here?", tt.profile, tt.provider)
+			if value != tt.value || reason != tt.reason {
+				t.Errorf("got %q (%s), want %q (%s)", value, reason, tt.value, tt.reason)
+			}
+			if got := ChooseFreeText("Why do you want to work here?", tt.profile, tt.provider); got != tt.value {
+				t.Errorf("ChooseFreeText = %q, want %q", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestFillInvalidsAnswersTextareas(t *testing.T) {
+	question := func() *fakeElement {
+		return &fakeElement{attrs: map[string]string{"required": ""}, label: "Why do you want to work here?"}
+	}
+	optional := &fakeElement{label: "Anything else?"}
+
+	fill := func(textarea *fakeElement, profile *store.LinkedInProfile, provider AnswerProvider) []FilledField {
+		t.Helper()
+		var filled []FilledField
+		form := &fakeForm{textareas: []*fakeElement{textarea, optional}}
+		if err := fillInvalids(form, profile, fillOptions{answers: provider, report: func(field FilledField) { filled = append(filled, field) }}); err != nil {
+			t.Fatalf("fillInvalids failed: %v", err)
+		}
+		return filled
+	}
+	profile := &store.LinkedInProfile{CoverLetter: "I'd love to help Acme ship."}
+
+	// The answer provider comes first
+	textarea := question()
+	filled := fill(textarea, profile, func(label, inputType string) (string, error) { return "Your mission.", nil })
+	if len(textarea.typed) != 1 || textarea.typed[0] != "Your mission." {
+		t.Errorf("expected the provider's answer, typed %q", textarea.typed)
+	}
+	want := FilledField{Label: "Why do you want to work here?", Kind: FieldTextarea, Value: "Your mission.", Reason: reasonAnswerProvider}
+	if len(filled) != 1 || filled[0] != want {
+		t.Errorf("expected %+v reported, got %+v", want, filled)
+	}
+
+	// Then the profile's cover letter
+	textarea = question()
+	fill(textarea, profile, nil)
+	if len(textarea.typed) != 1 || textarea.typed[0] != profile.CoverLetter {
+		t.Errorf("expected the cover letter, typed %q", textarea.typed)
+	}
+
+	// And a required textarea always gets something
+	textarea = question()
+	fill(textarea, &store.LinkedInProfile{}, nil)
+	if len(textarea.typed) != 1 || textarea.typed[0] != fallbackFreeTextAnswer {
+		t.Errorf("expected the generic answer, typed %q", textarea.typed)
+	}
+
+	if len(optional.typed) != 0 {
+		t.Errorf("expected the optional textarea to be left alone, typed %q", optional.typed)
+	}
+}
@@ -0,0 +1,25 @@
+package browser
+
+import "testing"
+
+func TestLookupCoordinates(t *testing.T) {
+	tests := []struct {
+		city, state string
+		want        Coordinates
+		ok          bool
+	}{
+		{"Austin", "TX", cityCoordinates["austin, tx"], true},
+		{" new york ", "New York", cityCoordinates["new york, ny"], true},
+		{"Round Rock", "TX", stateCoordinates["tx"], true},
+		{"", "california", stateCoordinates["ca"], true},
+		{"Toronto", "ON", Coordinates{}, false},
+		{"", "", Coordinates{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := LookupCoordinates(tt.city, tt.state)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("LookupCoordinates(%q, %q) = (%v, %v), want (%v, %v)", tt.city, tt.state, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,19 +30,259 @@ type BrowserManager struct {
 	browser    *rod.Browser
 	launcher   *launcher.Launcher
 	controlURL string
+	launching  bool // guarded by mu; Launch is starting Chrome
 	mu         sync.RWMutex
 	ctx        context.Context
 	cancel     context.CancelFunc
+	resumeCh   chan struct{} // non-nil while paused, closed on resume
+	applying   bool          // guarded by mu
+	claim      int           // guarded by mu; the pending ClaimApplying claim, 0 if none
+	claims     int           // guarded by mu; ClaimApplying calls so far, numbering claims
+	recorder   ApplicationRecorder
+	resumes    ResumeLister
+	onEvent    EventHandler
+	cookies    []*proto.NetworkCookie // session cookies from the last successful login
+	session    ApplySession           // guarded by mu
+	pages      []*rod.Page            // guarded by mu; every page opened through the manager
+	sharedPage *rod.Page              // guarded by mu; reused by Navigate and StartApplying
+
+	cooldownUntil time.Time    // guarded by mu; applying is refused until then
+	limiter       *tokenBucket // nil when submissions aren't rate limited
+
+	answers     AnswerProvider // guarded by mu; nil uses the built-in defaults
+	answerCache AnswerCache    // guarded by mu; nil gives each run a fresh cache
+	runAnswers  AnswerProvider // guarded by mu; answers wrapped in the current run's cache
+
+	answerMemory AnswerMemory // guarded by mu; nil doesn't remember answers between runs
+
+	deadline *sessionDeadline // guarded by mu; the running session's timeout, stopped while paused
+
+	companies *companyThrottle // guarded by mu; the running session's per-company limit
 }
 
+// ApplySession tallies the outcomes of one StartApplying run. Every job
+// found is counted in exactly one of the outcome fields once it's processed.
+type ApplySession struct {
+	Found    int `json:"found"`    // Jobs collected from search results
+	Applied  int `json:"applied"`  // Applications submitted
+	Failed   int `json:"failed"`   // Attempts that errored or couldn't submit
+	Skipped  int `json:"skipped"`  // Jobs without an Easy Apply button
+	Excluded int `json:"excluded"` // Titles matching an exclude keyword
+	External int `json:"external"` // Easy Apply redirected off LinkedIn
+
+	StartedAt  time.Time `json:"startedAt"`
+	EndedAt    time.Time `json:"endedAt"`              // Zero while the run is in progress
+	StopReason string    `json:"stopReason,omitempty"` // Why the run was cut short, e.g. StopReasonSessionTimeout
+}
+
+// MergeSessions adds up the tallies of sessions run side by side. The
+// merged session starts with the earliest one and is still in progress
+// while any of them is.
+func MergeSessions(sessions ...ApplySession) ApplySession {
+	var merged ApplySession
+	ended := true
+	for _, s := range sessions {
+		merged.Found += s.Found
+		merged.Applied += s.Applied
+		merged.Failed += s.Failed
+		merged.Skipped += s.Skipped
+		merged.Excluded += s.Excluded
+		merged.External += s.External
+
+		if s.StartedAt.IsZero() {
+			continue
+		}
+		if merged.StartedAt.IsZero() || s.StartedAt.Before(merged.StartedAt) {
+			merged.StartedAt = s.StartedAt
+		}
+		if s.EndedAt.IsZero() {
+			ended = false
+		} else if s.EndedAt.After(merged.EndedAt) {
+			merged.EndedAt = s.EndedAt
+		}
+	}
+	if !ended {
+		merged.EndedAt = time.Time{}
+	}
+	return merged
+}
+
+// record counts a processed job by its application status
+func (s *ApplySession) record(status string, err error) {
+	switch {
+	case err != nil:
+		s.Failed++
+	case status == store.ApplicationStatusApplied:
+		s.Applied++
+	case status == store.ApplicationStatusSkipped:
+		s.Skipped++
+	case status == store.ApplicationStatusExternalRedirect:
+		s.External++
+	default:
+		s.Failed++
+	}
+}
+
+// failureStreak counts failed applications since the last submitted one,
+// across every page of an apply session
+type failureStreak struct {
+	count int
+	max   int // Failures in a row that end the run (0 = never)
+}
+
+// record counts an outcome the way ApplySession.record does, reporting
+// whether max applications have now failed in a row. A nil streak never
+// reports true.
+func (f *failureStreak) record(status string, err error) bool {
+	if f == nil {
+		return false
+	}
+	switch {
+	case err != nil:
+		f.count++
+	case status == store.ApplicationStatusApplied:
+		f.count = 0
+	case status == store.ApplicationStatusSkipped, status == store.ApplicationStatusExternalRedirect:
+	default:
+		f.count++
+	}
+	return f.max > 0 && f.count >= f.max
+}
+
+// EventHandler receives named status events, such as a challenge being
+// detected, so the UI can react to them
+type EventHandler func(name string, data map[string]interface{})
+
+// ApplicationRecorder persists application attempts. *store.Store implements it.
+type ApplicationRecorder interface {
+	RecordApplication(app store.Application) (*store.Application, error)
+}
+
+// ApplicationRetrier finds failed applications and updates them after a
+// retry. *store.Store implements it; RetryFailed needs the recorder to.
+type ApplicationRetrier interface {
+	ListFailedApplications(profileID int64) ([]store.Application, error)
+	UpdateApplicationStatus(id int64, status string) error
+}
+
+// SkippedJobLister lists the jobs a profile skipped for a reason.
+// *store.Store implements it; StartApplying uses it through the recorder.
+type SkippedJobLister interface {
+	SkippedJobIDs(profileID int64, reason string) ([]int, error)
+}
+
+// CompanyApplicationCounter counts a profile's submitted applications per
+// company since a time. *store.Store implements it; StartApplying uses it
+// through the recorder for Config.CompanyLimitWindow.
+type CompanyApplicationCounter interface {
+	CompanyApplicationCounts(profileID int64, since time.Time) (map[string]int, error)
+}
+
+// ResumeLister lists a profile's resumes. *store.Store implements it.
+type ResumeLister interface {
+	ListResumes(profileID int64) ([]*store.Resume, error)
+}
+
+// ErrAlreadyApplying is returned when an apply session is already running
+var ErrAlreadyApplying = errors.New("an apply session is already running")
+
 // Config holds browser configuration options
 type Config struct {
-	Headless   bool
-	IsApplying bool   // Whether the browser is used for applying
-	BrowserBin string // Custom browser binary path
-	UserData   string // Custom user data directory
+	Headless        bool
+	IsApplying      bool   // Whether the browser is used for applying
+	BrowserBin      string // Custom browser binary path
+	UserData        string // Chrome user data directory, kept between launches (empty = a fresh temporary one)
+	MaxApplications int    // Stop after this many submitted applications (0 = unlimited)
+
+	NavigationRetries int           // Retries after a failed navigation (0 = DefaultNavigationRetries)
+	NavigationBackoff time.Duration // Delay before the first retry, doubled each time (0 = DefaultNavigationBackoff)
+
+	ChallengeSelectors []string // Selectors that identify a challenge page (nil = DefaultChallengeSelectors)
+
+	MaxRestarts int           // Automatic browser restarts allowed per apply session (0 = DefaultMaxRestarts)
+	ApplyDelay  time.Duration // Wait after opening each job before applying (0 = DefaultApplyDelay)
+
+	FollowCompany bool // Leave the "follow company" box checked on submit (off by default)
+	SpoofLocation bool // Report the profile's city/state as the browser geolocation
+
+	OperationTimeout time.Duration // Limit for each blocking page operation (0 = DefaultOperationTimeout)
+
+	StartMinimized bool // Minimize the window after launch so it doesn't steal focus
+
+	RateLimitCooldown time.Duration // Pause after LinkedIn throttles applying (0 = DefaultRateLimitCooldown)
+
+	ApplicationsPerHour int // Space submissions out to at most this many an hour (0 = unlimited)
+
+	MaxConsecutiveFailures int // Abort a run after this many failed applications in a row (0 = DefaultMaxConsecutiveFailures)
+
+	ManualLogin        bool          // Open a visible browser and wait for the user to sign in by hand instead of submitting credentials
+	ManualLoginTimeout time.Duration // How long to wait for a manual sign-in (0 = DefaultManualLoginTimeout)
+
+	SnapshotDir string // Save the page's HTML and a screenshot here when a job fails ("" = don't)
+
+	SessionTimeout time.Duration // Stop an apply run after this long, not counting time paused (0 = no limit)
+
+	Timing Timing // How text is typed into fields
+
+	Locale string // Browser language and Accept-Language, which the English selectors rely on ("" = DefaultLocale)
+
+	PosterPreference    PosterPreference // Skip jobs with or without a hiring team listed (PosterAny = apply to both)
+	HiringTeamSelectors []string         // Selectors that find a job's hiring team section (nil = DefaultHiringTeamSelectors)
+
+	UserAgent string // User-Agent sent by every page ("" = DefaultUserAgent)
+
+	MaxPerCompany      int           // Skip a company's postings once this many applications went to it (0 = unlimited)
+	CompanyLimitWindow time.Duration // Also count MaxPerCompany against applications this far back (0 = this session only)
 }
 
+// Defaults used when the matching Config field is unset
+const (
+	DefaultMaxRestarts = 3
+	DefaultApplyDelay  = 2 * time.Second
+
+	DefaultMaxConsecutiveFailures = 5
+
+	DefaultOperationTimeout = 30 * time.Second
+
+	DefaultManualLoginTimeout = 5 * time.Minute
+)
+
+// ErrBrowserLost is returned when the browser crashes during an apply
+// session and can't be recovered
+var ErrBrowserLost = errors.New("browser connection lost")
+
+// Navigation retry defaults used when Config leaves them unset
+const (
+	DefaultNavigationRetries = 3
+	DefaultNavigationBackoff = 2 * time.Second
+)
+
+// ErrTooManyFailures is returned when an apply session gives up after
+// Config.MaxConsecutiveFailures failed applications in a row, which usually
+// means LinkedIn changed its pages or is blocking the account
+var ErrTooManyFailures = errors.New("too many failed applications in a row")
+
+// Errors callers can tell apart with errors.Is. Most are wrapped with
+// details, so compare with errors.Is rather than ==.
+var (
+	// ErrBrowserNotRunning is returned when an operation needs a browser
+	// that hasn't been launched or has been closed
+	ErrBrowserNotRunning = errors.New("browser not running")
+	// ErrLoginFailed is returned when signing in to LinkedIn doesn't work
+	ErrLoginFailed = errors.New("failed to log in to LinkedIn")
+	// ErrSecurityChallenge is returned when a LinkedIn security check, such
+	// as a captcha or verification code, stops the session
+	ErrSecurityChallenge = errors.New("LinkedIn requires a security challenge")
+	// ErrNoJobsFound is returned when a job search shows no job links at all
+	ErrNoJobsFound = errors.New("no job links found")
+	// ErrEasyApplyNotFound is returned when a job page has no usable Easy
+	// Apply button
+	ErrEasyApplyNotFound = errors.New("Easy Apply button not found")
+	// ErrRateLimited is returned when LinkedIn serves its "too many
+	// requests" page, and wrapped by ErrThrottled
+	ErrRateLimited = errors.New("rate limited by LinkedIn")
+)
+
 // NewBrowserManager creates a new browser manager instance
 func NewBrowserManager(cfg *Config) *BrowserManager {
 	if cfg == nil {
@@ -51,22 +292,64 @@ func NewBrowserManager(cfg *Config) *BrowserManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &BrowserManager{
-		cfg:    cfg,
-		ctx:    ctx,
-		cancel: cancel,
+		cfg:     cfg,
+		ctx:     ctx,
+		cancel:  cancel,
+		limiter: newTokenBucket(cfg.ApplicationsPerHour, realClock{}),
 	}
 }
 
 // Launch starts the browser process
 func (bm *BrowserManager) Launch() error {
+	bm.mu.Lock()
+	if bm.browser != nil || bm.launching {
+		bm.mu.Unlock()
+		return fmt.Errorf("browser already running")
+	}
+
+	// A previous Close cancels the context; start this session with a fresh one
+	if bm.ctx.Err() != nil {
+		bm.ctx, bm.cancel = context.WithCancel(context.Background())
+	}
+
+	l := bm.newLauncher()
+	bm.launching = true
+	bm.mu.Unlock()
+
+	// Launch the browser without holding mu, so status checks and
+	// ClaimApplying answer while Chrome starts
+	url, err := l.Launch()
+
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
+	bm.launching = false
+	if err != nil {
+		return fmt.Errorf("failed to launch browser: %w", err)
+	}
 
-	if bm.browser != nil {
-		return fmt.Errorf("browser already running")
+	bm.controlURL = url
+	bm.launcher = l
+	// Pages from a browser that crashed without Close belong to a dead connection
+	bm.pages = nil
+	bm.sharedPage = nil
+
+	// Connect to browser
+	bm.browser = rod.New().ControlURL(url)
+	if err := bm.browser.Connect(); err != nil {
+		return fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	bm.browser.MustIgnoreCertErrors(true)
+
+	if bm.cfg.StartMinimized && !bm.cfg.Headless {
+		if err := setWindowState(bm.browser, proto.BrowserWindowStateMinimized); err != nil {
+			log.Printf("Failed to minimize browser window: %v", err)
+		}
 	}
+	return nil
+}
 
-	// Create launcher with options
+// newLauncher configures the Chrome launcher from the manager's config
+func (bm *BrowserManager) newLauncher() *launcher.Launcher {
 	l := launcher.New().
 		NoSandbox(true).           // --no-sandbox
 		Set("start-maximized").    // Start maximized
@@ -75,138 +358,1040 @@ func (bm *BrowserManager) Launch() error {
 		Set("disable-blink-features", "AutomationControlled").
 		Set("useAutomationExtension", "false").
 		Set("excludeSwitches", "enable-automation").
-		Headless(false). // Run in non-headless mode for visibility
-		Devtools(false)  // Keep devtools closed to appear more normal
+		Set("lang", bm.locale()).  // Pin the UI language the selectors are written for
+		Headless(bm.cfg.Headless). // Visible by default so the user can watch
+		Devtools(false)            // Keep devtools closed to appear more normal
 
 	// Try to find browser in order of preference:
-	// 1. Bundled browser
-	// 2. System Chrome
-	// 3. Auto-download (Rod default)
-	if bundledPath := bm.findBundledBrowser(); bundledPath != "" {
+	// 1. Configured binary (e.g. a downloaded Chrome for Testing)
+	// 2. Bundled browser
+	// 3. System Chrome
+	// 4. Auto-download (Rod default)
+	if bm.cfg.BrowserBin != "" && fileExists(bm.cfg.BrowserBin) {
+		l = l.Bin(bm.cfg.BrowserBin)
+	} else if bundledPath := bm.findBundledBrowser(); bundledPath != "" {
 		l = l.Bin(bundledPath)
 	} else if systemPath := bm.findSystemBrowser(); systemPath != "" {
 		l = l.Bin(systemPath)
 	}
 	// If neither found, Rod will auto-download
+	if bm.cfg.ManualLogin {
+		l = l.Headless(false) // The user signs in through the window
+	}
+	if bm.cfg.UserData != "" {
+		l = l.UserDataDir(bm.cfg.UserData)
+	}
+	return l
+}
 
-	// Launch the browser
-	url, err := l.Launch()
+// SetWindowState minimizes, maximizes or restores the browser window.
+// state is one of "normal", "minimized", "maximized" or "fullscreen".
+// Pages keep working while minimized. It is a no-op in headless mode.
+func (bm *BrowserManager) SetWindowState(state string) error {
+	switch s := proto.BrowserWindowState(state); s {
+	case proto.BrowserWindowStateNormal, proto.BrowserWindowStateMinimized,
+		proto.BrowserWindowStateMaximized, proto.BrowserWindowStateFullscreen:
+	default:
+		return fmt.Errorf("invalid window state: %s", state)
+	}
+	if bm.cfg.Headless {
+		return nil
+	}
+
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return ErrBrowserNotRunning
+	}
+	return setWindowState(browser, proto.BrowserWindowState(state))
+}
+
+// setWindowState applies state to the window holding the browser's first page
+func setWindowState(browser *rod.Browser, state proto.BrowserWindowState) error {
+	targets, err := proto.TargetGetTargets{}.Call(browser)
 	if err != nil {
-		return fmt.Errorf("failed to launch browser: %w", err)
+		return fmt.Errorf("failed to list targets: %w", err)
 	}
 
-	bm.controlURL = url
-	bm.launcher = l
+	var targetID proto.TargetTargetID
+	for _, info := range targets.TargetInfos {
+		if info.Type == proto.TargetTargetInfoTypePage {
+			targetID = info.TargetID
+			break
+		}
+	}
+	if targetID == "" {
+		return fmt.Errorf("no browser window found")
+	}
 
-	// Connect to browser
-	bm.browser = rod.New().ControlURL(url)
-	if err := bm.browser.Connect(); err != nil {
-		return fmt.Errorf("failed to connect to browser: %w", err)
+	window, err := proto.BrowserGetWindowForTarget{TargetID: targetID}.Call(browser)
+	if err != nil {
+		return fmt.Errorf("failed to get browser window: %w", err)
+	}
+	if err := (proto.BrowserSetWindowBounds{
+		WindowID: window.WindowID,
+		Bounds:   &proto.BrowserBounds{WindowState: state},
+	}).Call(browser); err != nil {
+		return fmt.Errorf("failed to set window state: %w", err)
 	}
-	bm.browser.MustIgnoreCertErrors(true)
 	return nil
 }
 
 func (bm *BrowserManager) Login(email, password string) (successfulLogin bool, initPage *rod.Page, err error) {
-	page := stealth.MustPage(bm.browser)
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return false, nil, ErrBrowserNotRunning
+	}
+	// Log in on the shared page so StartApplying keeps working in the same
+	// tab, however many times Login is called
+	page, err := bm.SharedPage()
+	if err != nil {
+		return false, nil, err
+	}
+	if bm.cfg.ManualLogin {
+		if err := bm.waitForManualLogin(bm.controller(page)); err != nil {
+			bm.Close()
+			return false, nil, err
+		}
+	} else {
+		if err := bm.submitLogin(page, email, password); err != nil {
+			bm.Close()
+			return false, nil, fmt.Errorf("%w: failed to submit login form: %w", ErrLoginFailed, err)
+		}
+		if !isLoggedIn(page) {
+			bm.Close()
+			return false, nil, nil
+		}
+	}
 
-	page.MustNavigate("https://linkedin.com")
-	time.Sleep(300 * time.Millisecond)
-	page.MustNavigate("https://www.linkedin.com/login?trk=guest_homepage-basic_nav-header-signin")
-	// 1. Find username field and input email
-	userField := page.MustElement("#username")
-	userField.MustInput(email)
+	// Keep the session cookies so a crashed browser can be restored without logging in again
+	if cookies, err := browser.GetCookies(); err == nil {
+		bm.mu.Lock()
+		bm.cookies = cookies
+		bm.mu.Unlock()
+	}
 
-	// 2. Press Tab
-	userField.MustWaitInteractable()
-	page.Keyboard.Press(input.Tab)
+	return true, page, nil
+}
 
-	// 3. Wait 2 seconds (or use a better wait if possible)
-	page.MustWaitRequestIdle() // or
-	time.Sleep(2 * time.Second)
+// ErrInvalidCredentials and ErrChallengeRequired are returned by
+// VerifyCredentials when LinkedIn rejects the login. ErrInvalidCredentials
+// wraps ErrLoginFailed; ErrChallengeRequired is ErrSecurityChallenge.
+var (
+	ErrInvalidCredentials = fmt.Errorf("%w: wrong email or password", ErrLoginFailed)
+	ErrChallengeRequired  = ErrSecurityChallenge
+)
 
-	// 4. Find password field and input password
-	pwField := page.MustElement("#password")
-	pwField.MustInput(password)
+// VerifyCredentials attempts a login and reports how LinkedIn responded
+// without starting to apply. It returns nil on success, ErrInvalidCredentials
+// or ErrChallengeRequired when the login is rejected, and always closes the
+// browser, even if page automation panics.
+func (bm *BrowserManager) VerifyCredentials(email, password string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("credential check failed: %v", r)
+		}
+		bm.Close()
+	}()
 
-	// 5. Wait 2 seconds
-	page.MustWaitRequestIdle() // or
-	time.Sleep(2 * time.Second)
+	if err := bm.Launch(); err != nil {
+		return err
+	}
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return ErrBrowserNotRunning
+	}
 
-	// 6. Find login button and click
-	loginButton := page.MustElement(".btn__primary--large")
-	loginButton.MustClick()
+	page, err := bm.openPage(browser)
+	if err != nil {
+		return err
+	}
+	if err := bm.submitLogin(page, email, password); err != nil {
+		return fmt.Errorf("%w: failed to submit login form: %w", ErrLoginFailed, err)
+	}
 
-	// 7. Wait 3 seconds
-	page.MustWaitRequestIdle() // or
-	time.Sleep(3 * time.Second)
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		info, err := page.Info()
+		if err != nil {
+			return fmt.Errorf("failed to read page info: %w", err)
+		}
+		html, err := page.HTML()
+		if err != nil {
+			return fmt.Errorf("failed to read page: %w", err)
+		}
 
-	loggedInElement, errorLoggingIn := page.Timeout(15 * time.Second).Element("#caret-small") // 8. Check for element by id with timeout
-	if errorLoggingIn != nil || loggedInElement == nil {
-		bm.browser.Close()
-		bm.browser = nil
-		bm.cancel()
-		return false, nil, nil
+		switch ClassifyLoginPage(info.URL, html) {
+		case LoginSucceeded:
+			return nil
+		case LoginInvalidCredentials:
+			return ErrInvalidCredentials
+		case LoginChallengeRequired:
+			return ErrChallengeRequired
+		}
+		time.Sleep(500 * time.Millisecond)
 	}
 
-	return true, page, nil
+	return fmt.Errorf("%w: timed out waiting for login result", ErrLoginFailed)
+}
+
+// loginCheckTimeout is how long isLoggedIn waits for a signed-in page
+const loginCheckTimeout = 15 * time.Second
+
+// isLoggedIn polls page until it shows a signed-in session (see
+// IsLoggedInPage) or loginCheckTimeout passes
+func isLoggedIn(page *rod.Page) bool {
+	deadline := time.Now().Add(loginCheckTimeout)
+	for {
+		if info, err := page.Info(); err == nil {
+			if html, err := page.HTML(); err == nil && IsLoggedInPage(info.URL, html) {
+				return true
+			}
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
 }
 
-func (bm *BrowserManager) StartApplying(profile *store.LinkedInProfile, page *rod.Page) error {
-	bm.SetApplying(true)
+// ErrManualLoginTimeout is returned when the user doesn't sign in within
+// Config.ManualLoginTimeout. It wraps ErrLoginFailed.
+var ErrManualLoginTimeout = fmt.Errorf("%w: timed out waiting for a manual login", ErrLoginFailed)
+
+// manualLoginPollInterval is how often waitForManualLogin checks for a
+// signed-in page
+var manualLoginPollInterval = 2 * time.Second
+
+// waitForManualLogin opens LinkedIn's login page and waits for the user to
+// sign in, solving any challenge along the way. It emits
+// browser:manual-login while waiting and browser:logged-in once signed in.
+// A session kept in the user data directory is signed in straight away.
+func (bm *BrowserManager) waitForManualLogin(pc PageController) error {
+	bm.mu.RLock()
+	ctx := bm.ctx
+	bm.mu.RUnlock()
+
+	timeout := bm.cfg.ManualLoginTimeout
+	if timeout <= 0 {
+		timeout = DefaultManualLoginTimeout
+	}
+	if err := pc.Navigate("https://www.linkedin.com/login"); err != nil {
+		return fmt.Errorf("failed to open login page: %w", err)
+	}
+
+	fmt.Printf("🔑 Waiting up to %v for you to sign in to LinkedIn\n", timeout)
+	bm.emit("browser:manual-login", map[string]interface{}{
+		"timeoutSeconds": int(timeout.Seconds()),
+	})
+	deadline := time.Now().Add(timeout)
+	for {
+		if url, err := pc.URL(); err == nil {
+			if html, err := pc.HTML(); err == nil && IsLoggedInPage(url, html) {
+				fmt.Println("✅ Signed in to LinkedIn")
+				bm.emit("browser:logged-in", nil)
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return ErrManualLoginTimeout
+		}
+		select {
+		case <-time.After(manualLoginPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// mustType is typeText for use inside rod.Try, panicking on failure like
+// rod's Must methods
+func mustType(el *rod.Element, text string, timing Timing) {
+	if err := typeText(el, text, timing); err != nil {
+		panic(err)
+	}
+}
+
+// submitLogin fills in and submits LinkedIn's login form. Each step fails
+// with a timeout error rather than hanging if the page doesn't cooperate.
+func (bm *BrowserManager) submitLogin(page *rod.Page, email, password string) error {
+	return rod.Try(func() {
+		bm.timed(page).MustNavigate("https://linkedin.com")
+		time.Sleep(300 * time.Millisecond)
+		bm.timed(page).MustNavigate("https://www.linkedin.com/login?trk=guest_homepage-basic_nav-header-signin")
+		// 1. Find username field and input email
+		userField := bm.timed(page).MustElement("#username")
+		mustType(userField, email, bm.cfg.Timing)
+
+		// 2. Press Tab
+		userField.MustWaitInteractable()
+		page.Keyboard.Press(input.Tab)
+
+		// 3. Wait 2 seconds (or use a better wait if possible)
+		bm.timed(page).MustWaitRequestIdle() // or
+		time.Sleep(2 * time.Second)
+
+		// 4. Find password field and input password
+		pwField := bm.timed(page).MustElement("#password")
+		mustType(pwField, password, bm.cfg.Timing)
+
+		// 5. Wait 2 seconds
+		bm.timed(page).MustWaitRequestIdle() // or
+		time.Sleep(2 * time.Second)
+
+		// 6. Find login button and click
+		loginButton := bm.timed(page).MustElement(".btn__primary--large")
+		loginButton.MustClick()
+
+		// 7. Wait 3 seconds
+		bm.timed(page).MustWaitRequestIdle() // or
+		time.Sleep(3 * time.Second)
+	})
+}
+
+// timed returns page bounded by Config.OperationTimeout. rod timeouts are
+// deadlines rather than per-call limits, so call this for each blocking
+// operation instead of once per page.
+func (bm *BrowserManager) timed(page *rod.Page) *rod.Page {
+	timeout := bm.cfg.OperationTimeout
+	if timeout <= 0 {
+		timeout = DefaultOperationTimeout
+	}
+	return page.Timeout(timeout)
+}
+
+// ApplyOptions adjust a single StartApplying run
+type ApplyOptions struct {
+	MaxJobsThisSession int  // Stop after this many submitted applications in this run (0 = unlimited)
+	Resume             bool // Pick up from the search page where the last unfinished run stopped
+}
+
+// sessionLimit returns the tighter of two application limits, where 0 is
+// unlimited
+func sessionLimit(maxApplications, maxThisSession int) int {
+	if maxThisSession > 0 && (maxApplications <= 0 || maxThisSession < maxApplications) {
+		return maxThisSession
+	}
+	return maxApplications
+}
+
+// StartApplying searches for jobs and applies to them until it runs out of
+// jobs or reaches Config.MaxApplications. It returns the number of
+// applications submitted. If LinkedIn throttles the run, applying stops and
+// is refused with ErrCoolingDown until Config.RateLimitCooldown passes.
+func (bm *BrowserManager) StartApplying(profile *store.LinkedInProfile, page *rod.Page) (applied int, err error) {
+	return bm.StartApplyingWith(profile, page, ApplyOptions{})
+}
+
+// StartApplyingWith is StartApplying with per-run options. With
+// opts.MaxJobsThisSession set it also stops once that many applications
+// are submitted; skipped and failed jobs don't count. Progress through the
+// search is checkpointed after each page, and opts.Resume continues from
+// the last checkpoint of a run that stopped before the end of the results.
+func (bm *BrowserManager) StartApplyingWith(profile *store.LinkedInProfile, page *rod.Page, opts ApplyOptions) (applied int, err error) {
+	if err := bm.CheckCooldown(); err != nil {
+		return 0, err
+	}
+	if !bm.beginApplying() {
+		return 0, ErrAlreadyApplying
+	}
+	defer bm.SetApplying(false)
+	defer func() {
+		if isThrottled(err) {
+			bm.startCooldown(err)
+		}
+	}()
+
+	if len(profile.Positions) == 0 || len(profile.Locations) == 0 {
+		return 0, fmt.Errorf("profile needs at least one position and location")
+	}
+	if page == nil {
+		shared, err := bm.SharedPage()
+		if err != nil {
+			return 0, err
+		}
+		page = shared
+	}
+	ctx, cancel := bm.startSessionDeadline()
+	defer cancel()
+	page = page.Context(ctx) // Page operations stop when the session times out
+	defer bm.startCompanyThrottle(profile.ID)()
 	rand.Seed(time.Now().UnixNano())
 	position := profile.Positions[rand.Intn(len(profile.Positions))]
 	location := profile.Locations[rand.Intn(len(profile.Locations))]
-	jobsPerPage := 0
-	IDs := []int{}
+	start := 0
+	if opts.Resume {
+		if checkpoint := bm.resumePoint(profile); checkpoint != nil {
+			position, location, start = checkpoint.Position, checkpoint.Location, checkpoint.Start
+			fmt.Printf("⏩ Resuming %s in %s from result %d\n", position, location, start)
+		}
+	}
+	seen := bm.skippedExternalJobs(profile.ID)
+	session := ApplySession{StartedAt: time.Now()}
+	bm.setSession(session)
+	defer func() {
+		if err != nil && errors.Is(sessionErr(ctx), ErrSessionTimeout) {
+			fmt.Printf("⏱️ Stopping: the session ran for its limit of %v\n", bm.cfg.SessionTimeout)
+			session.StopReason = StopReasonSessionTimeout
+			applied, err = session.Applied, nil
+		}
+		session.EndedAt = time.Now()
+		bm.setSession(session)
+		fmt.Printf("📊 Session summary: %d found, %d applied, %d failed, %d skipped, %d excluded, %d external\n",
+			session.Found, session.Applied, session.Failed, session.Skipped, session.Excluded, session.External)
+		bm.emit("browser:session-summary", map[string]interface{}{
+			"session": session,
+		})
+	}()
+	exhausted := false
+	defer func() {
+		// Only a run that reached the end of the search results forgets
+		// its checkpoint; one that was interrupted or hit its limit
+		// resumes from it
+		if exhausted {
+			bm.clearCheckpoint(profile.ID)
+		}
+	}()
+	restarts := 0
+	maxRestarts := bm.cfg.MaxRestarts
+	if maxRestarts <= 0 {
+		maxRestarts = DefaultMaxRestarts
+	}
+	maxApplications := sessionLimit(bm.cfg.MaxApplications, opts.MaxJobsThisSession)
+	failures := &failureStreak{max: bm.cfg.MaxConsecutiveFailures}
+	if failures.max <= 0 {
+		failures.max = DefaultMaxConsecutiveFailures
+	}
 	fmt.Printf("⚪ Starting application bot with position: %s in location: %s\n", position, location)
+	bm.applyGeolocation(page, profile)
+	pc := bm.controller(page)
+	throttled, stopWatching := watchThrottling(page)
+	defer func() { stopWatching() }() // The watcher is replaced after a recovery
+	queue := bm.openJobQueue(profile.ID)
+	wait := func() error {
+		bm.setSession(session)
+		if err := sessionErr(ctx); err != nil {
+			return err
+		}
+		if throttled.Load() {
+			return fmt.Errorf("%w: LinkedIn answered with HTTP 429", ErrThrottled)
+		}
+		if err := bm.waitIfPaused(); err != nil {
+			return err
+		}
+		return bm.waitForChallenge(page)
+	}
+	apply := func(jobID int) (string, error) {
+		status, err := recoverPanic(func() (string, error) {
+			return bm.applyToJob(pc, profile, jobID, func(resumePath string) (bool, error) {
+				return bm.FillOutEasyApplyForm(page, profile, resumePath)
+			})
+		})
+		if err == nil {
+			queue.done(jobID)
+			return status, nil
+		}
+		if err := sessionErr(ctx); err != nil {
+			return store.ApplicationStatusFailed, err
+		}
+		if bm.Ping() == nil {
+			bm.snapshotFailure(page, jobID)
+			queue.done(jobID)
+			return status, err
+		}
+
+		// The browser crashed or the connection dropped mid-job. The job
+		// stays queued for the next run.
+		if bm.stopped() {
+			return store.ApplicationStatusFailed, err
+		}
+		if restarts >= maxRestarts {
+			return store.ApplicationStatusFailed, fmt.Errorf("%w: giving up after %d restarts: %v", ErrBrowserLost, restarts, err)
+		}
+		restarts++
+		fmt.Printf("⚠️ Browser lost during job ID %d (%v), restarting (%d/%d)\n", jobID, err, restarts, maxRestarts)
+		newPage, rerr := bm.recoverSession(profile)
+		if rerr != nil {
+			return store.ApplicationStatusFailed, fmt.Errorf("%w: failed to recover: %v", ErrBrowserLost, rerr)
+		}
+		page = newPage.Context(ctx)
+		pc = bm.controller(page)
+		stopWatching()
+		throttled, stopWatching = watchThrottling(page)
+		bm.applyGeolocation(page, profile)
+		fmt.Println("✅ Browser recovered, resuming with the next job")
+		bm.emit("browser:recovered", map[string]interface{}{
+			"jobId":    jobID,
+			"restarts": restarts,
+		})
+		return store.ApplicationStatusFailed, nil
+	}
+	run := func(IDs []int) (bool, error) {
+		limitReached, err := applyToJobs(IDs, &session, maxApplications, failures, wait, apply)
+		if errors.Is(err, ErrTooManyFailures) {
+			fmt.Printf("🛑 Stopping: %v\n", err)
+			bm.emit("browser:circuit-broken", map[string]interface{}{
+				"failures": failures.count,
+			})
+		}
+		return limitReached, err
+	}
+
+	// Finish the jobs an interrupted run queued before searching again
+	if leftover := queue.leftover(); len(leftover) > 0 {
+		fmt.Printf("⏩ Picking up %d queued jobs from the last run\n", len(leftover))
+		for _, id := range leftover {
+			seen[id] = true
+		}
+		limitReached, err := run(leftover)
+		if err != nil {
+			return session.Applied, err
+		}
+		if limitReached {
+			fmt.Printf("✅ Reached max applications (%d), stopping\n", session.Applied)
+			return session.Applied, nil
+		}
+	}
 	for {
-		jobsPageUrl := fmt.Sprintf("https://www.linkedin.com/jobs/search/?f_LF=f_AL&keywords=%s&location=%s&sortBy=DD&start=%d",
-			position, location, jobsPerPage)
-		page.MustNavigate(jobsPageUrl)
+		jobsPageUrl := BuildJobSearchURL(position, location, start, profile.SearchSort, profile.PostedWithin,
+			profile.ExperienceLevels, profile.JobTypes)
+		if err := pc.Navigate(jobsPageUrl); err != nil {
+			return session.Applied, err
+		}
 		time.Sleep(1 * time.Second) // Add a delay to let jobs page load
 		if _, err := bm.LoadPage(page); err != nil {
-			return fmt.Errorf("failed to load page: %w", err)
-		}
-		links := page.MustElementsX("//div[@data-job-id]")
-		if links.Empty() {
-			return fmt.Errorf("No job links found, stopping application process.")
-		}
-		for _, element := range links {
-			children := element.MustElementsX(".//a[contains(@class, 'job-card-container__link')]")
-			for _, child := range children {
-				jobLink := child.MustAttribute("href")
-				jobID, ok := ExtractJobID(*jobLink)
-				if !ok {
-					fmt.Printf("Failed to extract job ID from link: %s\n", *jobLink)
+			return session.Applied, fmt.Errorf("failed to load page: %w", err)
+		}
+		IDs, listed, err := collectJobIDs(pc, seen, profile.TitleExcludeKeywords, &session, func(card JobCard, reason string) {
+			bm.recordApplication(store.Application{
+				ProfileID: profile.ID,
+				JobID:     card.ID,
+				Title:     card.Title,
+				Status:    store.ApplicationStatusSkipped,
+				Reason:    reason,
+			})
+		})
+		if err != nil {
+			return session.Applied, err
+		}
+		if done, err := endOfResults(listed, len(IDs)); done {
+			exhausted = true
+			return session.Applied, err
+		}
+		start += listed
+		queue.add(IDs)
+		limitReached, err := run(IDs)
+		if err != nil {
+			return session.Applied, err
+		}
+		bm.saveCheckpoint(profile.ID, position, location, start)
+		if limitReached {
+			fmt.Printf("✅ Reached max applications (%d), stopping\n", session.Applied)
+			return session.Applied, nil
+		}
+	}
+}
+
+// startSessionDeadline starts Config.SessionTimeout for an apply session,
+// stopped while the run is paused. The returned context ends when it
+// passes; cancel ends it with the session. It isn't tied to the browser's
+// context, which a restart mid-session replaces.
+func (bm *BrowserManager) startSessionDeadline() (context.Context, context.CancelFunc) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	ctx, deadline, cancel := newSessionDeadline(context.Background(), bm.cfg.SessionTimeout)
+	if bm.resumeCh != nil {
+		deadline.pause()
+	}
+	bm.deadline = deadline
+	return ctx, func() {
+		cancel()
+		bm.mu.Lock()
+		defer bm.mu.Unlock()
+		if bm.deadline == deadline {
+			bm.deadline = nil
+		}
+	}
+}
+
+// ApplyToJobURL applies to the single job at jobURL the same way
+// StartApplying applies to each search result, recording the outcome. It
+// returns the application status, which makes a problematic posting easy
+// to reproduce.
+func (bm *BrowserManager) ApplyToJobURL(profile *store.LinkedInProfile, page *rod.Page, jobURL string) (status string, err error) {
+	jobID, ok := JobIDFromURL(jobURL)
+	if !ok {
+		return "", fmt.Errorf("not a LinkedIn job URL: %s", jobURL)
+	}
+	if err := bm.CheckCooldown(); err != nil {
+		return "", err
+	}
+	if !bm.beginApplying() {
+		return "", ErrAlreadyApplying
+	}
+	defer bm.SetApplying(false)
+	defer func() {
+		if isThrottled(err) {
+			bm.startCooldown(err)
+		}
+	}()
+
+	if page == nil {
+		if page, err = bm.SharedPage(); err != nil {
+			return "", err
+		}
+	}
+	bm.applyGeolocation(page, profile)
+	if err := bm.waitForChallenge(page); err != nil {
+		return "", err
+	}
+	status, err = recoverPanic(func() (string, error) {
+		return bm.applyToJob(bm.controller(page), profile, jobID, func(resumePath string) (bool, error) {
+			return bm.FillOutEasyApplyForm(page, profile, resumePath)
+		})
+	})
+	if err != nil {
+		bm.snapshotFailure(page, jobID)
+	}
+	return status, err
+}
+
+// RetryFailed re-attempts the profile's failed applications, skipping jobs
+// that have since been applied to, and updates each application's status
+// with the outcome. It returns the number of applications submitted.
+func (bm *BrowserManager) RetryFailed(profile *store.LinkedInProfile, page *rod.Page) (applied int, err error) {
+	if err := bm.CheckCooldown(); err != nil {
+		return 0, err
+	}
+	if !bm.beginApplying() {
+		return 0, ErrAlreadyApplying
+	}
+	defer bm.SetApplying(false)
+	defer func() {
+		if isThrottled(err) {
+			bm.startCooldown(err)
+		}
+	}()
+
+	bm.mu.RLock()
+	retrier, ok := bm.recorder.(ApplicationRetrier)
+	bm.mu.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("application recorder can't retry failed applications")
+	}
+
+	failed, err := retrier.ListFailedApplications(profile.ID)
+	if err != nil {
+		return 0, err
+	}
+	if page == nil {
+		if page, err = bm.SharedPage(); err != nil {
+			return 0, err
+		}
+	}
+
+	fmt.Printf("⚪ Retrying %d failed applications\n", len(failed))
+	bm.applyGeolocation(page, profile)
+	wait := func() error {
+		if err := bm.waitIfPaused(); err != nil {
+			return err
+		}
+		return bm.waitForChallenge(page)
+	}
+	pc := bm.controller(page)
+	fill := func(resumePath string) (bool, error) {
+		return bm.FillOutEasyApplyForm(page, profile, resumePath)
+	}
+	return retryApplications(failed, wait, func(jobID int) (string, error) {
+		status, err := recoverPanic(func() (string, error) {
+			return bm.retryJob(pc, profile, jobID, fill)
+		})
+		if err != nil {
+			bm.snapshotFailure(page, jobID)
+		}
+		return status, err
+	}, retrier.UpdateApplicationStatus)
+}
+
+// retryApplications retries each failed application and stores any change
+// in status. It stops at the first error from wait or update, or when
+// LinkedIn rate limits a retry, and returns the number of applications that
+// went through.
+func retryApplications(apps []store.Application, wait func() error, retry func(jobID int) (string, error), update func(id int64, status string) error) (int, error) {
+	applied := 0
+	for _, app := range apps {
+		if wait != nil {
+			if err := wait(); err != nil {
+				return applied, err
+			}
+		}
+		status, err := retry(app.JobID)
+		if isThrottled(err) {
+			return applied, err
+		}
+		if err != nil {
+			fmt.Printf("❌ Retry failed for job ID %d: %v\n", app.JobID, err)
+			status = store.ApplicationStatusFailed
+		}
+		if status == store.ApplicationStatusApplied {
+			applied++
+		}
+		if status == app.Status {
+			continue
+		}
+		if err := update(app.ID, status); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// retryJob opens a job and runs the Easy Apply form again with fill,
+// returning the resulting application status
+func (bm *BrowserManager) retryJob(pc PageController, profile *store.LinkedInProfile, jobID int, fill formFiller) (string, error) {
+	fmt.Printf("⚪ Retrying job ID: %d\n", jobID)
+	if err := pc.Navigate(fmt.Sprintf("https://www.linkedin.com/jobs/view/%d", jobID)); err != nil {
+		return store.ApplicationStatusFailed, err
+	}
+	title := ""
+	if html, err := pc.HTML(); err == nil {
+		title, _ = ParseJobDetails(html)
+	}
+	openPages := bm.pageTargets()
+	if err := clickEasyApply(pc); err != nil {
+		fmt.Printf("❌ No Easy Apply button for job ID %d: %v\n", jobID, err)
+		return store.ApplicationStatusSkipped, nil
+	}
+	if _, ok := bm.leftLinkedIn(pc, openPages); ok {
+		return store.ApplicationStatusExternalRedirect, nil
+	}
+	if err := checkApplyLimit(pc); err != nil {
+		return store.ApplicationStatusFailed, err
+	}
+	submitted, err := fill(bm.resumeFor(profile.ID, title))
+	if err != nil || !submitted {
+		return store.ApplicationStatusFailed, err
+	}
+	fmt.Printf("✅ Successfully applied for job ID %d on retry\n", jobID)
+	return store.ApplicationStatusApplied, nil
+}
+
+// applyGeolocation points the page's geolocation at the profile's city and
+// state when Config.SpoofLocation is set. Failures are logged, not fatal.
+func (bm *BrowserManager) applyGeolocation(page *rod.Page, profile *store.LinkedInProfile) {
+	if !bm.cfg.SpoofLocation {
+		return
+	}
+	coords, ok := LookupCoordinates(profile.UserCity, profile.UserState)
+	if !ok {
+		log.Printf("No coordinates for %s, %s; leaving geolocation unchanged", profile.UserCity, profile.UserState)
+		return
+	}
+	if err := setGeolocation(page, coords); err != nil {
+		log.Printf("Failed to set geolocation: %v", err)
+	}
+}
+
+// checkApplyLimit returns ErrThrottled if the page shows LinkedIn's Easy
+// Apply limit notice
+func checkApplyLimit(pc PageController) error {
+	if html, err := pc.HTML(); err == nil && isEasyApplyLimitReached(html) {
+		return fmt.Errorf("%w: reached the Easy Apply limit", ErrThrottled)
+	}
+	return nil
+}
+
+// clickEasyApply clicks the job's primary Easy Apply button, failing if
+// there isn't a usable one
+func clickEasyApply(pc PageController) error {
+	found, err := pc.ClickEasyApply()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrEasyApplyNotFound
+	}
+	return nil
+}
+
+// formFiller fills out and submits the open Easy Apply form using the given
+// resume, reporting whether the application was submitted
+type formFiller func(resumePath string) (bool, error)
+
+// applyToJob opens a job and submits an Easy Apply application for it with
+// fill, recording the outcome. It returns the application status.
+func (bm *BrowserManager) applyToJob(pc PageController, profile *store.LinkedInProfile, jobID int, fill formFiller) (string, error) {
+	fmt.Printf("⚪ Applying to job ID: %d\n", jobID)
+	if err := pc.Navigate(fmt.Sprintf("https://www.linkedin.com/jobs/view/%d", jobID)); err != nil {
+		fmt.Printf("❌ Skipping job ID %d: %v\n", jobID, err)
+		return store.ApplicationStatusFailed, err
+	}
+	delay := bm.cfg.ApplyDelay
+	if delay <= 0 {
+		delay = DefaultApplyDelay
+	}
+	time.Sleep(delay)
+	record := store.Application{ProfileID: profile.ID, JobID: jobID}
+	var salary SalaryRange
+	salaryListed := false
+	posterMatches := true
+	alreadyApplied := false
+	if html, err := pc.HTML(); err == nil {
+		record.Title, record.Company = ParseJobDetails(html)
+		alreadyApplied = IsAlreadyApplied(html)
+		salary, salaryListed = ParseJobSalary(html)
+		posterMatches = bm.matchesPoster(html)
+	}
+	if alreadyApplied {
+		fmt.Printf("⏭️ Skipping job ID %d: already applied on LinkedIn\n", jobID)
+		record.Status = store.ApplicationStatusSkipped
+		record.Reason = store.SkipReasonAlreadyApplied
+		bm.recordApplication(record)
+		return record.Status, nil
+	}
+	if salaryListed && belowSalaryFloor(salary, profile.DesiredSalary) {
+		fmt.Printf("⏭️ Skipping job ID %d: salary tops out at %d, below %d\n", jobID, salary.Max, profile.DesiredSalary)
+		record.Status = store.ApplicationStatusSkipped
+		record.Reason = store.SkipReasonBelowSalaryFloor
+		bm.recordApplication(record)
+		return record.Status, nil
+	}
+	if companies := bm.companyThrottle(); !companies.allow(record.Company) {
+		fmt.Printf("⏭️ Skipping job ID %d: already applied to %d jobs at %s\n", jobID, companies.max, record.Company)
+		record.Status = store.ApplicationStatusSkipped
+		record.Reason = store.SkipReasonCompanyLimit
+		bm.recordApplication(record)
+		return record.Status, nil
+	}
+	if !posterMatches {
+		fmt.Printf("⏭️ Skipping job ID %d: doesn't match the %q poster preference\n", jobID, bm.cfg.PosterPreference)
+		record.Status = store.ApplicationStatusSkipped
+		record.Reason = store.SkipReasonPosterPreference
+		bm.recordApplication(record)
+		return record.Status, nil
+	}
+	if description, err := scrapeJobDescription(pc); err == nil {
+		record.Description = description
+	} else {
+		fmt.Printf("⚠️ Continuing without a description for job ID %d: %v\n", jobID, err)
+	}
+	openPages := bm.pageTargets()
+	if err := clickEasyApply(pc); err != nil {
+		fmt.Printf("❌ No Easy Apply button for job ID %d: %v\n", jobID, err)
+		record.Status = store.ApplicationStatusSkipped
+		bm.recordApplication(record)
+		return record.Status, nil
+	}
+	if external, ok := bm.leftLinkedIn(pc, openPages); ok {
+		fmt.Printf("⏭️ Skipping job ID %d: Easy Apply opened %s\n", jobID, external)
+		record.Status = store.ApplicationStatusExternalRedirect
+		bm.recordApplication(record)
+		return record.Status, nil
+	}
+	if err := checkApplyLimit(pc); err != nil {
+		return store.ApplicationStatusFailed, err
+	}
+	fmt.Printf("⚪ Found Easy Apply button for job ID %d, attempting to apply...\n", jobID)
+	submitted, err := fill(bm.resumeFor(profile.ID, record.Title))
+	switch {
+	case err != nil:
+		fmt.Printf("❌ Failed to apply for job ID %d: %v\n", jobID, err)
+		record.Status = store.ApplicationStatusFailed
+	case !submitted:
+		fmt.Printf("❌ Could not submit application for job ID %d\n", jobID)
+		record.Status = store.ApplicationStatusFailed
+	default:
+		fmt.Printf("✅ Successfully applied for job ID %d\n", jobID)
+		record.Status = store.ApplicationStatusApplied
+		bm.companyThrottle().record(record.Company, 1)
+	}
+	bm.recordApplication(record)
+	return record.Status, err
+}
+
+// pageTargets returns the target IDs of every open tab
+func (bm *BrowserManager) pageTargets() map[proto.TargetTargetID]bool {
+	targets := map[proto.TargetTargetID]bool{}
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return targets
+	}
+	pages, err := browser.Pages()
+	if err != nil {
+		return targets
+	}
+	for _, p := range pages {
+		targets[p.TargetID] = true
+	}
+	return targets
+}
+
+// redirectSettleDelay is how long leftLinkedIn waits after clicking Easy
+// Apply before looking for a redirect or popup
+var redirectSettleDelay = 1 * time.Second
+
+// leftLinkedIn checks whether clicking Easy Apply navigated the job page off
+// LinkedIn or opened a new tab that wasn't in before. New tabs are closed.
+// It returns the offending URL.
+func (bm *BrowserManager) leftLinkedIn(pc PageController, before map[proto.TargetTargetID]bool) (string, bool) {
+	time.Sleep(redirectSettleDelay) // Give a redirect or popup time to happen
+
+	external := ""
+	if browser := bm.GetBrowser(); browser != nil {
+		if pages, err := browser.Pages(); err == nil {
+			for _, p := range pages {
+				if before[p.TargetID] {
 					continue
 				}
-				IDs = append(IDs, jobID)
+				if info, err := p.Info(); err == nil && external == "" {
+					external = info.URL
+				}
+				if err := p.Close(); err != nil {
+					log.Printf("Failed to close stray tab: %v", err)
+				}
 			}
 		}
-		for _, jobID := range IDs {
-			fmt.Printf("⚪ Applying to job ID: %d\n", jobID)
-			page.MustNavigate(fmt.Sprintf("https://www.linkedin.com/jobs/view/%d", jobID))
-			time.Sleep(2 * time.Second)
-			_, err := bm.GetEasyApplyButton(page)
-			if err != nil {
-				fmt.Printf("❌ No Easy Apply button for job ID %d: %v\n", jobID, err)
-				continue
+	}
+	if external != "" {
+		return external, true
+	}
+
+	if url, err := pc.URL(); err == nil && !IsLinkedInURL(url) {
+		return url, true
+	}
+	return "", false
+}
+
+// recoverPanic runs fn, converting a panic from a rod Must* call into an error
+func recoverPanic[T any](fn func() (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result, err = zero, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// collectJobIDs reads the job cards on the search results page pc shows and
+// returns the IDs of jobs not in seen, marking them seen. Titles matching an
+// exclude keyword are counted as excluded instead, and cards showing the job
+// is applied to off LinkedIn are counted as skipped. Both are passed to
+// onSkip, if set, with the store.SkipReason they were left out for. listed
+// is the number of cards on the page, including ones already seen.
+func collectJobIDs(pc PageController, seen map[int]bool, exclude []string, session *ApplySession, onSkip func(card JobCard, reason string)) (ids []int, listed int, err error) {
+	html, err := pc.HTML()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read search results: %w", err)
+	}
+
+	cards := ParseJobCards(html)
+	for _, card := range cards {
+		if seen[card.ID] {
+			continue
+		}
+		seen[card.ID] = true
+		session.Found++
+		if keyword, excluded := MatchExcludedKeyword(card.Title, exclude); excluded {
+			fmt.Printf("⏭️ Skipping job ID %d (%s): title contains excluded keyword %q\n", card.ID, card.Title, keyword)
+			session.Excluded++
+			if onSkip != nil {
+				onSkip(card, store.SkipReasonTitleExcluded)
 			}
-			fmt.Printf("⚪ Found Easy Apply button for job ID %d, attempting to apply...\n", jobID)
-			_, err = bm.FillOutEasyApplyForm(page, profile)
-			if err != nil {
-				fmt.Printf("❌ Failed to apply for job ID %d: %v\n", jobID, err)
-			} else {
-				fmt.Printf("✅ Successfully applied for job ID %d\n", jobID)
+			continue
+		}
+		if card.External {
+			fmt.Printf("⏭️ Skipping job ID %d (%s): applied to on the company site\n", card.ID, card.Title)
+			session.Skipped++
+			if onSkip != nil {
+				onSkip(card, store.SkipReasonExternal)
+			}
+			continue
+		}
+		ids = append(ids, card.ID)
+	}
+	return ids, len(cards), nil
+}
+
+// endOfResults reports whether a search results page listing listed jobs,
+// of which fresh are new to the run, ends the search, and the error the
+// run stops with
+func endOfResults(listed, fresh int) (bool, error) {
+	if listed == 0 {
+		return true, fmt.Errorf("%w, stopping application process", ErrNoJobsFound)
+	}
+	if fresh == 0 {
+		fmt.Println("✅ No new jobs in the search results, stopping")
+		return true, nil
+	}
+	return false, nil
+}
+
+// applyToJobs calls apply for each job ID, tallying the returned statuses in
+// session. It stops early and returns true once session.Applied reaches max
+// (0 means unlimited). If wait is non-nil it is called before each job and
+// an error from it aborts the loop. Errors from apply only fail that job,
+// except ErrBrowserLost and rate limiting, which abort the loop. Once
+// failures, which carries over between calls, reaches its maximum the loop
+// aborts with ErrTooManyFailures; a nil failures never aborts.
+func applyToJobs(jobIDs []int, session *ApplySession, max int, failures *failureStreak, wait func() error, apply func(jobID int) (string, error)) (bool, error) {
+	for _, jobID := range jobIDs {
+		if max > 0 && session.Applied >= max {
+			return true, nil
+		}
+		if wait != nil {
+			if err := wait(); err != nil {
+				return false, err
 			}
 		}
+		status, err := apply(jobID)
+		if errors.Is(err, ErrBrowserLost) || isThrottled(err) {
+			return false, err
+		}
+		session.record(status, err)
+		if failures.record(status, err) {
+			return false, fmt.Errorf("%w: the last %d applications failed", ErrTooManyFailures, failures.count)
+		}
+	}
+	return max > 0 && session.Applied >= max, nil
+}
+
+// setSession publishes a snapshot of the running session for SessionStats
+func (bm *BrowserManager) setSession(session ApplySession) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.session = session
+}
+
+// SessionStats returns the tallies of the current apply session, or of the
+// last one if none is running. Counts are refreshed between jobs.
+func (bm *BrowserManager) SessionStats() ApplySession {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return bm.session
+}
+
+// findJobList waits for the first element matching any of JobListSelectors
+func (bm *BrowserManager) findJobList(page *rod.Page) (*rod.Element, error) {
+	race := bm.timed(page).Race()
+	for _, sel := range JobListSelectors {
+		race = race.Element(sel)
+	}
+	el, err := race.Do()
+	if err != nil {
+		return nil, fmt.Errorf("no job list container matched %v: %w", JobListSelectors, err)
 	}
+	return el, nil
 }
 
+// LoadPage scrolls the job list until no new jobs load, bounded by these
+const (
+	loadPageMaxScrolls   = 30 // Safety cap on scrolls per results page
+	loadPageStableRounds = 3  // Scrolls without new jobs before stopping
+)
+
 func (bm *BrowserManager) LoadPage(page *rod.Page) (*goquery.Document, error) {
 	// Find the job list container and hover over it so scroll targets it
-	jobList, err := page.Element(".scaffold-layout__list")
+	jobList, err := bm.findJobList(page)
 	if err != nil {
 		fmt.Printf("Could not find job list container: %v\n", err)
 		return nil, err
@@ -216,12 +1401,36 @@ func (bm *BrowserManager) LoadPage(page *rod.Page) (*goquery.Document, error) {
 		return nil, err
 	}
 
-	for i := 0; i < 14; i++ {
-		if err := page.Mouse.Scroll(0, 200, 1); err != nil {
+	var box *proto.DOMRect
+	if shape, err := jobList.Shape(); err == nil {
+		box = shape.Box()
+	}
+
+	pattern := newScrollPattern(time.Now().UnixNano())
+	scroll := func(i int) error {
+		step := pattern.next()
+		if err := page.Mouse.Scroll(0, step.Delta, 1); err != nil {
 			fmt.Printf("Error scrolling on iteration %d: %v\n", i, err)
-			return nil, err
+			return err
 		}
-		time.Sleep(2 * time.Second)
+		if step.Wiggle && box != nil {
+			// Drift the cursor around the middle of the list so it stays the scroll target
+			x := box.X + box.Width/2 + pattern.offset(box.Width/4)
+			y := box.Y + box.Height/2 + pattern.offset(box.Height/4)
+			_ = page.Mouse.MoveTo(proto.Point{X: x, Y: y})
+		}
+		time.Sleep(step.Dwell)
+		return nil
+	}
+	countJobs := func() int {
+		jobs, err := page.Elements("div[data-job-id]")
+		if err != nil {
+			return 0
+		}
+		return len(jobs)
+	}
+	if _, err := scrollUntilStable(loadPageMaxScrolls, loadPageStableRounds, scroll, countJobs); err != nil {
+		return nil, err
 	}
 	html, err := page.HTML()
 	if err != nil {
@@ -236,49 +1445,145 @@ func (bm *BrowserManager) LoadPage(page *rod.Page) (*goquery.Document, error) {
 	return doc, nil
 }
 
+// ErrNoJobDescription is returned by ScrapeJobDescription when the job page
+// has no description element
+var ErrNoJobDescription = errors.New("job description not found")
+
+// ScrapeJobDescription returns the description text of the job page is
+// showing. A "see more" expander is clicked first so truncated descriptions
+// are read in full.
+func (bm *BrowserManager) ScrapeJobDescription(page *rod.Page) (string, error) {
+	return scrapeJobDescription(bm.controller(page))
+}
+
+// scrapeJobDescription implements ScrapeJobDescription over a PageController
+func scrapeJobDescription(pc PageController) (string, error) {
+	for _, sel := range SeeMoreDescriptionSelectors {
+		clicked, err := pc.Click(sel)
+		if err != nil {
+			log.Printf("Failed to expand job description: %v", err)
+		}
+		if clicked || err != nil {
+			break
+		}
+	}
+
+	html, err := pc.HTML()
+	if err != nil {
+		return "", fmt.Errorf("failed to read job page: %w", err)
+	}
+	description := ParseJobDescription(html)
+	if description == "" {
+		return "", ErrNoJobDescription
+	}
+	return description, nil
+}
+
+// GetEasyApplyButton clicks the job's Easy Apply button. When several
+// elements are labelled for Easy Apply, the primary one is chosen with
+// selectEasyApplyButton.
 func (bm *BrowserManager) GetEasyApplyButton(page *rod.Page) (bool, error) {
-	page.MustWaitLoad()
-	buttons := page.MustElementsX(`//*[contains(@aria-label, "Easy Apply to")]`)
+	if err := bm.timed(page).WaitLoad(); err != nil {
+		return false, fmt.Errorf("failed to wait for the job page: %w", err)
+	}
+	if err := clickEasyApply(bm.controller(page)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	// If you want to click the first one
-	if len(buttons) > 0 {
-		buttons[0].MustClick()
-		return true, nil
+// easyApplyCandidate is an element labelled for Easy Apply, with what
+// selectEasyApplyButton needs to know about it
+type easyApplyCandidate struct {
+	el        *rod.Element
+	label     string
+	visible   bool
+	enabled   bool
+	inTopCard bool // Inside the job's top card rather than e.g. a sticky header
+}
+
+// easyApplyCandidates reads what selectEasyApplyButton needs from each
+// element. Elements that can't be read are left out.
+func easyApplyCandidates(els rod.Elements) []easyApplyCandidate {
+	var candidates []easyApplyCandidate
+	for _, el := range els {
+		visible, err := el.Visible()
+		if err != nil {
+			continue
+		}
+		c := easyApplyCandidate{el: el, visible: visible}
+		if label, err := el.Attribute("aria-label"); err == nil && label != nil {
+			c.label = *label
+		}
+		disabled, err := el.Attribute("disabled")
+		ariaDisabled, _ := el.Attribute("aria-disabled")
+		c.enabled = err == nil && disabled == nil && (ariaDisabled == nil || *ariaDisabled != "true")
+		if res, err := el.Eval(`(sel) => this.closest(sel) !== null`, JobTopCardSelector); err == nil {
+			c.inTopCard = res.Value.Bool()
+		}
+		candidates = append(candidates, c)
 	}
+	return candidates
+}
 
-	return false, errors.New("Easy Apply button not found")
+// selectEasyApplyButton picks the primary Easy Apply button: a visible,
+// enabled one, inside the top card if any are, and then one whose label
+// starts with "Easy Apply to"
+func selectEasyApplyButton(candidates []easyApplyCandidate) (*rod.Element, error) {
+	var usable, inTopCard []easyApplyCandidate
+	for _, c := range candidates {
+		if !c.visible || !c.enabled {
+			continue
+		}
+		usable = append(usable, c)
+		if c.inTopCard {
+			inTopCard = append(inTopCard, c)
+		}
+	}
+	if len(inTopCard) > 0 {
+		usable = inTopCard
+	}
+	if len(usable) == 0 {
+		return nil, ErrEasyApplyNotFound
+	}
+	for _, c := range usable {
+		if strings.HasPrefix(strings.TrimSpace(c.label), "Easy Apply to") {
+			return c.el, nil
+		}
+	}
+	return usable[0].el, nil
 }
+
 func sleepRand(minSec, maxSec float64) {
 	d := minSec + rand.Float64()*(maxSec-minSec)
 	time.Sleep(time.Duration(d * float64(time.Second)))
 }
 
-func (bm *BrowserManager) FillOutEasyApplyForm(page *rod.Page, profile *store.LinkedInProfile) (bool, error) {
+func (bm *BrowserManager) FillOutEasyApplyForm(page *rod.Page, profile *store.LinkedInProfile, resumePath string) (bool, error) {
 	const (
 		nextSel   = `button[aria-label='Continue to next step']`
 		reviewSel = `button[aria-label='Review your application']`
 		submitSel = `button[aria-label='Submit application']`
 
 		errorMessageSel = `.artdeco-inline-feedback__icon`
-		followLabelSel  = `label[for='follow-company-checkbox']`
 	)
 
 	type locator struct {
 		kind string // "css" or "xpath"
 		q    string
+		name string // for diagnostics
 	}
 
 	buttons := []locator{
-		{kind: "css", q: nextSel}, // i == 0
-		{kind: "css", q: reviewSel},
-		{kind: "css", q: followLabelSel}, // i == 2 special case
-		{kind: "css", q: submitSel},      // i == 3 => submitted
+		{kind: "css", q: nextSel, name: "next"}, // i == 0
+		{kind: "css", q: reviewSel, name: "review"},
+		{kind: "css", q: submitSel, name: "submit"}, // i == 2 => submitted
 	}
 
 	submitted := false
 
 	isPresent := func(loc locator) bool {
-		page.MustWaitLoad()
+		bm.timed(page).MustWaitLoad()
 		_, err := page.Timeout(4 * time.Second).Element(loc.q)
 		if err != nil {
 			// Check if there are iframes
@@ -324,7 +1629,7 @@ func (bm *BrowserManager) FillOutEasyApplyForm(page *rod.Page, profile *store.Li
 					continue
 				}
 				if shadowRoot := host.MustShadowRoot(); shadowRoot != nil {
-					if err := bm.FillInvalids(shadowRoot, profile, nil); err != nil {
+					if err := bm.FillInvalids(shadowRoot, profile, bm.runAnswerProvider()); err != nil {
 						log.Println("fillInvalids error:", err)
 					}
 				}
@@ -333,6 +1638,15 @@ func (bm *BrowserManager) FillOutEasyApplyForm(page *rod.Page, profile *store.Li
 
 	}
 
+	fillProfileFields := func() {
+		for _, root := range easyApplyRoots(page) {
+			bm.FillCoverLetter(root, profile, bm.runAnswerProvider())
+			bm.UploadResume(root, resumePath)
+			bm.FillPhoneCountryCode(root, profile)
+			bm.SetFollowCompany(root, bm.cfg.FollowCompany)
+		}
+	}
+
 	clickWhenClickable := func(loc locator) error {
 		var err error
 
@@ -366,12 +1680,36 @@ func (bm *BrowserManager) FillOutEasyApplyForm(page *rod.Page, profile *store.Li
 
 	sleepRand(1.5, 2.5)
 
-	for i := 0; i < 15 && !submitted; i++ {
+	var tracker stepTracker
+	lastButton := ""
+	stall := func(reason string) error {
+		err := &formStallError{
+			reason:       reason,
+			step:         tracker.step,
+			lastButton:   lastButton,
+			inlineErrors: hasErrors(),
+			field:        firstUnresolvedField(page),
+		}
+		log.Printf("Easy Apply %v", err)
+		return err
+	}
+
+	for i := 0; i < maxFormIterations && !submitted; i++ {
+		if tracker.observe(formSignature(visibleFieldIDs(page))) {
+			return false, stall(fmt.Sprintf("form did not advance (fields: %s)", tracker.last))
+		}
+		fillProfileFields()
 		handleInlineErrors()
 		for j, loc := range buttons {
 			if isPresent(loc) && !hasErrors() {
+				if j == 2 {
+					if err := bm.waitForSubmitSlot(); err != nil {
+						return false, err
+					}
+				}
 				if err := clickWhenClickable(loc); err == nil {
-					if j == 3 {
+					lastButton = loc.name
+					if j == 2 {
 						submitted = true
 						break
 					}
@@ -384,9 +1722,224 @@ func (bm *BrowserManager) FillOutEasyApplyForm(page *rod.Page, profile *store.Li
 		}
 	}
 
-	return submitted, nil
+	if !submitted {
+		return false, stall(fmt.Sprintf("gave up after %d iterations", maxFormIterations))
+	}
+	if err := bm.DismissApplicationSent(page); err != nil {
+		log.Printf("Failed to dismiss application sent modal: %v", err)
+	}
+	return true, nil
+}
+
+// maxFormIterations caps the passes FillOutEasyApplyForm makes over a form
+const maxFormIterations = 15
+
+// formStallError explains why FillOutEasyApplyForm gave up without submitting
+type formStallError struct {
+	reason       string
+	step         int
+	lastButton   string // last button clicked, empty if none
+	inlineErrors bool   // whether inline validation errors were showing
+	field        string // first required field left empty, if any
+}
+
+func (e *formStallError) Error() string {
+	msg := fmt.Sprintf("stalled on step %d: %s", e.step, e.reason)
+	if e.field != "" {
+		msg += fmt.Sprintf(", unresolved required field %q", e.field)
+	}
+	lastButton := e.lastButton
+	if lastButton == "" {
+		lastButton = "none"
+	}
+	return msg + fmt.Sprintf(" (last button: %s, inline errors: %v)", lastButton, e.inlineErrors)
+}
+
+// firstUnresolvedField returns the label of the first visible required
+// field in the Easy Apply modal that is still empty
+func firstUnresolvedField(page *rod.Page) string {
+	for _, root := range easyApplyRoots(page) {
+		fields, err := root.Elements("input, select, textarea")
+		if err != nil {
+			continue
+		}
+		for _, field := range fields {
+			if visible, err := field.Visible(); err != nil || !visible {
+				continue
+			}
+			if !isRequired(field) || currentValue(field) != "" {
+				continue
+			}
+			if label := getBestLabelText(root, field); label != "" {
+				return label
+			}
+			return attr(field, "id")
+		}
+	}
+	return ""
+}
+
+// maxUnchangedIterations is how many extra passes a form step may take
+// (e.g. to fix inline errors) before the application is abandoned
+const maxUnchangedIterations = 2
+
+// stepTracker detects an Easy Apply form that stops advancing between steps
+type stepTracker struct {
+	last      string // signature of the current step
+	step      int    // number of distinct steps seen
+	unchanged int    // consecutive iterations on the current step
+}
+
+// observe records the current step signature and reports whether the form
+// has been stuck on the same step for too long. Empty signatures (no form
+// fields visible, e.g. the review page) never count as stuck.
+func (t *stepTracker) observe(signature string) bool {
+	if signature == "" {
+		t.unchanged = 0
+		return false
+	}
+	if signature != t.last {
+		t.last = signature
+		t.step++
+		t.unchanged = 0
+		return false
+	}
+	t.unchanged++
+	return t.unchanged >= maxUnchangedIterations
+}
+
+// formSignature identifies a form step by the set of field IDs it shows
+func formSignature(ids []string) string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// "Your application was sent" modal shown after a submit
+const (
+	applicationSentModalSel   = ".artdeco-modal"
+	applicationSentDismissSel = ".artdeco-modal__dismiss"
+
+	// applicationSentTimeout bounds both waiting for the modal to appear and
+	// waiting for it to close
+	applicationSentTimeout = 5 * time.Second
+)
+
+// DismissApplicationSent closes the modal LinkedIn shows after an
+// application is submitted, so the next job's page doesn't load underneath
+// it. It clicks the modal's dismiss button, falling back to Escape, and
+// waits for the modal to go away. No modal appearing is not an error.
+func (bm *BrowserManager) DismissApplicationSent(page *rod.Page) error {
+	var button *rod.Element
+	var frame *rod.Page
+	deadline := time.Now().Add(applicationSentTimeout)
+	for {
+		if button, frame = findInFrames(page, applicationSentDismissSel); button != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	if err := button.Timeout(applicationSentTimeout).Click(proto.InputMouseButtonLeft, 1); err != nil {
+		log.Printf("Dismiss button not clickable (%v), pressing Escape", err)
+		if err := frame.Keyboard.Press(input.Escape); err != nil {
+			return fmt.Errorf("failed to press Escape: %w", err)
+		}
+	}
+
+	deadline = time.Now().Add(applicationSentTimeout)
+	for {
+		if modal, _ := findInFrames(page, applicationSentModalSel); modal == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("modal still open after %s", applicationSentTimeout)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// findInFrames returns the first visible element matching sel on the page
+// or inside one of its iframes, along with the frame it was found in
+func findInFrames(page *rod.Page, sel string) (*rod.Element, *rod.Page) {
+	frames := []*rod.Page{page}
+	if iframes, err := page.Elements("iframe"); err == nil {
+		for _, iframe := range iframes {
+			if frame, err := iframe.Frame(); err == nil {
+				frames = append(frames, frame)
+			}
+		}
+	}
+
+	for _, frame := range frames {
+		if has, el, _ := frame.Has(sel); has {
+			if visible, _ := el.Visible(); visible {
+				return el, frame
+			}
+		}
+	}
+	return nil, nil
+}
+
+// easyApplyRoots returns the Easy Apply modal roots on the page and inside
+// any iframes, preferring the modal's shadow root when it has one
+func easyApplyRoots(page *rod.Page) []*rod.Element {
+	const modalSel = ".jobs-easy-apply-modal"
+
+	var roots []*rod.Element
+	add := func(host *rod.Element) {
+		if shadowRoot, err := host.ShadowRoot(); err == nil && shadowRoot != nil {
+			roots = append(roots, shadowRoot)
+			return
+		}
+		roots = append(roots, host)
+	}
+
+	if has, host, _ := page.Has(modalSel); has {
+		add(host)
+	}
+
+	iframes, err := page.Elements("iframe")
+	if err != nil {
+		return roots
+	}
+	for _, iframe := range iframes {
+		frame, err := iframe.Frame()
+		if err != nil {
+			continue
+		}
+		if has, host, _ := frame.Has(modalSel); has {
+			add(host)
+		}
+	}
+	return roots
+}
+
+// visibleFieldIDs lists the IDs of visible form fields in the Easy Apply modal
+func visibleFieldIDs(page *rod.Page) []string {
+	var ids []string
+	for _, root := range easyApplyRoots(page) {
+		fields, err := root.Elements("input, select, textarea")
+		if err != nil {
+			continue
+		}
+		for _, field := range fields {
+			id := attr(field, "id")
+			if id == "" {
+				continue
+			}
+			if visible, err := field.Visible(); err == nil && visible {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
 }
-func attr(el *rod.Element, name string) string {
+
+func attr(el attributer, name string) string {
 	v, _ := el.Attribute(name)
 	if v == nil {
 		return ""
@@ -394,12 +1947,12 @@ func attr(el *rod.Element, name string) string {
 	return strings.TrimSpace(*v)
 }
 
-func isEmpty(el *rod.Element) bool {
+func isEmpty(el attributer) bool {
 	// For inputs/textareas, "value" is a good proxy
 	return strings.TrimSpace(attr(el, "value")) == ""
 }
 
-func isRequired(el *rod.Element) bool {
+func isRequired(el attributer) bool {
 	if strings.EqualFold(attr(el, "aria-required"), "true") {
 		return true
 	}
@@ -425,14 +1978,15 @@ func click(el *rod.Element) error {
 	return err
 }
 
-func clearAndType(el *rod.Element, text string) error {
+// clearAndType replaces el's value with text, typed as timing says
+func clearAndType(el *rod.Element, text string, timing Timing) error {
 	_ = el.ScrollIntoView()
 	// Select-all then input
 	if err := el.SelectAllText(); err != nil {
 		// if SelectAllText fails, try JS clear
 		_, _ = el.Eval(`(e) => { try { e.value = ""; } catch (_) {} }`)
 	}
-	return el.Input(text)
+	return typeText(el, text, timing)
 }
 
 // -------------------- Label extraction --------------------
@@ -495,127 +2049,905 @@ func getBestLabelText(page *rod.Element, el *rod.Element) string {
 		}
 	}
 
-	return ""
+	return ""
+}
+
+// Minimal CSS escaper for ids used inside attribute selectors.
+func cssEscape(s string) string {
+	// good enough for typical IDs; if you have weird chars, expand this.
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+// -------------------- Heuristics --------------------
+
+// AnswerProvider answers a free-form question, typically backed by an LLM
+type AnswerProvider func(label, inputType string) (string, error)
+
+// fallbackFreeTextAnswer is entered in required free-text fields when neither
+// an AnswerProvider nor a profile cover letter is available
+const fallbackFreeTextAnswer = "I am excited about this opportunity and believe my experience makes me a strong fit for the role."
+
+// ChooseValue picks a value for a required text input from its label and
+// type, using the profile and, for unrecognised questions, llmFallback. It
+// returns "" for a yes/no question it can't answer.
+func ChooseValue(labelText, inputType string, p *store.LinkedInProfile, llmFallback AnswerProvider) string {
+	value, _ := chooseValue(Question{Label: labelText, InputType: inputType}, p, llmFallback)
+	return value
+}
+
+// chooseValue is ChooseValue for a classified question, also returning
+// which heuristic chose the value
+func chooseValue(q Question, p *store.LinkedInProfile, llmFallback AnswerProvider) (value, reason string) {
+	words := questionWords(q.Label)
+	t := strings.ToLower(strings.TrimSpace(q.InputType))
+
+	switch ClassifyQuestion(q) {
+	case QuestionPhone:
+		if t == "number" || t == "tel" {
+			_, national := NormalizePhone(p.PhoneNumber)
+			return national, "phone number without country code"
+		}
+		return p.PhoneNumber, "phone number"
+	case QuestionLocation:
+		switch {
+		case hasPhrase(words, "zip", "zipcode", "postal", "postcode"):
+			return p.ZipCode, "zip code"
+		case hasPhrase(words, "state") && !hasPhrase(words, "city"):
+			return p.UserState, "state"
+		}
+		return p.UserCity + ", " + p.UserState, "city and state"
+	case QuestionSalary:
+		return strconv.Itoa(p.DesiredSalary), "desired salary"
+	case QuestionExperience:
+		return strconv.Itoa(p.YearsExperience), "years of experience"
+	case QuestionProfileURL:
+		return p.ProfileURL, "LinkedIn profile URL"
+	case QuestionYesNo:
+		if hasPhrase(words, "ever worked", "previously worked", "ever been employed", "previously been employed") {
+			return "No", "never worked here before"
+		}
+		if ans, ok := providerAnswer(llmFallback, q); ok {
+			return ans, reasonAnswerProvider
+		}
+		// Guessing could misstate eligibility, so the question is left
+		// for the user
+		return "", "unanswered yes/no question"
+	case QuestionDate:
+		if ans, ok := providerAnswer(llmFallback, q); ok {
+			return ans, reasonAnswerProvider
+		}
+		if t == "date" {
+			return time.Now().Format("2006-01-02"), "today's date"
+		}
+		return time.Now().Format("01/02/2006"), "today's date"
+	case QuestionFreeText:
+		if t != "number" {
+			return chooseFreeText(q.Label, p, llmFallback)
+		}
+	}
+
+	// defaults
+	if t == "number" {
+		return strconv.Itoa(p.YearsExperience), "unrecognised number, years of experience"
+	}
+
+	if ans, ok := providerAnswer(llmFallback, q); ok {
+		return ans, reasonAnswerProvider
+	}
+
+	return strconv.Itoa(p.YearsExperience), "unrecognised question, years of experience"
+}
+
+// providerAnswer asks llmFallback, if set, to answer q. Errors and blank
+// answers report false.
+func providerAnswer(llmFallback AnswerProvider, q Question) (string, bool) {
+	if llmFallback == nil {
+		return "", false
+	}
+	ans, err := llmFallback(q.Label, q.InputType)
+	if err != nil || strings.TrimSpace(ans) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(ans), true
+}
+
+// twoDigitDialCodes are the two-digit country calling codes. Codes starting
+// with 1 or 7 are one digit and every other code is three, so together these
+// split any international number unambiguously.
+var twoDigitDialCodes = map[string]bool{
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true, "34": true, "36": true,
+	"39": true, "40": true, "41": true, "43": true, "44": true, "45": true, "46": true, "47": true,
+	"48": true, "49": true, "51": true, "52": true, "53": true, "54": true, "55": true, "56": true,
+	"57": true, "58": true, "60": true, "61": true, "62": true, "63": true, "64": true, "65": true,
+	"66": true, "81": true, "82": true, "84": true, "86": true, "90": true, "91": true, "92": true,
+	"93": true, "94": true, "95": true, "98": true,
+}
+
+// NormalizePhone splits a phone number in any common format into its
+// country calling code (empty when the number has none) and the national
+// number as digits only. Extensions and a "(0)" trunk prefix are dropped.
+func NormalizePhone(raw string) (countryCode, national string) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	for _, ext := range []string{"ext", "x", "#"} {
+		if i := strings.Index(s, ext); i >= 0 {
+			s = s[:i]
+		}
+	}
+	s = strings.ReplaceAll(s, "(0)", "")
+
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+
+	switch {
+	case strings.HasPrefix(s, "+"):
+	case strings.HasPrefix(s, "00"):
+		digits = digits[2:]
+	case len(digits) == 11 && digits[0] == '1':
+		// North American number written with its country code but no +
+		return "1", digits[1:]
+	default:
+		return "", digits
+	}
+
+	n := 3
+	switch {
+	case strings.HasPrefix(digits, "1"), strings.HasPrefix(digits, "7"):
+		n = 1
+	case len(digits) >= 2 && twoDigitDialCodes[digits[:2]]:
+		n = 2
+	}
+	if n > len(digits) {
+		n = len(digits)
+	}
+	return digits[:n], digits[n:]
+}
+
+// SetFollowCompany checks or unchecks the "follow company" box shown on the
+// review step so it matches follow. LinkedIn pre-checks it.
+func (bm *BrowserManager) SetFollowCompany(page *rod.Element, follow bool) {
+	setFollowCompany(page, follow, nil)
+}
+
+// setFollowCompany is SetFollowCompany, passing a changed box to report if
+// it's set
+func setFollowCompany(page *rod.Element, follow bool, report func(FilledField)) {
+	has, checkbox, err := page.Has("#follow-company-checkbox")
+	if err != nil || !has {
+		return
+	}
+	checked, err := checkbox.Property("checked")
+	if err != nil || checked.Bool() == follow {
+		return
+	}
+
+	// The checkbox itself is visually hidden; its label receives the click
+	target := checkbox
+	if hasLabel, label, _ := page.Has("label[for='follow-company-checkbox']"); hasLabel {
+		target = label
+	}
+	if err := click(target); err != nil {
+		log.Printf("Failed to set follow company to %v: %v", follow, err)
+	} else {
+		log.Printf("Set follow company to %v", follow)
+		reportFill(report, FilledField{Label: "Follow company", Kind: FieldCheckbox, Value: checkboxValue(follow), Reason: "follow company setting"})
+	}
+}
+
+// marketingKeywords mark opt-ins that must never be checked automatically
+var marketingKeywords = []string{
+	"follow", "marketing", "newsletter", "promotion", "promotional", "offers",
+	"subscribe", "job alerts", "updates", "email me", "text me", "sms",
+}
+
+// consentKeywords mark required agreements that gate submission
+var consentKeywords = []string{
+	"i agree", "agree to", "i consent", "consent to", "acknowledge", "i certify",
+	"i confirm", "terms", "privacy policy", "data processing", "processing of my",
+}
+
+// isConsentLabel reports whether a checkbox label is a terms or data
+// processing agreement rather than a marketing opt-in
+func isConsentLabel(labelText string) bool {
+	l := strings.ToLower(labelText)
+	if containsAny(l, marketingKeywords...) {
+		return false
+	}
+	return containsAny(l, consentKeywords...)
+}
+
+// CheckConsentBoxes checks required, unchecked agreement checkboxes so the
+// form can be submitted. Marketing opt-ins and the follow-company box are
+// never touched here.
+func (bm *BrowserManager) CheckConsentBoxes(page *rod.Element) {
+	checkConsentBoxes(page, nil)
+}
+
+// checkConsentBoxes is CheckConsentBoxes, passing each checked box to
+// report if it's set
+func checkConsentBoxes(page *rod.Element, report func(FilledField)) {
+	checkboxes, err := page.Elements("input[type='checkbox']")
+	if err != nil {
+		return
+	}
+	for _, checkbox := range checkboxes {
+		if attr(checkbox, "id") == "follow-company-checkbox" || !isRequired(checkbox) {
+			continue
+		}
+		if checked, err := checkbox.Property("checked"); err != nil || checked.Bool() {
+			continue
+		}
+		labelText := getBestLabelText(page, checkbox)
+		if !isConsentLabel(labelText) {
+			continue
+		}
+
+		if err := click(checkbox); err != nil {
+			log.Printf("Failed to check consent box '%s': %v", labelText, err)
+		} else {
+			log.Printf("Checked consent box '%s'", labelText)
+			reportFill(report, FilledField{Label: labelText, Kind: FieldCheckbox, Value: checkboxValue(true), Reason: "required agreement"})
+		}
+	}
+}
+
+// isResumeUploadField reports whether a file input takes a resume rather
+// than, say, a cover letter
+func isResumeUploadField(labelText, id string) bool {
+	l := strings.ToLower(labelText + " " + id)
+	return !strings.Contains(l, "cover") && containsAny(l, "resume", "résumé", "cv")
+}
+
+// UploadResume attaches the file at path to an empty resume upload input.
+// It does nothing when path is empty or the file is missing.
+func (bm *BrowserManager) UploadResume(page *rod.Element, path string) {
+	if path == "" {
+		return
+	}
+	if !fileExists(path) {
+		log.Printf("Resume file not found: %s", path)
+		return
+	}
+
+	inputs, err := page.Elements("input[type='file']")
+	if err != nil {
+		return
+	}
+	for _, fileInput := range inputs {
+		labelText := getBestLabelText(page, fileInput)
+		if !isResumeUploadField(labelText, attr(fileInput, "id")) {
+			continue
+		}
+		if count, err := fileInput.Eval(`() => this.files ? this.files.length : 0`); err == nil && count.Value.Int() > 0 {
+			continue // Already uploaded on an earlier pass
+		}
+
+		if err := fileInput.SetFiles([]string{path}); err != nil {
+			log.Printf("Failed to upload resume '%s': %v", path, err)
+		} else {
+			log.Printf("Uploaded resume '%s'", path)
+		}
+	}
+}
+
+// isPhoneCountryCodeField reports whether a select is the dial-code half of
+// a split phone number field
+func isPhoneCountryCodeField(labelText, id string) bool {
+	l := strings.ToLower(labelText)
+	return strings.Contains(strings.ToLower(id), "phonenumber-country") ||
+		(containsAny(l, "country code", "dial code") && !strings.Contains(l, "zip"))
+}
+
+// FillPhoneCountryCode selects the profile's dial code in any phone country
+// code dropdown, leaving dropdowns that already match alone
+func (bm *BrowserManager) FillPhoneCountryCode(page *rod.Element, profile *store.LinkedInProfile) {
+	fillPhoneCountryCode(page, profile, nil)
+}
+
+// fillPhoneCountryCode is FillPhoneCountryCode, passing each changed
+// dropdown to report if it's set
+func fillPhoneCountryCode(page *rod.Element, profile *store.LinkedInProfile, report func(FilledField)) {
+	if profile == nil {
+		return
+	}
+	code, _ := NormalizePhone(profile.PhoneNumber)
+	if code == "" {
+		return
+	}
+	option := "(+" + code + ")"
+
+	selects, err := page.Elements("select")
+	if err != nil {
+		return
+	}
+	for _, selectEl := range selects {
+		labelText := getBestLabelText(page, selectEl)
+		if !isPhoneCountryCodeField(labelText, attr(selectEl, "id")) {
+			continue
+		}
+		if selected, err := selectEl.Eval(`() => this.selectedIndex >= 0 ? this.options[this.selectedIndex].text : ""`); err == nil &&
+			strings.Contains(selected.Value.Str(), option) {
+			continue
+		}
+
+		if err := selectEl.Select([]string{option}, true, rod.SelectorTypeText); err != nil {
+			log.Printf("Failed to select dial code %s for label '%s': %v", option, labelText, err)
+		} else {
+			log.Printf("Selected dial code %s for label '%s'", option, labelText)
+			reportFill(report, FilledField{Label: labelText, Kind: FieldSelect, Value: option, Reason: "phone number country code"})
+		}
+	}
+}
+
+// clampToRange keeps a numeric answer within an input's min/max attributes
+// so LinkedIn doesn't reject it with an inline error. Non-numeric values
+// fall back to the field's max (or min when no max is set).
+func clampToRange(value, minAttr, maxAttr string) string {
+	minVal, hasMin := parseNumber(minAttr)
+	maxVal, hasMax := parseNumber(maxAttr)
+	if !hasMin && !hasMax {
+		return value
+	}
+
+	v, ok := parseNumber(value)
+	if !ok {
+		if hasMax {
+			return formatNumber(maxVal)
+		}
+		return formatNumber(minVal)
+	}
+
+	switch {
+	case hasMin && v < minVal:
+		return formatNumber(minVal)
+	case hasMax && v > maxVal:
+		return formatNumber(maxVal)
+	}
+	return value
+}
+
+func parseNumber(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// ChooseFreeText picks an answer for a free-text (textarea) question,
+// preferring the AnswerProvider, then the profile's cover letter template
+func ChooseFreeText(labelText string, p *store.LinkedInProfile, llmFallback AnswerProvider) string {
+	value, _ := chooseFreeText(labelText, p, llmFallback)
+	return value
+}
+
+// chooseFreeText is ChooseFreeText, also returning where the answer came from
+func chooseFreeText(labelText string, p *store.LinkedInProfile, llmFallback AnswerProvider) (value, reason string) {
+	if llmFallback != nil {
+		if ans, err := llmFallback(labelText, "textarea"); err == nil && strings.TrimSpace(ans) != "" {
+			return strings.TrimSpace(ans), reasonAnswerProvider
+		}
+	}
+
+	if p != nil && strings.TrimSpace(p.CoverLetter) != "" {
+		return strings.TrimSpace(p.CoverLetter), "cover letter"
+	}
+
+	return fallbackFreeTextAnswer, "generic answer"
+}
+
+// truncate shortens s to at most n runes for logging
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
+
+// currentValue returns the live value of a form control
+func currentValue(el *rod.Element) string {
+	v, err := el.Property("value")
+	if err != nil {
+		return attr(el, "value")
+	}
+	return strings.TrimSpace(v.Str())
+}
+
+// isCoverLetterField reports whether a textarea looks like an optional
+// cover letter / "additional information" box rather than a short question
+func isCoverLetterField(labelText, rows string) bool {
+	l := strings.ToLower(labelText)
+	if containsAny(l, "cover letter", "additional information", "anything else", "additional details") {
+		return true
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(rows))
+	return err == nil && n >= 5
+}
+
+// FillCoverLetter populates empty cover-letter textareas with the profile's
+// cover letter (or an AnswerProvider answer). Pre-filled fields are left alone.
+func (bm *BrowserManager) FillCoverLetter(page *rod.Element, profile *store.LinkedInProfile, llmFallback AnswerProvider) {
+	fillCoverLetter(page, profile, llmFallback, nil, bm.cfg.Timing)
+}
+
+// fillCoverLetter is FillCoverLetter, passing each filled field to report
+// if it's set
+func fillCoverLetter(page *rod.Element, profile *store.LinkedInProfile, llmFallback AnswerProvider, report func(FilledField), timing Timing) {
+	textareas, err := page.Elements("textarea")
+	if err != nil {
+		return
+	}
+	for _, textareaEl := range textareas {
+		if currentValue(textareaEl) != "" {
+			continue
+		}
+		labelText := getBestLabelText(page, textareaEl)
+		if !isCoverLetterField(labelText, attr(textareaEl, "rows")) {
+			continue
+		}
+
+		var value, reason string
+		if llmFallback != nil {
+			if ans, err := llmFallback(labelText, "cover-letter"); err == nil {
+				value, reason = strings.TrimSpace(ans), reasonAnswerProvider
+			}
+		}
+		if value == "" && profile != nil {
+			value, reason = strings.TrimSpace(profile.CoverLetter), "cover letter"
+		}
+		if value == "" {
+			continue
+		}
+
+		if err := clearAndType(textareaEl, value, timing); err != nil {
+			log.Printf("Failed to fill cover letter for label '%s': %v", labelText, err)
+		} else {
+			log.Printf("Filled cover letter for label '%s' with '%s'", labelText, truncate(value, 40))
+			reportFill(report, FilledField{Label: labelText, Kind: FieldCoverLetter, Value: value, Reason: reason})
+		}
+	}
+}
+
+// -------------------- Main: FillInvalids --------------------
+
+// FillInvalids fills required fields LinkedIn left empty, preferring the
+// profile's remembered answers and otherwise choosing values from the profile
+func (bm *BrowserManager) FillInvalids(page *rod.Element, profile *store.LinkedInProfile, llmFallback AnswerProvider) error {
+	bm.CheckConsentBoxes(page)
+
+	bm.mu.RLock()
+	memory := bm.answerMemory
+	bm.mu.RUnlock()
+	return fillInvalids(rodForm{root: page, typing: bm.cfg.Timing}, profile, fillOptions{answers: llmFallback, memory: memory})
+}
+
+// fillOptions are where fillInvalids gets answers from and tells about them
+type fillOptions struct {
+	answers AnswerProvider    // nil uses the built-in defaults
+	memory  AnswerMemory      // nil doesn't remember answers
+	report  func(FilledField) // nil doesn't report filled fields
+}
+
+// fillInvalids fills every required, empty text input and textarea in form.
+// Optional and pre-filled fields are left alone. Answers come from
+// opts.memory when it has one, and newly chosen answers are saved to it.
+func fillInvalids(form FormContainer, profile *store.LinkedInProfile, opts fillOptions) error {
+	const (
+		textInputXPath = `//*[starts-with(@id, 'single-line-text-form-component-formElement-urn-li-jobs-applyformcommon-easyApplyFormElement-')]`
+	)
+
+	textInputs, err := form.ElementsX(textInputXPath)
+	if err != nil {
+		return fmt.Errorf("failed to find text inputs: %w", err)
+	}
+	for _, inputEl := range textInputs {
+		if isEmpty(inputEl) && isRequired(inputEl) {
+			labelText := form.Label(inputEl)
+			question := Question{Label: labelText, InputType: attr(inputEl, "type"), Hint: questionHint(form, inputEl)}
+			// Answers to questions the profile doesn't cover are kept for
+			// the user to review; values read from the profile aren't
+			value, reason := rememberedAnswer(opts.memory, profile, question, func() (string, string) {
+				return chooseValue(question, profile, opts.answers)
+			}, func(reason string) bool {
+				return reason == reasonAnswerProvider || ClassifyQuestion(question) == QuestionUnknown
+			})
+			if value == "" {
+				log.Printf("Leaving input for label '%s' unanswered (%s)", labelText, reason)
+				continue
+			}
+			if clamped := clampToRange(value, attr(inputEl, "min"), attr(inputEl, "max")); clamped != value {
+				value, reason = clamped, reason+", clamped to the allowed range"
+			}
+			filled := inputEl
+			err := withRequery(inputEl, requerier(form, inputEl), func(el FormElement) error {
+				filled = el
+				return el.Input(value)
+			})
+			if err == nil && isTypeahead(filled) {
+				var picked string
+				if picked, err = confirmTypeahead(form, filled, labelText, value); err == nil && picked != "" {
+					value, reason = picked, reason+", picked from suggestions"
+				}
+			}
+			if err != nil {
+				log.Printf("Failed to fill input for label '%s': %v", labelText, err)
+			} else {
+				log.Printf("Filled input for label '%s' with value '%s'", labelText, value)
+				reportFill(opts.report, FilledField{Label: labelText, Kind: FieldInput, Value: value, Reason: reason})
+			}
+		}
+	}
+
+	textareas, err := form.Elements("textarea")
+	if err != nil {
+		return nil
+	}
+	for _, textareaEl := range textareas {
+		if textareaEl.Value() == "" && isRequired(textareaEl) {
+			labelText := form.Label(textareaEl)
+			question := Question{Label: labelText, InputType: "textarea"}
+			value, reason := rememberedAnswer(opts.memory, profile, question, func() (string, string) {
+				return chooseFreeText(labelText, profile, opts.answers)
+			}, func(reason string) bool {
+				return reason == reasonAnswerProvider
+			})
+			err := withRequery(textareaEl, requerier(form, textareaEl), func(el FormElement) error {
+				return el.Input(value)
+			})
+			if err != nil {
+				log.Printf("Failed to fill textarea for label '%s': %v", labelText, err)
+			} else {
+				log.Printf("Filled textarea for label '%s' with '%s'", labelText, truncate(value, 40))
+				reportFill(opts.report, FilledField{Label: labelText, Kind: FieldTextarea, Value: value, Reason: reason})
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsAny(s string, kws ...string) bool {
+	for _, kw := range kws {
+		if strings.Contains(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes every tracked page and shuts down the browser
+func (bm *BrowserManager) Close() error {
+	return bm.close(false)
+}
+
+// close is Close, keeping the applying flag set if keepApplying is true
+func (bm *BrowserManager) close(keepApplying bool) error {
+	bm.CloseAllPages()
+
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.browser == nil {
+		return nil
+	}
+
+	err := bm.browser.Close()
+	bm.browser = nil
+	if !keepApplying {
+		bm.applying = false
+		bm.claim = 0
+	}
+	bm.cancel()
+	if bm.resumeCh != nil {
+		close(bm.resumeCh)
+		bm.resumeCh = nil
+	}
+
+	return err
+}
+
+// Pause suspends an active apply run before its next job without closing
+// the browser
+func (bm *BrowserManager) Pause() {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.resumeCh == nil {
+		bm.resumeCh = make(chan struct{})
+		bm.deadline.pause()
+	}
+}
+
+// Resume continues a paused apply run
+func (bm *BrowserManager) Resume() {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.resumeCh != nil {
+		close(bm.resumeCh)
+		bm.resumeCh = nil
+		bm.deadline.resume()
+	}
+}
+
+// pauseOwned pauses the apply run like Pause, returning a token for
+// resumeOwned. The token is nil if the run was already paused, so a pause
+// the user set isn't lifted by whoever called pauseOwned.
+func (bm *BrowserManager) pauseOwned() chan struct{} {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.resumeCh != nil {
+		return nil
+	}
+	bm.resumeCh = make(chan struct{})
+	bm.deadline.pause()
+	return bm.resumeCh
 }
 
-// Minimal CSS escaper for ids used inside attribute selectors.
-func cssEscape(s string) string {
-	// good enough for typical IDs; if you have weird chars, expand this.
-	return strings.ReplaceAll(s, `"`, `\"`)
+// resumeOwned resumes the run if it is still in the pause token came
+// from. A nil token, or a pause the run has since left, does nothing.
+func (bm *BrowserManager) resumeOwned(token chan struct{}) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if token != nil && bm.resumeCh == token {
+		close(bm.resumeCh)
+		bm.resumeCh = nil
+		bm.deadline.resume()
+	}
 }
 
-// -------------------- Heuristics --------------------
+// IsPaused reports whether the apply run is paused
+func (bm *BrowserManager) IsPaused() bool {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return bm.resumeCh != nil
+}
 
-func ChooseValue(labelText, inputType string, p *store.LinkedInProfile, llmFallback func(label, typ string) (string, error)) string {
-	l := strings.ToLower(strings.TrimSpace(labelText))
-	t := strings.ToLower(strings.TrimSpace(inputType))
+// waitIfPaused blocks while the run is paused. It returns the context error
+// if the browser is closed while waiting.
+func (bm *BrowserManager) waitIfPaused() error {
+	bm.mu.RLock()
+	resumeCh := bm.resumeCh
+	ctx := bm.ctx
+	bm.mu.RUnlock()
 
-	containsAny := func(s string, kws ...string) bool {
-		for _, kw := range kws {
-			if strings.Contains(s, kw) {
-				return true
-			}
-		}
-		return false
+	if resumeCh == nil {
+		return ctx.Err()
 	}
 
-	switch {
-	case containsAny(l, "phone", "mobile", "telephone", "contact"):
-		return p.PhoneNumber
-	case containsAny(l, "city", "location", "reside"):
-		return p.UserCity + ", " + p.UserState
-	case strings.Contains(l, "have you ever worked"):
-		return "No"
-	case strings.Contains(l, "state"):
-		return p.UserState
-	// case containsAny(l, "zip", "postal"):
-	// 	return p.ZipCode TODO
-	case containsAny(l, "salary", "wage", "income", "compensation"):
-		return strconv.Itoa(p.DesiredSalary)
-	case strings.Contains(l, "experience") && strings.Contains(l, "year"):
-		return strconv.Itoa(p.YearsExperience)
-	case containsAny(l, "linkedin", "linked-in", "linked in"):
-		return p.ProfileURL
+	fmt.Println("⏸️ Applying paused")
+	select {
+	case <-resumeCh:
+		fmt.Println("▶️ Applying resumed")
+		return ctx.Err()
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	// defaults
-	if t == "number" {
-		return strconv.Itoa(p.YearsExperience)
-	}
+// waitForChallenge pauses the run while the page shows a LinkedIn challenge,
+// emitting browser:challenge so the user can solve it, and resumes once the
+// challenge is gone. A run the user had already paused stays paused. It
+// returns the context error if the browser is closed while waiting.
+func (bm *BrowserManager) waitForChallenge(page *rod.Page) error {
+	bm.mu.RLock()
+	ctx := bm.ctx
+	bm.mu.RUnlock()
 
-	if llmFallback != nil {
-		if ans, err := llmFallback(labelText, inputType); err == nil && strings.TrimSpace(ans) != "" {
-			return strings.TrimSpace(ans)
-		}
+	selectors := bm.cfg.ChallengeSelectors
+	if selectors == nil {
+		selectors = DefaultChallengeSelectors
 	}
 
-	return strconv.Itoa(p.YearsExperience)
-}
+	detected := false
+	var pause chan struct{}
+	for {
+		info, err := page.Info()
+		if err != nil {
+			return fmt.Errorf("failed to read page info: %w", err)
+		}
+		html, err := page.HTML()
+		if err != nil {
+			return fmt.Errorf("failed to read page: %w", err)
+		}
 
-// -------------------- Main: FillInvalids --------------------
+		if !IsChallengePage(info.URL, html, selectors) {
+			if detected {
+				fmt.Println("✅ Challenge cleared")
+				bm.resumeOwned(pause)
+				bm.emit("browser:challenge-cleared", nil)
+			}
+			return nil
+		}
 
-func (bm *BrowserManager) FillInvalids(page *rod.Element, profile *store.LinkedInProfile, llmFallback func(label, typ string) (string, error)) error {
-	const (
-		textInputXPath = `//*[starts-with(@id, 'single-line-text-form-component-formElement-urn-li-jobs-applyformcommon-easyApplyFormElement-')]`
-	)
+		if !detected {
+			detected = true
+			fmt.Printf("🛑 Challenge detected at %s, waiting for it to be solved\n", info.URL)
+			pause = bm.pauseOwned()
+			bm.emit("browser:challenge", map[string]interface{}{
+				"url": info.URL,
+			})
+		}
 
-	integerInputs := page.MustElementsX(textInputXPath)
-	for _, inputEl := range integerInputs {
-		if isEmpty(inputEl) && isRequired(inputEl) {
-			labelText := getBestLabelText(page, inputEl)
-			inputType := attr(inputEl, "type")
-			value := ChooseValue(labelText, inputType, profile, llmFallback)
-			if err := clearAndType(inputEl, value); err != nil {
-				log.Printf("Failed to fill input for label '%s': %v", labelText, err)
-			} else {
-				log.Printf("Filled input for label '%s' with value '%s'", labelText, value)
-			}
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			return fmt.Errorf("%w: stopped before it was solved: %w", ErrSecurityChallenge, ctx.Err())
 		}
 	}
+}
 
-	return nil
+// SetEventHandler sets the callback that receives status events
+func (bm *BrowserManager) SetEventHandler(handler EventHandler) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.onEvent = handler
 }
 
-func containsAny(s string, kws ...string) bool {
-	for _, kw := range kws {
-		if strings.Contains(s, kw) {
-			return true
-		}
+// emit forwards an event to the handler, if one is set
+func (bm *BrowserManager) emit(name string, data map[string]interface{}) {
+	bm.mu.RLock()
+	handler := bm.onEvent
+	bm.mu.RUnlock()
+
+	if handler != nil {
+		handler(name, data)
 	}
-	return false
 }
 
-// Close shuts down the browser
-func (bm *BrowserManager) Close() error {
+// IsRunning checks if browser is currently running and responsive. It asks
+// once and never tears the browser down, so a slow answer during a status
+// poll doesn't kill a live browser.
+func (bm *BrowserManager) IsRunning() bool {
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return false
+	}
+	_, err := browser.Timeout(pingTimeout).Version()
+	return err == nil
+}
+
+// SetRecorder sets where application attempts are recorded
+func (bm *BrowserManager) SetRecorder(recorder ApplicationRecorder) {
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
+	bm.recorder = recorder
+}
 
-	if bm.browser == nil {
-		return nil
+// SetResumeLister sets where profile resumes are looked up
+func (bm *BrowserManager) SetResumeLister(resumes ResumeLister) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.resumes = resumes
+}
+
+// resumeFor returns the path of the profile's resume best suited to
+// jobTitle, or "" to keep whatever resume LinkedIn already has on file
+func (bm *BrowserManager) resumeFor(profileID int64, jobTitle string) string {
+	bm.mu.RLock()
+	lister := bm.resumes
+	bm.mu.RUnlock()
+
+	if lister == nil {
+		return ""
+	}
+	resumes, err := lister.ListResumes(profileID)
+	if err != nil {
+		log.Printf("Failed to list resumes: %v", err)
+		return ""
 	}
+	resume := store.SelectResume(resumes, jobTitle)
+	if resume == nil {
+		return ""
+	}
+	return resume.Path
+}
 
-	err := bm.browser.Close()
-	bm.browser = nil
-	bm.SetApplying(false)
-	bm.cancel()
+// skippedExternalJobs returns the profile's jobs already skipped for being
+// applied to off LinkedIn, so a run doesn't collect them again
+func (bm *BrowserManager) skippedExternalJobs(profileID int64) map[int]bool {
+	bm.mu.RLock()
+	lister, ok := bm.recorder.(SkippedJobLister)
+	bm.mu.RUnlock()
 
-	return err
+	seen := map[int]bool{}
+	if !ok {
+		return seen
+	}
+	ids, err := lister.SkippedJobIDs(profileID, store.SkipReasonExternal)
+	if err != nil {
+		log.Printf("Failed to list skipped jobs: %v", err)
+		return seen
+	}
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return seen
 }
 
-// IsRunning checks if browser is currently running
-func (bm *BrowserManager) IsRunning() bool {
+// recordApplication saves an application attempt, logging rather than
+// failing the run if it can't be stored
+func (bm *BrowserManager) recordApplication(app store.Application) {
 	bm.mu.RLock()
-	defer bm.mu.RUnlock()
-	return bm.browser != nil
+	recorder := bm.recorder
+	bm.mu.RUnlock()
+
+	if recorder == nil {
+		return
+	}
+	if _, err := recorder.RecordApplication(app); err != nil {
+		log.Printf("Failed to record application for job ID %d: %v", app.JobID, err)
+	}
 }
 
+// IsApplying reports whether an apply session is running
 func (bm *BrowserManager) IsApplying() bool {
-	// Check if the browser is currently applying
-	return bm.ctx.Value("IsApplying") == true
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return bm.applying
 }
 
+// SetApplying sets the apply-session flag. Clearing it also drops a
+// pending ClaimApplying claim.
 func (bm *BrowserManager) SetApplying(value bool) {
-	bm.ctx = context.WithValue(bm.ctx, "IsApplying", value)
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.applying = value
+	if !value {
+		bm.claim = 0
+	}
+}
+
+// beginApplying atomically claims the apply session, returning false if
+// one is already running
+func (bm *BrowserManager) beginApplying() bool {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.claim != 0 {
+		bm.claim = 0 // The run takes over ClaimApplying's claim
+	} else if bm.applying {
+		return false
+	}
+	bm.applying = true
+
+	cache := bm.answerCache
+	if cache == nil {
+		cache = NewAnswerCache()
+	}
+	bm.runAnswers = CachedAnswerProvider(bm.answers, cache)
+	return true
+}
+
+// ClaimApplying claims the apply session ahead of launching the browser
+// and logging in, so a second caller is refused with ErrAlreadyApplying
+// before it launches anything. The next StartApplyingWith, ApplyToJobURL or
+// RetryFailed call takes the claim over. release gives the claim up if no
+// run took it; after that it does nothing, so it can be deferred.
+func (bm *BrowserManager) ClaimApplying() (release func(), err error) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.applying {
+		return nil, ErrAlreadyApplying
+	}
+	bm.applying = true
+	bm.claims++
+	claim := bm.claims
+	bm.claim = claim
+	return func() {
+		bm.mu.Lock()
+		defer bm.mu.Unlock()
+		if bm.claim == claim {
+			bm.claim = 0
+			bm.applying = false
+		}
+	}, nil
 }
 
 // GetBrowser returns the rod browser instance
@@ -625,24 +2957,82 @@ func (bm *BrowserManager) GetBrowser() *rod.Browser {
 	return bm.browser
 }
 
-// NewPage creates a new browser page
+// NewPage creates a new browser page. The page is tracked and closed by
+// CloseAllPages.
 func (bm *BrowserManager) NewPage() (*rod.Page, error) {
-	bm.mu.RLock()
-	defer bm.mu.RUnlock()
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return nil, ErrBrowserNotRunning
+	}
+	return bm.openPage(browser)
+}
 
-	if bm.browser == nil {
-		return nil, fmt.Errorf("browser not running")
+// openPage opens a stealth page on browser and tracks it
+func (bm *BrowserManager) openPage(browser *rod.Browser) (*rod.Page, error) {
+	page, err := stealth.Page(browser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open page: %w", err)
 	}
+	bm.overrideUserAgent(page)
+	bm.overrideAcceptLanguage(page)
 
-	return bm.browser.MustPage(), nil
+	bm.mu.Lock()
+	bm.pages = append(bm.pages, page)
+	bm.mu.Unlock()
+	return page, nil
 }
 
-// Navigate opens a URL in a new page
-func (bm *BrowserManager) Navigate(url string) (*rod.Page, error) {
+// SharedPage returns the page reused across navigations, opening it on
+// first use
+func (bm *BrowserManager) SharedPage() (*rod.Page, error) {
+	bm.mu.RLock()
+	page := bm.sharedPage
+	bm.mu.RUnlock()
+	if page != nil {
+		return page, nil
+	}
+
 	page, err := bm.NewPage()
 	if err != nil {
 		return nil, err
 	}
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.sharedPage != nil {
+		// Another caller opened one first; ours is still tracked and closed later
+		return bm.sharedPage, nil
+	}
+	bm.sharedPage = page
+	return page, nil
+}
+
+// CloseAllPages closes every page opened through the manager. Pages that
+// are already gone are skipped.
+func (bm *BrowserManager) CloseAllPages() {
+	bm.mu.Lock()
+	pages := bm.pages
+	bm.pages = nil
+	bm.sharedPage = nil
+	bm.mu.Unlock()
+
+	for _, page := range pages {
+		if err := page.Timeout(pingTimeout).Close(); err != nil {
+			log.Printf("Error closing page: %v", err)
+		}
+	}
+}
+
+// Navigate opens a URL in a new page, or in the shared page when reuse is
+// set so repeated navigations don't pile up tabs
+func (bm *BrowserManager) Navigate(url string, reuse bool) (*rod.Page, error) {
+	newPage := bm.NewPage
+	if reuse {
+		newPage = bm.SharedPage
+	}
+	page, err := newPage()
+	if err != nil {
+		return nil, err
+	}
 
 	if err := page.Navigate(url); err != nil {
 		return nil, fmt.Errorf("failed to navigate: %w", err)
@@ -651,8 +3041,150 @@ func (bm *BrowserManager) Navigate(url string) (*rod.Page, error) {
 	return page, nil
 }
 
+// navigateWithRetry navigates page to url, retrying with exponential
+// backoff on navigation errors and on LinkedIn's rate-limit page
+func (bm *BrowserManager) navigateWithRetry(page *rod.Page, url string) error {
+	bm.mu.RLock()
+	ctx := bm.ctx
+	bm.mu.RUnlock()
+
+	retries := bm.cfg.NavigationRetries
+	if retries <= 0 {
+		retries = DefaultNavigationRetries
+	}
+	backoff := bm.cfg.NavigationBackoff
+	if backoff <= 0 {
+		backoff = DefaultNavigationBackoff
+	}
+
+	return retryWithBackoff(ctx, retries, backoff, func() error {
+		if err := bm.timed(page).Navigate(url); err != nil {
+			return fmt.Errorf("failed to navigate to %s: %w", url, err)
+		}
+		if err := bm.timed(page).WaitLoad(); err != nil {
+			return fmt.Errorf("failed to load %s: %w", url, err)
+		}
+		html, err := page.HTML()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", url, err)
+		}
+		if isRateLimited(html) {
+			return ErrRateLimited
+		}
+		return nil
+	})
+}
+
+// retryWithBackoff calls fn until it succeeds, retrying up to retries
+// times. The delay starts at backoff and doubles after each attempt. It
+// stops early with the context error if ctx is cancelled while waiting.
+func retryWithBackoff(ctx context.Context, retries int, backoff time.Duration, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+		}
+
+		delay := backoff << attempt
+		fmt.Printf("⚠️ %v, retrying in %s\n", err, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// isRateLimited reports whether html is LinkedIn's "too many requests" page
+func isRateLimited(html string) bool {
+	lower := strings.ToLower(html)
+	return strings.Contains(lower, "too many requests") || strings.Contains(lower, "http error 429")
+}
+
+// ErrBrowserBusy is returned when the configuration is changed while the
+// browser is running or an apply run is going
+var ErrBrowserBusy = errors.New("cannot change the configuration while the browser is in use")
+
+// SetConfig replaces the configuration used by the next Launch and apply
+// run. It fails with ErrBrowserBusy while the browser is running or an
+// apply run holds the session.
+func (bm *BrowserManager) SetConfig(cfg *Config) error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.browser != nil || bm.launching || bm.applying {
+		return ErrBrowserBusy
+	}
+	if cfg.ApplicationsPerHour != bm.cfg.ApplicationsPerHour {
+		bm.limiter = newTokenBucket(cfg.ApplicationsPerHour, realClock{})
+	}
+	bm.cfg = cfg
+	return nil
+}
+
+// SetBrowserBin sets the browser binary used by the next Launch. It fails
+// while the browser is running.
+func (bm *BrowserManager) SetBrowserBin(path string) error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.browser != nil {
+		return fmt.Errorf("cannot change browser while it is running")
+	}
+	bm.cfg.BrowserBin = path
+	return nil
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ErrBrowserNotAvailable means there's no Chrome to launch. It's returned
+// before launching rather than leaving Launch to fail partway through.
+var ErrBrowserNotAvailable = errors.New("browser not available, run DownloadBrowser first")
+
+// CheckBrowserAvailable returns ErrBrowserNotAvailable, naming every path it
+// checked, when Launch would find no configured, bundled or system browser
+func (bm *BrowserManager) CheckBrowserAvailable() error {
+	bm.mu.RLock()
+	bin := bm.cfg.BrowserBin
+	bm.mu.RUnlock()
+
+	var paths []string
+	if bin != "" {
+		paths = append(paths, bin)
+	}
+	if bundled := bundledBrowserPath(); bundled != "" {
+		paths = append(paths, bundled)
+	}
+	return checkBrowserPaths(append(paths, systemBrowserPaths()...))
+}
+
+// checkBrowserPaths returns nil if any of paths exists
+func checkBrowserPaths(paths []string) error {
+	for _, p := range paths {
+		if fileExists(p) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w (checked %s)", ErrBrowserNotAvailable, strings.Join(paths, ", "))
+}
+
 // findBundledBrowser looks for a bundled browser in the app resources
 func (bm *BrowserManager) findBundledBrowser() string {
+	if path := bundledBrowserPath(); path != "" && fileExists(path) {
+		return path
+	}
+	return ""
+}
+
+// bundledBrowserPath is where a bundled browser would sit in the app resources
+func bundledBrowserPath() string {
 	// Get executable directory
 	exe, err := os.Executable()
 	if err != nil {
@@ -660,66 +3192,166 @@ func (bm *BrowserManager) findBundledBrowser() string {
 	}
 	exeDir := filepath.Dir(exe)
 
-	var browserPath string
 	switch runtime.GOOS {
 	case "darwin":
-		browserPath = filepath.Join(exeDir, "resources", "chrome", "Chromium.app", "Contents", "MacOS", "Chromium")
+		return filepath.Join(exeDir, "resources", "chrome", "Chromium.app", "Contents", "MacOS", "Chromium")
 	case "windows":
-		browserPath = filepath.Join(exeDir, "resources", "chrome", "chrome.exe")
+		return filepath.Join(exeDir, "resources", "chrome", "chrome.exe")
 	case "linux":
-		browserPath = filepath.Join(exeDir, "resources", "chrome", "chrome")
+		return filepath.Join(exeDir, "resources", "chrome", "chrome")
 	}
-
-	if _, err := os.Stat(browserPath); err == nil {
-		return browserPath
-	}
-
 	return ""
 }
 
 // findSystemBrowser looks for Chrome/Chromium installed on the system
 func (bm *BrowserManager) findSystemBrowser() string {
-	var paths []string
+	for _, p := range systemBrowserPaths() {
+		if fileExists(p) {
+			return p
+		}
+	}
+	return ""
+}
 
+// systemBrowserPaths lists where Chrome/Chromium is installed on this OS
+func systemBrowserPaths() []string {
 	switch runtime.GOOS {
 	case "darwin":
-		paths = []string{
+		return []string{
 			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
 			"/Applications/Chromium.app/Contents/MacOS/Chromium",
 			"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
 		}
 	case "windows":
-		paths = []string{
+		return []string{
 			`C:\Program Files\Google\Chrome\Application\chrome.exe`,
 			`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`,
 			`C:\Program Files\Microsoft\Edge\Application\msedge.exe`,
 		}
 	case "linux":
-		paths = []string{
+		return []string{
 			"/usr/bin/google-chrome",
 			"/usr/bin/google-chrome-stable",
 			"/usr/bin/chromium",
 			"/usr/bin/chromium-browser",
 		}
 	}
+	return nil
+}
 
-	for _, p := range paths {
-		if _, err := os.Stat(p); err == nil {
-			return p
+// Restart stops and starts the browser. The applying flag stays set
+// throughout, so an active session keeps its claim and no other run can
+// start while the browser is down.
+func (bm *BrowserManager) Restart() error {
+	if err := bm.close(true); err != nil {
+		log.Printf("Error closing browser during restart: %v", err)
+	}
+	return bm.Launch()
+}
+
+// pingTimeout bounds how long each ping waits for the browser to answer
+const pingTimeout = 3 * time.Second
+
+// maxPingFailures is how many pings in a row must go unanswered before
+// Ping declares the browser lost
+const maxPingFailures = 3
+
+// Ping checks that the browser still answers DevTools calls, asking up to
+// maxPingFailures times so one slow answer doesn't count. A browser that
+// never answers has crashed or lost its connection, so it is killed and
+// forgotten along with its pages, and Launch can be called again.
+func (bm *BrowserManager) Ping() error {
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return ErrBrowserNotRunning
+	}
+
+	var err error
+	for range maxPingFailures {
+		if _, err = browser.Timeout(pingTimeout).Version(); err == nil {
+			return nil
 		}
 	}
+	bm.forgetBrowser(browser)
+	return fmt.Errorf("%w: not responding: %v", ErrBrowserLost, err)
+}
 
-	return ""
+// forgetBrowser kills browser if it's still the current one and clears
+// everything tied to it, so the next Launch starts fresh
+func (bm *BrowserManager) forgetBrowser(browser *rod.Browser) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.browser != browser {
+		return
+	}
+	if bm.launcher != nil {
+		bm.launcher.Kill()
+	}
+	bm.browser = nil
+	bm.pages = nil
+	bm.sharedPage = nil
+	// A new context rather than a cancelled one: the browser was lost, not
+	// closed on purpose, so stopped stays false and recovery goes ahead
+	bm.cancel()
+	bm.ctx, bm.cancel = context.WithCancel(context.Background())
 }
 
-// Restart stops and starts the browser
-func (bm *BrowserManager) Restart() error {
-	if err := bm.Close(); err != nil {
-		return err
+// stopped reports whether the browser was closed on purpose
+func (bm *BrowserManager) stopped() bool {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return bm.ctx.Err() != nil && bm.browser == nil
+}
+
+// recoverSession restarts the browser and logs back in, preferring the
+// cookies saved from the last login over re-entering credentials
+func (bm *BrowserManager) recoverSession(profile *store.LinkedInProfile) (*rod.Page, error) {
+	bm.mu.RLock()
+	cookies := bm.cookies
+	bm.mu.RUnlock()
+
+	if err := bm.Restart(); err != nil {
+		return nil, fmt.Errorf("failed to restart browser: %w", err)
+	}
+
+	if len(cookies) > 0 {
+		page, err := bm.restoreSession(cookies)
+		if err == nil {
+			return page, nil
+		}
+		fmt.Printf("⚠️ Could not restore session from cookies (%v), logging in again\n", err)
 	}
-	err := bm.Launch()
+
+	ok, page, err := bm.Login(profile.Email, profile.Password)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	if !ok {
+		return nil, ErrLoginFailed
+	}
+	return page, nil
+}
+
+// restoreSession loads saved cookies into the browser and checks that
+// LinkedIn still treats them as logged in
+func (bm *BrowserManager) restoreSession(cookies []*proto.NetworkCookie) (*rod.Page, error) {
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return nil, ErrBrowserNotRunning
+	}
+	if err := browser.SetCookies(proto.CookiesToParams(cookies)); err != nil {
+		return nil, fmt.Errorf("failed to set cookies: %w", err)
+	}
+
+	page, err := bm.SharedPage()
+	if err != nil {
+		return nil, err
+	}
+	if err := page.Navigate("https://www.linkedin.com/feed/"); err != nil {
+		return nil, fmt.Errorf("failed to navigate: %w", err)
+	}
+	if !isLoggedIn(page) {
+		return nil, fmt.Errorf("%w: session is no longer logged in", ErrLoginFailed)
+	}
+	return page, nil
 }
@@ -0,0 +1,1303 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foxyapply/internal/store"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestClampToRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		min, max string
+		want     string
+	}{
+		{name: "below min", value: "0", min: "1", max: "99", want: "1"},
+		{name: "above max", value: "150000", min: "0", max: "99", want: "99"},
+		{name: "in range", value: "5", min: "0", max: "99", want: "5"},
+		{name: "no bounds", value: "150000", want: "150000"},
+		{name: "only max", value: "12", max: "10", want: "10"},
+		{name: "decimal bounds", value: "7", min: "0.5", max: "6.5", want: "6.5"},
+		{name: "non-numeric falls back to max", value: "", min: "0", max: "30", want: "30"},
+		{name: "non-numeric falls back to min", value: "n/a", min: "2", want: "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampToRange(tt.value, tt.min, tt.max); got != tt.want {
+				t.Errorf("clampToRange(%q, %q, %q) = %q, want %q", tt.value, tt.min, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCoverLetterField(t *testing.T) {
+	tests := []struct {
+		label string
+		rows  string
+		want  bool
+	}{
+		{label: "Cover letter", want: true},
+		{label: "Additional information (optional)", want: true},
+		{label: "Describe your experience", rows: "6", want: true},
+		{label: "Why do you want to work here?", rows: "2", want: false},
+		{label: "Years of experience", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isCoverLetterField(tt.label, tt.rows); got != tt.want {
+			t.Errorf("isCoverLetterField(%q, %q) = %v, want %v", tt.label, tt.rows, got, tt.want)
+		}
+	}
+}
+
+func TestApplyToJobsStopsAtMax(t *testing.T) {
+	jobIDs := []int{1, 2, 3, 4, 5, 6}
+
+	var attempted []int
+	var session ApplySession
+	apply := func(jobID int) (string, error) {
+		attempted = append(attempted, jobID)
+		// Odd job IDs fail to submit
+		if jobID%2 == 0 {
+			return store.ApplicationStatusApplied, nil
+		}
+		return store.ApplicationStatusFailed, nil
+	}
+
+	if reached, _ := applyToJobs(jobIDs, &session, 2, nil, nil, apply); !reached {
+		t.Fatal("expected limit to be reached")
+	}
+	if session.Applied != 2 {
+		t.Errorf("expected 2 applications, got %d", session.Applied)
+	}
+	if len(attempted) != 4 {
+		t.Errorf("expected to stop after job 4, attempted %v", attempted)
+	}
+
+	// Already at the limit: nothing else is attempted
+	attempted = nil
+	if reached, _ := applyToJobs(jobIDs, &session, 2, nil, nil, apply); !reached {
+		t.Fatal("expected limit to still be reached")
+	}
+	if len(attempted) != 0 {
+		t.Errorf("expected no attempts once limit reached, got %v", attempted)
+	}
+}
+
+func TestApplyToJobsUnlimited(t *testing.T) {
+	var session ApplySession
+	reached, _ := applyToJobs([]int{1, 2, 3}, &session, 0, nil, nil, func(jobID int) (string, error) {
+		return store.ApplicationStatusApplied, nil
+	})
+	if reached {
+		t.Error("expected unlimited run never to reach a limit")
+	}
+	if session.Applied != 3 {
+		t.Errorf("expected 3 applications, got %d", session.Applied)
+	}
+}
+
+func TestApplyToJobsStopsAfterConsecutiveFailures(t *testing.T) {
+	outcomes := map[int]string{
+		1: store.ApplicationStatusFailed,
+		2: store.ApplicationStatusFailed,
+		3: store.ApplicationStatusApplied, // Resets the count
+		4: store.ApplicationStatusFailed,
+		5: store.ApplicationStatusSkipped, // Neither counts nor resets
+		6: store.ApplicationStatusFailed,
+		7: store.ApplicationStatusFailed,
+		8: store.ApplicationStatusApplied,
+	}
+	var attempted []int
+	apply := func(jobID int) (string, error) {
+		attempted = append(attempted, jobID)
+		return outcomes[jobID], nil
+	}
+
+	var session ApplySession
+	failures := &failureStreak{max: 3}
+	_, err := applyToJobs([]int{1, 2, 3, 4, 5, 6, 7, 8}, &session, 0, failures, nil, apply)
+	if !errors.Is(err, ErrTooManyFailures) {
+		t.Fatalf("expected ErrTooManyFailures, got %v", err)
+	}
+	if fmt.Sprint(attempted) != "[1 2 3 4 5 6 7]" || session.Failed != 5 {
+		t.Errorf("expected to stop after job 7 with every failure tallied, attempted %v, session %+v", attempted, session)
+	}
+
+	// The count carries over to the next page of results, and errors count as failures
+	failures = &failureStreak{max: 3}
+	if _, err := applyToJobs([]int{1, 2}, &session, 0, failures, nil, apply); err != nil {
+		t.Fatalf("expected two failures to be tolerated, got %v", err)
+	}
+	attempted = nil
+	_, err = applyToJobs([]int{9, 8}, &session, 0, failures, nil, func(jobID int) (string, error) {
+		attempted = append(attempted, jobID)
+		return "", errors.New("navigation failed")
+	})
+	if !errors.Is(err, ErrTooManyFailures) || fmt.Sprint(attempted) != "[9]" {
+		t.Errorf("expected the next page's first failure to stop the run, got %v after %v", err, attempted)
+	}
+
+	// Without a streak, failures never stop the run
+	if _, err := applyToJobs([]int{1, 2, 4, 6, 7}, &session, 0, nil, nil, apply); err != nil {
+		t.Errorf("expected no circuit breaker, got %v", err)
+	}
+}
+
+func TestPauseBlocksUntilResume(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	bm.Pause()
+	if !bm.IsPaused() {
+		t.Fatal("expected manager to be paused")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- bm.waitIfPaused() }()
+
+	select {
+	case <-done:
+		t.Fatal("expected waitIfPaused to block while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	bm.Resume()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected nil error after resume, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected waitIfPaused to return after resume")
+	}
+}
+
+func TestOwnedPauseLeavesUserPauseAlone(t *testing.T) {
+	bm := NewBrowserManager(nil)
+
+	// A pause taken on a running run is lifted by its owner
+	token := bm.pauseOwned()
+	if token == nil || !bm.IsPaused() {
+		t.Fatal("expected pauseOwned to pause a running run")
+	}
+	bm.resumeOwned(token)
+	if bm.IsPaused() {
+		t.Error("expected resumeOwned to lift its own pause")
+	}
+
+	// A pause the user already set stays
+	bm.Pause()
+	if token := bm.pauseOwned(); token != nil {
+		t.Errorf("expected no token for a run already paused, got %v", token)
+	}
+	bm.resumeOwned(nil)
+	if !bm.IsPaused() {
+		t.Error("expected the user's pause to stay")
+	}
+	bm.Resume()
+
+	// So does one the user set after resuming the owned pause by hand
+	token = bm.pauseOwned()
+	bm.Resume()
+	bm.Pause()
+	bm.resumeOwned(token)
+	if !bm.IsPaused() {
+		t.Error("expected a later pause by the user to stay")
+	}
+}
+
+func TestPausedRunRespondsToCancellation(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	bm.Pause()
+
+	var session ApplySession
+	done := make(chan error, 1)
+	go func() {
+		_, err := applyToJobs([]int{1, 2}, &session, 0, nil, bm.waitIfPaused, func(jobID int) (string, error) {
+			return store.ApplicationStatusApplied, nil
+		})
+		done <- err
+	}()
+
+	bm.cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected paused run to stop on cancellation")
+	}
+	if session.Applied != 0 {
+		t.Errorf("expected no applications while paused, got %d", session.Applied)
+	}
+}
+
+func TestStepTrackerDetectsStuckForm(t *testing.T) {
+	var tracker stepTracker
+
+	steps := []struct {
+		signature string
+		stuck     bool
+	}{
+		{signature: formSignature([]string{"phone", "email"}), stuck: false},
+		{signature: formSignature([]string{"email", "phone"}), stuck: false}, // same step, retry once
+		{signature: formSignature([]string{"years"}), stuck: false},          // advanced
+		{signature: "", stuck: false},                                        // no fields visible
+		{signature: formSignature([]string{"years"}), stuck: false},
+		{signature: formSignature([]string{"years"}), stuck: true},
+	}
+
+	for i, s := range steps {
+		if got := tracker.observe(s.signature); got != s.stuck {
+			t.Fatalf("observation %d (%q): stuck = %v, want %v", i, s.signature, got, s.stuck)
+		}
+	}
+	if tracker.last != "years" {
+		t.Errorf("expected stuck step signature 'years', got %q", tracker.last)
+	}
+}
+
+func TestOnlyOneApplySessionAtATime(t *testing.T) {
+	bm := NewBrowserManager(nil)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make(chan bool, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- bm.beginApplying()
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	claimed := 0
+	for ok := range results {
+		if ok {
+			claimed++
+		}
+	}
+	if claimed != 1 {
+		t.Fatalf("expected exactly one session to start, got %d", claimed)
+	}
+
+	profile := &store.LinkedInProfile{Positions: []string{"Engineer"}, Locations: []string{"Remote"}}
+	if _, err := bm.StartApplying(profile, nil); !errors.Is(err, ErrAlreadyApplying) {
+		t.Errorf("expected ErrAlreadyApplying, got %v", err)
+	}
+
+	bm.SetApplying(false)
+	if bm.IsApplying() {
+		t.Error("expected session to be released")
+	}
+}
+
+// Run with -race: flag and pause state must be safe to touch from the UI
+// goroutine while an apply run reads them.
+func TestApplyingStateConcurrentAccess(t *testing.T) {
+	bm := NewBrowserManager(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(4)
+		go func(v bool) {
+			defer wg.Done()
+			bm.SetApplying(v)
+		}(i%2 == 0)
+		go func() {
+			defer wg.Done()
+			_ = bm.IsApplying()
+		}()
+		go func() {
+			defer wg.Done()
+			bm.Pause()
+			bm.Resume()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = bm.IsPaused()
+			_ = bm.Close()
+		}()
+	}
+	wg.Wait()
+
+	// Close cancels the original context even after the flag was toggled
+	bm.cancel()
+	if err := bm.waitIfPaused(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled after cancel, got %v", err)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	err := retryWithBackoff(ctx, 3, time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	calls = 0
+	err = retryWithBackoff(ctx, 2, time.Millisecond, func() error {
+		calls++
+		return ErrRateLimited
+	})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited after exhausting retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 1 attempt plus 2 retries, got %d calls", calls)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	calls = 0
+	err = retryWithBackoff(cancelled, 5, time.Hour, func() error {
+		calls++
+		return errors.New("transient")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected cancellation to stop retries, got %d calls", calls)
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		html string
+		want bool
+	}{
+		{"<html><body><h1>Too Many Requests</h1></body></html>", true},
+		{"<div>HTTP ERROR 429</div>", true},
+		{"<div class=\"jobs-search\">Software Engineer</div>", false},
+	}
+	for _, tt := range tests {
+		if got := isRateLimited(tt.html); got != tt.want {
+			t.Errorf("isRateLimited(%q) = %v, want %v", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestApplyToJobsStopsWhenBrowserLost(t *testing.T) {
+	var session ApplySession
+	var attempted []int
+	_, err := applyToJobs([]int{1, 2, 3}, &session, 0, nil, nil, func(jobID int) (string, error) {
+		attempted = append(attempted, jobID)
+		if jobID == 2 {
+			return store.ApplicationStatusFailed, fmt.Errorf("%w: giving up", ErrBrowserLost)
+		}
+		return store.ApplicationStatusApplied, nil
+	})
+	if !errors.Is(err, ErrBrowserLost) {
+		t.Fatalf("expected ErrBrowserLost, got %v", err)
+	}
+	if len(attempted) != 2 {
+		t.Errorf("expected loop to stop after job 2, attempted %v", attempted)
+	}
+	if session.Applied != 1 {
+		t.Errorf("expected 1 applied, got %d", session.Applied)
+	}
+}
+
+func TestApplyToJobsTalliesOutcomes(t *testing.T) {
+	outcomes := map[int]struct {
+		status string
+		err    error
+	}{
+		1: {store.ApplicationStatusApplied, nil},
+		2: {store.ApplicationStatusFailed, nil},
+		3: {store.ApplicationStatusSkipped, nil},
+		4: {store.ApplicationStatusExternalRedirect, nil},
+		5: {store.ApplicationStatusApplied, nil},
+		6: {"", errors.New("navigation failed")},
+		7: {store.ApplicationStatusApplied, errors.New("submitted but errored")},
+	}
+
+	session := ApplySession{Found: 8, Excluded: 1}
+	_, err := applyToJobs([]int{1, 2, 3, 4, 5, 6, 7}, &session, 0, nil, nil, func(jobID int) (string, error) {
+		o := outcomes[jobID]
+		return o.status, o.err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ApplySession{Found: 8, Applied: 2, Failed: 3, Skipped: 1, Excluded: 1, External: 1}
+	if session != want {
+		t.Errorf("expected tallies %+v, got %+v", want, session)
+	}
+	if total := session.Applied + session.Failed + session.Skipped + session.Excluded + session.External; total != session.Found {
+		t.Errorf("expected outcomes to add up to %d found, got %d", session.Found, total)
+	}
+}
+
+func TestSessionStats(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	if stats := bm.SessionStats(); stats != (ApplySession{}) {
+		t.Errorf("expected empty stats before any session, got %+v", stats)
+	}
+
+	bm.setSession(ApplySession{Found: 3, Applied: 1})
+	if stats := bm.SessionStats(); stats.Found != 3 || stats.Applied != 1 {
+		t.Errorf("expected published stats, got %+v", stats)
+	}
+}
+
+func TestMergeSessions(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	first := ApplySession{Found: 5, Applied: 2, Failed: 1, StartedAt: start.Add(time.Minute), EndedAt: start.Add(time.Hour)}
+	second := ApplySession{Found: 4, Applied: 1, Skipped: 2, Excluded: 1, External: 1, StartedAt: start, EndedAt: start.Add(2 * time.Hour)}
+
+	merged := MergeSessions(first, second, ApplySession{})
+	want := ApplySession{
+		Found: 9, Applied: 3, Failed: 1, Skipped: 2, Excluded: 1, External: 1,
+		StartedAt: start, EndedAt: start.Add(2 * time.Hour),
+	}
+	if merged != want {
+		t.Errorf("expected %+v, got %+v", want, merged)
+	}
+
+	second.EndedAt = time.Time{}
+	if merged := MergeSessions(first, second); !merged.EndedAt.IsZero() {
+		t.Errorf("expected merged session to be in progress, got end %v", merged.EndedAt)
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	submitted, err := recoverPanic(func() (bool, error) {
+		panic("websocket: close 1006")
+	})
+	if submitted || err == nil {
+		t.Fatalf("expected panic to become an error, got (%v, %v)", submitted, err)
+	}
+
+	submitted, err = recoverPanic(func() (bool, error) { return true, nil })
+	if !submitted || err != nil {
+		t.Errorf("expected result to pass through, got (%v, %v)", submitted, err)
+	}
+}
+
+func TestPingWithoutBrowser(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	if err := bm.Ping(); err == nil {
+		t.Error("expected ping to fail without a browser")
+	}
+	if bm.IsRunning() {
+		t.Error("expected IsRunning to be false without a browser")
+	}
+	if bm.stopped() {
+		t.Error("expected fresh manager not to be stopped")
+	}
+	bm.cancel()
+	if !bm.stopped() {
+		t.Error("expected cancelled manager without a browser to be stopped")
+	}
+}
+
+// flakyCDP fails Browser.getVersion a set number of times, then answers
+type flakyCDP struct {
+	fakeCDP
+	failures int
+}
+
+func (f *flakyCDP) Call(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+	if method == "Browser.getVersion" {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.failures > 0 {
+			f.failures--
+			return nil, errors.New("timed out")
+		}
+		return []byte(`{"product": "Chrome/131.0.6778.85"}`), nil
+	}
+	return f.fakeCDP.Call(ctx, sessionID, method, params)
+}
+
+func TestPingToleratesSlowAnswers(t *testing.T) {
+	client := &flakyCDP{fakeCDP: fakeCDP{params: map[string]interface{}{}}, failures: maxPingFailures - 1}
+	bm := NewBrowserManager(nil)
+	browser := rod.New().Client(client)
+	bm.browser = browser
+	bm.sharedPage = &rod.Page{}
+	bm.pages = []*rod.Page{bm.sharedPage}
+
+	if err := bm.Ping(); err != nil {
+		t.Fatalf("expected a late answer to count, got %v", err)
+	}
+
+	// Status checks never tear the browser down
+	client.failures = 1
+	if bm.IsRunning() || bm.GetBrowser() != browser {
+		t.Fatal("expected one missed status check to leave the browser alone")
+	}
+
+	client.failures = maxPingFailures
+	if err := bm.Ping(); !errors.Is(err, ErrBrowserLost) {
+		t.Fatalf("expected ErrBrowserLost after %d missed pings, got %v", maxPingFailures, err)
+	}
+	if bm.GetBrowser() != nil || bm.sharedPage != nil || len(bm.pages) != 0 {
+		t.Error("expected the lost browser and its pages to be forgotten")
+	}
+	if bm.stopped() {
+		t.Error("expected a lost browser not to count as stopped on purpose")
+	}
+}
+
+func TestRestartKeepsApplyingClaim(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	bm.browser = rod.New().Client(&fakeCDP{params: map[string]interface{}{}})
+	if !bm.beginApplying() {
+		t.Fatal("expected to claim the applying flag")
+	}
+
+	// The close half of a restart leaves the session's claim in place, so
+	// no other run can start while the browser is down
+	if err := bm.close(true); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if !bm.IsApplying() || bm.beginApplying() {
+		t.Error("expected the applying flag to survive the restart's close")
+	}
+
+	bm.browser = rod.New().Client(&fakeCDP{params: map[string]interface{}{}})
+	if err := bm.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if bm.IsApplying() {
+		t.Error("expected Close to clear the applying flag")
+	}
+}
+
+func TestClaimApplying(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	release, err := bm.ClaimApplying()
+	if err != nil {
+		t.Fatalf("ClaimApplying failed: %v", err)
+	}
+	if _, err := bm.ClaimApplying(); !errors.Is(err, ErrAlreadyApplying) {
+		t.Errorf("expected a second claim to fail with ErrAlreadyApplying, got %v", err)
+	}
+	release()
+	if bm.IsApplying() {
+		t.Error("expected release to give up an unused claim")
+	}
+
+	// A run takes the claim over and releases it when it ends
+	release, err = bm.ClaimApplying()
+	if err != nil {
+		t.Fatalf("ClaimApplying failed: %v", err)
+	}
+	if _, err := bm.StartApplying(&store.LinkedInProfile{}, nil); err == nil || errors.Is(err, ErrAlreadyApplying) {
+		t.Errorf("expected the run to start with the claim and fail on the empty profile, got %v", err)
+	}
+	if bm.IsApplying() {
+		t.Error("expected the run to release the claim it took over")
+	}
+
+	// A stale release leaves a newer claim alone
+	next, err := bm.ClaimApplying()
+	if err != nil {
+		t.Fatalf("ClaimApplying failed: %v", err)
+	}
+	release()
+	if !bm.IsApplying() {
+		t.Error("expected a stale release not to drop a newer claim")
+	}
+	next()
+}
+
+func TestSetConfig(t *testing.T) {
+	bm := NewBrowserManager(&Config{Headless: false})
+	if err := bm.SetConfig(&Config{Headless: true, ApplicationsPerHour: 10}); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if !bm.cfg.Headless || bm.limiter == nil {
+		t.Error("expected the new config and its rate limit to be used")
+	}
+
+	// A run keeps the config it started with
+	if !bm.beginApplying() {
+		t.Fatal("expected to claim the apply session")
+	}
+	if err := bm.SetConfig(&Config{}); !errors.Is(err, ErrBrowserBusy) {
+		t.Errorf("expected ErrBrowserBusy while applying, got %v", err)
+	}
+	if !bm.cfg.Headless {
+		t.Error("expected the running config to be kept")
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw      string
+		code     string
+		national string
+	}{
+		{"+1 (555) 123-4567", "1", "5551234567"},
+		{"(555) 123-4567", "", "5551234567"},
+		{"555.123.4567", "", "5551234567"},
+		{"1-555-123-4567", "1", "5551234567"},
+		{"+44 (0)20 7946 0958", "44", "2079460958"},
+		{"+442079460958", "44", "2079460958"},
+		{"0044 20 7946 0958", "44", "2079460958"},
+		{"+353 1 234 5678", "353", "12345678"},
+		{"+91-98765-43210", "91", "9876543210"},
+		{"+1 555 123 4567 ext. 89", "1", "5551234567"},
+		{"555-123-4567 x12", "", "5551234567"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		code, national := NormalizePhone(tt.raw)
+		if code != tt.code || national != tt.national {
+			t.Errorf("NormalizePhone(%q) = (%q, %q), want (%q, %q)", tt.raw, code, national, tt.code, tt.national)
+		}
+	}
+}
+
+func TestChooseValuePhone(t *testing.T) {
+	profile := &store.LinkedInProfile{PhoneNumber: "+1 (555) 123-4567"}
+
+	if got := ChooseValue("Mobile phone number", "number", profile, nil); got != "5551234567" {
+		t.Errorf("expected digits-only number input, got %q", got)
+	}
+	if got := ChooseValue("Phone", "tel", profile, nil); got != "5551234567" {
+		t.Errorf("expected digits-only tel input, got %q", got)
+	}
+	if got := ChooseValue("Mobile phone number", "text", profile, nil); got != profile.PhoneNumber {
+		t.Errorf("expected raw phone number for text input, got %q", got)
+	}
+}
+
+func TestIsPhoneCountryCodeField(t *testing.T) {
+	if !isPhoneCountryCodeField("Phone country code", "") {
+		t.Error("expected label match")
+	}
+	if !isPhoneCountryCodeField("", "text-entity-list-form-component-formElement-urn-li-jobs-applyformcommon-easyApplyFormElement-123-phoneNumber-country") {
+		t.Error("expected id match")
+	}
+	if isPhoneCountryCodeField("Country", "location-country") {
+		t.Error("expected plain country select not to match")
+	}
+}
+
+func TestSetBrowserBin(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	if err := bm.SetBrowserBin("/opt/chrome/chrome"); err != nil {
+		t.Fatalf("expected SetBrowserBin to succeed while stopped: %v", err)
+	}
+	if bm.cfg.BrowserBin != "/opt/chrome/chrome" {
+		t.Errorf("expected BrowserBin to be updated, got %q", bm.cfg.BrowserBin)
+	}
+}
+
+func TestCheckBrowserAvailable(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "chrome")
+	if err := os.WriteFile(bin, nil, 0755); err != nil {
+		t.Fatalf("failed to write fake browser: %v", err)
+	}
+	bm := NewBrowserManager(&Config{BrowserBin: bin})
+	if err := bm.CheckBrowserAvailable(); err != nil {
+		t.Errorf("expected the configured browser to be found, got %v", err)
+	}
+
+	if err := checkBrowserPaths([]string{"/missing/chrome", bin}); err != nil {
+		t.Errorf("expected any existing path to do, got %v", err)
+	}
+	err := checkBrowserPaths([]string{"/missing/chrome", "/also/missing/chromium"})
+	if !errors.Is(err, ErrBrowserNotAvailable) {
+		t.Fatalf("expected ErrBrowserNotAvailable, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "/missing/chrome, /also/missing/chromium") {
+		t.Errorf("expected the checked paths in the error, got %q", err)
+	}
+
+	bm = NewBrowserManager(&Config{BrowserBin: "/missing/chrome"})
+	if bm.findSystemBrowser() != "" || bm.findBundledBrowser() != "" {
+		t.Skip("a system or bundled browser is installed")
+	}
+	err = bm.CheckBrowserAvailable()
+	if !errors.Is(err, ErrBrowserNotAvailable) || !strings.Contains(err.Error(), "/missing/chrome") {
+		t.Errorf("expected ErrBrowserNotAvailable naming the configured path, got %v", err)
+	}
+	for _, p := range systemBrowserPaths() {
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("expected system path %s in %q", p, err)
+		}
+	}
+}
+
+func TestFormStallError(t *testing.T) {
+	tests := []struct {
+		err  *formStallError
+		want string
+	}{
+		{
+			&formStallError{reason: "gave up after 15 iterations", step: 3, lastButton: "next", inlineErrors: true, field: "Years of Go experience"},
+			`stalled on step 3: gave up after 15 iterations, unresolved required field "Years of Go experience" (last button: next, inline errors: true)`,
+		},
+		{
+			&formStallError{reason: "form did not advance (fields: a,b)", step: 1},
+			"stalled on step 1: form did not advance (fields: a,b) (last button: none, inline errors: false)",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() =\n  %s\nwant\n  %s", got, tt.want)
+		}
+	}
+}
+
+func TestOperationTimeoutOnMissingSelector(t *testing.T) {
+	bm := NewBrowserManager(&Config{OperationTimeout: 500 * time.Millisecond})
+	bin := bm.findSystemBrowser()
+	if bin == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+
+	u, err := launcher.New().Bin(bin).Headless(true).NoSandbox(true).Launch()
+	if err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	browser := rod.New().ControlURL(u).MustConnect()
+	defer browser.MustClose()
+
+	page := browser.MustPage("about:blank")
+	start := time.Now()
+	err = rod.Try(func() {
+		bm.timed(page).MustElement("#never-appears")
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected lookup to fail fast, took %s", elapsed)
+	}
+
+	// Each call gets a fresh budget rather than sharing one deadline
+	time.Sleep(600 * time.Millisecond)
+	if _, err := bm.timed(page).Eval(`() => 1`); err != nil {
+		t.Errorf("expected a new operation to succeed after an earlier timeout: %v", err)
+	}
+}
+
+func TestIsConsentLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		want  bool
+	}{
+		{"I agree to the terms and conditions", true},
+		{"I consent to the processing of my personal data", true},
+		{"I acknowledge that I have read the Privacy Policy", true},
+		{"I certify that the information provided is accurate", true},
+		{"Follow Acme Corp to stay up to date with their page", false},
+		{"I agree to receive marketing emails", false},
+		{"Subscribe to our newsletter", false},
+		{"Send me job alerts and updates", false},
+		{"Are you legally authorized to work in the US?", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isConsentLabel(tt.label); got != tt.want {
+			t.Errorf("isConsentLabel(%q) = %v, want %v", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestIsResumeUploadField(t *testing.T) {
+	tests := []struct {
+		label, id string
+		want      bool
+	}{
+		{"Upload resume", "", true},
+		{"", "jobs-document-upload-file-input-upload-resume", true},
+		{"Upload CV (PDF, DOCX)", "", true},
+		{"Upload cover letter", "jobs-document-upload-file-input-upload-cover-letter", false},
+		{"Portfolio", "", false},
+	}
+	for _, tt := range tests {
+		if got := isResumeUploadField(tt.label, tt.id); got != tt.want {
+			t.Errorf("isResumeUploadField(%q, %q) = %v, want %v", tt.label, tt.id, got, tt.want)
+		}
+	}
+}
+
+// fakeResumes is a ResumeLister backed by a slice
+type fakeResumes []*store.Resume
+
+func (f fakeResumes) ListResumes(profileID int64) ([]*store.Resume, error) {
+	return f, nil
+}
+
+func TestResumeFor(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	if path := bm.resumeFor(1, "Backend Engineer"); path != "" {
+		t.Errorf("expected no resume without a lister, got %q", path)
+	}
+
+	bm.SetResumeLister(fakeResumes{
+		{Path: "/general.pdf", IsDefault: true},
+		{Path: "/backend.pdf", Keywords: []string{"backend"}},
+	})
+	if path := bm.resumeFor(1, "Backend Engineer"); path != "/backend.pdf" {
+		t.Errorf("expected backend resume, got %q", path)
+	}
+	if path := bm.resumeFor(1, "Designer"); path != "/general.pdf" {
+		t.Errorf("expected default resume, got %q", path)
+	}
+}
+
+// fakeCDP is a CDP client that records calls and answers from canned results
+type fakeCDP struct {
+	mu      sync.Mutex
+	calls   []string
+	params  map[string]interface{}
+	results map[string]string
+}
+
+func (f *fakeCDP) Event() <-chan *cdp.Event {
+	return make(chan *cdp.Event)
+}
+
+func (f *fakeCDP) Call(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method)
+	f.params[method] = params
+	if res, ok := f.results[method]; ok {
+		return []byte(res), nil
+	}
+	return []byte("{}"), nil
+}
+
+func TestSetWindowState(t *testing.T) {
+	client := &fakeCDP{
+		params: map[string]interface{}{},
+		results: map[string]string{
+			"Target.getTargets": `{"targetInfos": [
+				{"targetId": "worker", "type": "service_worker", "title": "", "url": "", "attached": false, "canAccessOpener": false},
+				{"targetId": "tab-1", "type": "page", "title": "", "url": "about:blank", "attached": false, "canAccessOpener": false}
+			]}`,
+			"Browser.getWindowForTarget": `{"windowId": 7, "bounds": {}}`,
+		},
+	}
+
+	bm := NewBrowserManager(nil)
+	if err := bm.SetWindowState("minimized"); err == nil {
+		t.Error("expected error without a browser")
+	}
+
+	bm.browser = rod.New().Client(client)
+	if err := bm.SetWindowState("sideways"); err == nil {
+		t.Error("expected error for an invalid state")
+	}
+	if err := bm.SetWindowState("minimized"); err != nil {
+		t.Fatalf("failed to set window state: %v", err)
+	}
+
+	want := []string{"Target.getTargets", "Browser.getWindowForTarget", "Browser.setWindowBounds"}
+	if fmt.Sprint(client.calls) != fmt.Sprint(want) {
+		t.Fatalf("expected calls %v, got %v", want, client.calls)
+	}
+	if p := client.params["Browser.getWindowForTarget"].(proto.BrowserGetWindowForTarget); p.TargetID != "tab-1" {
+		t.Errorf("expected window lookup for the page target, got %q", p.TargetID)
+	}
+	bounds := client.params["Browser.setWindowBounds"].(proto.BrowserSetWindowBounds)
+	if bounds.WindowID != 7 || bounds.Bounds.WindowState != proto.BrowserWindowStateMinimized {
+		t.Errorf("expected window 7 minimized, got %+v", bounds)
+	}
+
+	// Headless browsers have no window to change
+	headless := NewBrowserManager(&Config{Headless: true})
+	headless.browser = rod.New().Client(client)
+	client.calls = nil
+	if err := headless.SetWindowState("minimized"); err != nil || len(client.calls) != 0 {
+		t.Errorf("expected headless to be a no-op, got err %v and calls %v", err, client.calls)
+	}
+}
+
+func TestCloseAllPages(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	if _, err := bm.Navigate("about:blank", true); err == nil {
+		t.Error("expected error without a browser")
+	}
+
+	bin := bm.findSystemBrowser()
+	if bin == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+	u, err := launcher.New().Bin(bin).Headless(true).NoSandbox(true).Launch()
+	if err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	bm.browser = rod.New().ControlURL(u).MustConnect()
+	defer bm.Close()
+
+	first, err := bm.Navigate("about:blank", true)
+	if err != nil {
+		t.Fatalf("failed to navigate: %v", err)
+	}
+	second, err := bm.Navigate("about:blank", true)
+	if err != nil {
+		t.Fatalf("failed to navigate: %v", err)
+	}
+	if first != second {
+		t.Error("expected reused navigations to share a page")
+	}
+	if _, err := bm.Navigate("about:blank", false); err != nil {
+		t.Fatalf("failed to navigate: %v", err)
+	}
+	if len(bm.pages) != 2 {
+		t.Fatalf("expected 2 tracked pages, got %d", len(bm.pages))
+	}
+
+	bm.CloseAllPages()
+	if len(bm.pages) != 0 || bm.sharedPage != nil {
+		t.Error("expected tracked pages to be cleared")
+	}
+	pages, err := bm.browser.Pages()
+	if err != nil {
+		t.Fatalf("failed to list pages: %v", err)
+	}
+	for _, page := range pages {
+		if page.TargetID == first.TargetID {
+			t.Error("expected shared page to be closed")
+		}
+	}
+}
+
+func TestRetryApplicationsUpdatesStatus(t *testing.T) {
+	apps := []store.Application{
+		{ID: 10, JobID: 1, Status: store.ApplicationStatusFailed},
+		{ID: 11, JobID: 2, Status: store.ApplicationStatusFailed},
+		{ID: 12, JobID: 3, Status: store.ApplicationStatusFailed},
+		{ID: 13, JobID: 4, Status: store.ApplicationStatusFailed},
+	}
+	outcomes := map[int]struct {
+		status string
+		err    error
+	}{
+		1: {store.ApplicationStatusApplied, nil},
+		2: {store.ApplicationStatusFailed, nil},
+		3: {store.ApplicationStatusApplied, errors.New("submitted but errored")},
+		4: {store.ApplicationStatusSkipped, nil},
+	}
+
+	updates := map[int64]string{}
+	applied, err := retryApplications(apps, nil, func(jobID int) (string, error) {
+		o := outcomes[jobID]
+		return o.status, o.err
+	}, func(id int64, status string) error {
+		updates[id] = status
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("expected 1 application to go through, got %d", applied)
+	}
+
+	// Unchanged and errored attempts stay failed without a write
+	want := map[int64]string{10: store.ApplicationStatusApplied, 13: store.ApplicationStatusSkipped}
+	if fmt.Sprint(updates) != fmt.Sprint(want) {
+		t.Errorf("expected updates %v, got %v", want, updates)
+	}
+}
+
+func TestRetryApplicationsStopsOnWaitError(t *testing.T) {
+	apps := []store.Application{{ID: 1, JobID: 1, Status: store.ApplicationStatusFailed}}
+	stop := errors.New("stopped")
+	_, err := retryApplications(apps, func() error { return stop }, func(int) (string, error) {
+		t.Fatal("retry should not run after wait fails")
+		return "", nil
+	}, func(int64, string) error { return nil })
+	if !errors.Is(err, stop) {
+		t.Errorf("expected wait error, got %v", err)
+	}
+}
+
+func TestDismissApplicationSent(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	bin := bm.findSystemBrowser()
+	if bin == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+
+	u, err := launcher.New().Bin(bin).Headless(true).NoSandbox(true).Launch()
+	if err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	browser := rod.New().ControlURL(u).MustConnect()
+	defer browser.MustClose()
+
+	page := browser.MustPage("about:blank")
+	page.MustSetDocumentContent(`<div class="artdeco-modal">Your application was sent
+		<button class="artdeco-modal__dismiss" onclick="this.parentElement.remove()">Dismiss</button>
+	</div>`)
+	if err := bm.DismissApplicationSent(page); err != nil {
+		t.Fatalf("failed to dismiss modal: %v", err)
+	}
+	if has, _, _ := page.Has(".artdeco-modal"); has {
+		t.Error("expected modal to be removed")
+	}
+
+	// No modal is fine
+	page.MustSetDocumentContent(`<p>Next job</p>`)
+	if err := bm.DismissApplicationSent(page); err != nil {
+		t.Errorf("expected no error without a modal, got %v", err)
+	}
+}
+
+func TestSelectEasyApplyButton(t *testing.T) {
+	primary, sticky, disabled, hidden, save := &rod.Element{}, &rod.Element{}, &rod.Element{}, &rod.Element{}, &rod.Element{}
+	candidate := func(el *rod.Element, label string, visible, enabled, inTopCard bool) easyApplyCandidate {
+		return easyApplyCandidate{el: el, label: label, visible: visible, enabled: enabled, inTopCard: inTopCard}
+	}
+
+	tests := []struct {
+		name       string
+		candidates []easyApplyCandidate
+		want       *rod.Element
+	}{
+		{"single", []easyApplyCandidate{
+			candidate(primary, "Easy Apply to Go Engineer at Acme", true, true, true),
+		}, primary},
+		{"skips disabled and hidden", []easyApplyCandidate{
+			candidate(disabled, "Easy Apply to Go Engineer at Acme", true, false, true),
+			candidate(hidden, "Easy Apply to Go Engineer at Acme", false, true, true),
+			candidate(primary, "Easy Apply to Go Engineer at Acme", true, true, true),
+		}, primary},
+		{"prefers the top card", []easyApplyCandidate{
+			candidate(sticky, "Easy Apply to Go Engineer at Acme", true, true, false),
+			candidate(primary, "Easy Apply to Go Engineer at Acme", true, true, true),
+		}, primary},
+		{"prefers the Easy Apply label", []easyApplyCandidate{
+			candidate(save, "Save job, Easy Apply to Go Engineer at Acme", true, true, true),
+			candidate(primary, "Easy Apply to Go Engineer at Acme", true, true, true),
+		}, primary},
+		{"falls back outside the top card", []easyApplyCandidate{
+			candidate(disabled, "Easy Apply to Go Engineer at Acme", true, false, true),
+			candidate(sticky, "Easy Apply to Go Engineer at Acme", true, true, false),
+		}, sticky},
+		{"falls back to the first usable", []easyApplyCandidate{
+			candidate(hidden, "Easy Apply to Go Engineer at Acme", false, true, true),
+			candidate(save, "Save job, Easy Apply to Go Engineer at Acme", true, true, true),
+		}, save},
+	}
+	for _, tt := range tests {
+		got, err := selectEasyApplyButton(tt.candidates)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: got %p (%v), want %p", tt.name, got, err, tt.want)
+		}
+	}
+
+	for name, candidates := range map[string][]easyApplyCandidate{
+		"none":         nil,
+		"all disabled": {candidate(disabled, "Easy Apply to Go Engineer at Acme", true, false, true)},
+		"all hidden":   {candidate(hidden, "Easy Apply to Go Engineer at Acme", false, true, true)},
+	} {
+		if el, err := selectEasyApplyButton(candidates); err == nil {
+			t.Errorf("%s: expected an error, got %p", name, el)
+		}
+	}
+}
+
+func TestClickEasyApplyInChrome(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	bin := bm.findSystemBrowser()
+	if bin == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+
+	u, err := launcher.New().Bin(bin).Headless(true).NoSandbox(true).Launch()
+	if err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	browser := rod.New().ControlURL(u).MustConnect()
+	defer browser.MustClose()
+
+	page := browser.MustPage("about:blank")
+	page.MustSetDocumentContent(`
+		<button aria-label="Easy Apply to Go Engineer at Acme" style="display:none" onclick="document.body.dataset.clicked='hidden'">Easy Apply</button>
+		<header><button aria-label="Easy Apply to Go Engineer at Acme" onclick="document.body.dataset.clicked='sticky'">Easy Apply</button></header>
+		<div class="jobs-unified-top-card">
+			<button aria-label="Easy Apply to Go Engineer at Acme" onclick="document.body.dataset.clicked='primary'">Easy Apply</button>
+		</div>`)
+	if err := clickEasyApply(bm.controller(page)); err != nil {
+		t.Fatalf("clickEasyApply failed: %v", err)
+	}
+	if got := page.MustEval(`() => document.body.dataset.clicked || ""`).String(); got != "primary" {
+		t.Errorf("expected the top card's button to be clicked, got %q", got)
+	}
+}
+
+func TestNewLauncherPassesUserDataDir(t *testing.T) {
+	dir := t.TempDir()
+	l := NewBrowserManager(&Config{Headless: true, UserData: dir}).newLauncher()
+	if got := l.Get(flags.UserDataDir); got != dir {
+		t.Errorf("expected --user-data-dir=%s, got %q", dir, got)
+	}
+
+	// Without one, each launch gets rod's fresh temporary directory
+	l = NewBrowserManager(&Config{Headless: true}).newLauncher()
+	if got := l.Get(flags.UserDataDir); got == "" || got == dir {
+		t.Errorf("expected a temporary user data dir, got %q", got)
+	}
+}
+
+func TestNewLauncherShowsWindowForManualLogin(t *testing.T) {
+	if !NewBrowserManager(&Config{Headless: true}).newLauncher().Has(flags.Headless) {
+		t.Error("expected a headless launch")
+	}
+	if NewBrowserManager(&Config{Headless: true, ManualLogin: true}).newLauncher().Has(flags.Headless) {
+		t.Error("expected a manual login to show the window")
+	}
+}
+
+func TestNewLauncherSetsLocale(t *testing.T) {
+	if got := NewBrowserManager(&Config{Headless: true}).newLauncher().Get(flags.Flag("lang")); got != DefaultLocale {
+		t.Errorf("expected --lang=%s by default, got %q", DefaultLocale, got)
+	}
+	if got := NewBrowserManager(&Config{Headless: true, Locale: "de-DE"}).newLauncher().Get(flags.Flag("lang")); got != "de-DE" {
+		t.Errorf("expected --lang=de-DE, got %q", got)
+	}
+}
+
+func TestWithAcceptLanguage(t *testing.T) {
+	if got := acceptLanguage("en-US"); got != "en-US,en;q=0.9" {
+		t.Errorf("unexpected Accept-Language %q", got)
+	}
+	if got := acceptLanguage("fr"); got != "fr" {
+		t.Errorf("unexpected Accept-Language %q", got)
+	}
+
+	var headers proto.NetworkHeaders
+	if err := json.Unmarshal([]byte(`{"accept-language": "de-DE,de;q=0.9", "User-Agent": "Chrome"}`), &headers); err != nil {
+		t.Fatalf("failed to parse headers: %v", err)
+	}
+	var got []string
+	for _, h := range withAcceptLanguage(headers, "en-US,en;q=0.9") {
+		got = append(got, h.Name+": "+h.Value)
+	}
+	if want := "[Accept-Language: en-US,en;q=0.9 User-Agent: Chrome]"; fmt.Sprint(got) != want {
+		t.Errorf("expected %s, got %v", want, got)
+	}
+}
+
+func TestWaitForManualLogin(t *testing.T) {
+	defer func(d time.Duration) { manualLoginPollInterval = d }(manualLoginPollInterval)
+	manualLoginPollInterval = time.Millisecond
+	const loginURL = "https://www.linkedin.com/login"
+
+	bm := NewBrowserManager(&Config{ManualLogin: true})
+	var events []string
+	bm.SetEventHandler(func(name string, data map[string]interface{}) { events = append(events, name) })
+	signedIn := &fakePage{pages: map[string]string{loginURL: `<nav id="global-nav"><span id="caret-small"></span></nav>`}}
+	if err := bm.waitForManualLogin(signedIn); err != nil {
+		t.Fatalf("expected the signed-in page to be detected, got %v", err)
+	}
+	if fmt.Sprint(events) != "[browser:manual-login browser:logged-in]" {
+		t.Errorf("expected waiting and logged-in events, got %v", events)
+	}
+
+	bm = NewBrowserManager(&Config{ManualLogin: true, ManualLoginTimeout: 10 * time.Millisecond})
+	waiting := &fakePage{pages: map[string]string{loginURL: `<form><input id="username"></form>`}}
+	if err := bm.waitForManualLogin(waiting); !errors.Is(err, ErrManualLoginTimeout) {
+		t.Errorf("expected ErrManualLoginTimeout, got %v", err)
+	}
+
+	// Closing the browser stops the wait
+	bm = NewBrowserManager(&Config{ManualLogin: true})
+	bm.cancel()
+	if err := bm.waitForManualLogin(waiting); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to stop with the browser, got %v", err)
+	}
+}
+
+func TestBrowserErrors(t *testing.T) {
+	// Without a browser, every operation that needs one says so
+	bm := NewBrowserManager(nil)
+	if err := bm.Ping(); !errors.Is(err, ErrBrowserNotRunning) {
+		t.Errorf("Ping: expected ErrBrowserNotRunning, got %v", err)
+	}
+	if _, err := bm.NewPage(); !errors.Is(err, ErrBrowserNotRunning) {
+		t.Errorf("NewPage: expected ErrBrowserNotRunning, got %v", err)
+	}
+	if err := bm.SetWindowState("minimized"); !errors.Is(err, ErrBrowserNotRunning) {
+		t.Errorf("SetWindowState: expected ErrBrowserNotRunning, got %v", err)
+	}
+	if _, _, err := bm.Login("user@example.com", "password"); !errors.Is(err, ErrBrowserNotRunning) {
+		t.Errorf("Login: expected ErrBrowserNotRunning, got %v", err)
+	}
+	if _, err := bm.restoreSession(nil); !errors.Is(err, ErrBrowserNotRunning) {
+		t.Errorf("restoreSession: expected ErrBrowserNotRunning, got %v", err)
+	}
+
+	if err := clickEasyApply(&fakePage{}); !errors.Is(err, ErrEasyApplyNotFound) {
+		t.Errorf("clickEasyApply: expected ErrEasyApplyNotFound, got %v", err)
+	}
+	if _, err := selectEasyApplyButton(nil); !errors.Is(err, ErrEasyApplyNotFound) {
+		t.Errorf("selectEasyApplyButton: expected ErrEasyApplyNotFound, got %v", err)
+	}
+
+	// Specific errors match the general ones they belong to
+	for _, tt := range []struct {
+		err, target error
+	}{
+		{ErrInvalidCredentials, ErrLoginFailed},
+		{ErrManualLoginTimeout, ErrLoginFailed},
+		{ErrChallengeRequired, ErrSecurityChallenge},
+		{ErrThrottled, ErrRateLimited},
+		{checkApplyLimit(&fakePage{pages: map[string]string{"": "You've reached the Easy Apply limit"}}), ErrRateLimited},
+	} {
+		if !errors.Is(tt.err, tt.target) {
+			t.Errorf("expected %v to match %v", tt.err, tt.target)
+		}
+	}
+}
+
+func TestLaunchUsesUserDataDir(t *testing.T) {
+	dir := t.TempDir()
+	bm := NewBrowserManager(&Config{Headless: true, UserData: dir})
+	if bm.findSystemBrowser() == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+	if err := bm.Launch(); err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	defer bm.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, "Local State")); err != nil {
+		t.Errorf("expected Chrome to use %s for its profile: %v", dir, err)
+	}
+}
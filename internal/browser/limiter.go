@@ -0,0 +1,108 @@
+package browser
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// clock is the time source for the application limiter, swapped for a fake
+// in tests
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// tokenBucket spaces out applications to a fixed hourly rate. It holds at
+// most one token, so submissions are evenly spread rather than bursting at
+// the start of each hour.
+type tokenBucket struct {
+	mu       sync.Mutex
+	clock    clock
+	interval time.Duration // Time to earn one token
+	tokens   float64
+	last     time.Time
+}
+
+// newTokenBucket returns a limiter allowing perHour acquisitions an hour,
+// or nil if perHour isn't positive. The first acquisition never waits.
+func newTokenBucket(perHour int, c clock) *tokenBucket {
+	if perHour <= 0 {
+		return nil
+	}
+	return &tokenBucket{
+		clock:    c,
+		interval: time.Hour / time.Duration(perHour),
+		tokens:   1,
+		last:     c.Now(),
+	}
+}
+
+// reserve takes a token if one is available, otherwise returning how long
+// until the next one is earned
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	if b.tokens > 1 {
+		b.tokens = 1
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.interval))
+}
+
+// Wait blocks until a token is available and takes it. It returns the
+// context error if ctx is cancelled first.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		delay := b.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		select {
+		case <-b.clock.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ApplicationsPerHour returns the configured submission rate, or 0 if
+// submissions aren't rate limited
+func (bm *BrowserManager) ApplicationsPerHour() int {
+	if bm.cfg.ApplicationsPerHour < 0 {
+		return 0
+	}
+	return bm.cfg.ApplicationsPerHour
+}
+
+// waitForSubmitSlot blocks until the application limiter allows another
+// submission. It returns the context error if the browser is closed while
+// waiting.
+func (bm *BrowserManager) waitForSubmitSlot() error {
+	bm.mu.RLock()
+	limiter := bm.limiter
+	ctx := bm.ctx
+	bm.mu.RUnlock()
+
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
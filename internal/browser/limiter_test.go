@@ -0,0 +1,93 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock whose time only moves when a waiter sleeps on After,
+// recording each requested wait
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestTokenBucketSpacesAcquisitions(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)}
+	bucket := newTokenBucket(6, clk) // One every 10 minutes
+
+	for i := 0; i < 3; i++ {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait %d failed: %v", i, err)
+		}
+	}
+	want := []time.Duration{10 * time.Minute, 10 * time.Minute}
+	if len(clk.waits) != len(want) || clk.waits[0] != want[0] || clk.waits[1] != want[1] {
+		t.Fatalf("expected waits %v, got %v", want, clk.waits)
+	}
+
+	// Time spent elsewhere counts towards the next token
+	clk.waits = nil
+	clk.now = clk.now.Add(4 * time.Minute)
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if len(clk.waits) != 1 || clk.waits[0] != 6*time.Minute {
+		t.Errorf("expected a 6m wait, got %v", clk.waits)
+	}
+
+	// An idle hour doesn't bank a burst of tokens
+	clk.waits = nil
+	clk.now = clk.now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if len(clk.waits) != 1 || clk.waits[0] != 10*time.Minute {
+		t.Errorf("expected one 10m wait after idling, got %v", clk.waits)
+	}
+}
+
+func TestTokenBucketWaitHonorsCancellation(t *testing.T) {
+	bucket := newTokenBucket(1, realClock{})
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait should not block: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	err := bucket.Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Wait took %v to notice cancellation", elapsed)
+	}
+}
+
+func TestNewTokenBucketUnlimited(t *testing.T) {
+	if bucket := newTokenBucket(0, realClock{}); bucket != nil {
+		t.Error("expected no limiter for 0 applications per hour")
+	}
+	bm := NewBrowserManager(&Config{})
+	if err := bm.waitForSubmitSlot(); err != nil {
+		t.Errorf("unlimited manager should never wait: %v", err)
+	}
+	if got := NewBrowserManager(&Config{ApplicationsPerHour: 12}).ApplicationsPerHour(); got != 12 {
+		t.Errorf("expected 12 applications per hour, got %d", got)
+	}
+}
@@ -1,11 +1,128 @@
 package browser
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foxyapply/internal/store"
+	"math"
 	"net/url"
+	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/PuerkitoBio/goquery"
 )
 
+// JobTitleSelectors locate the job title on a job detail page, in order of preference
+var JobTitleSelectors = []string{
+	".job-details-jobs-unified-top-card__job-title h1",
+	".job-details-jobs-unified-top-card__job-title",
+	".jobs-unified-top-card__job-title",
+	".top-card-layout__title",
+}
+
+// CompanyNameSelectors locate the company name on a job detail page, in order of preference
+var CompanyNameSelectors = []string{
+	".job-details-jobs-unified-top-card__company-name a",
+	".job-details-jobs-unified-top-card__company-name",
+	".jobs-unified-top-card__company-name",
+	".topcard__org-name-link",
+}
+
+// JobDescriptionSelectors locate the job description on a job detail page, in order of preference
+var JobDescriptionSelectors = []string{
+	".jobs-description__content",
+	"#job-details",
+	".description__text",
+}
+
+// SeeMoreDescriptionSelectors match the button that expands a truncated job description
+var SeeMoreDescriptionSelectors = []string{
+	"button.jobs-description__footer-button",
+	"button[aria-label*='see more description' i]",
+	"button.show-more-less-html__button--more",
+}
+
+// ParseJobDescription extracts the job description text from a job detail
+// page, with whitespace collapsed. A missing description yields "".
+func ParseJobDescription(html string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
+	}
+	return firstText(doc, JobDescriptionSelectors)
+}
+
+// EasyApplyButtonSelector matches the Easy Apply button on a job detail page
+const EasyApplyButtonSelector = `[aria-label*="Easy Apply to"]`
+
+// AppliedBadgeSelector matches the note LinkedIn shows on a job detail
+// page the user has already applied to, e.g. "Applied 3 days ago"
+const AppliedBadgeSelector = `.jobs-s-apply .artdeco-inline-feedback--success, .post-apply-timeline__entity`
+
+// IsAlreadyApplied reports whether a job detail page shows that the user
+// applied to the job on LinkedIn, by the applied badge or by a disabled
+// Easy Apply button
+func IsAlreadyApplied(html string) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+	if strings.Contains(strings.ToLower(doc.Find(AppliedBadgeSelector).Text()), "applied") {
+		return true
+	}
+	return doc.Find(EasyApplyButtonSelector).FilterFunction(func(_ int, button *goquery.Selection) bool {
+		disabled, _ := button.Attr("aria-disabled")
+		_, hasDisabled := button.Attr("disabled")
+		return hasDisabled || disabled == "true"
+	}).Length() > 0
+}
+
+// JobTopCardSelector matches the card at the top of a job detail page that
+// holds the job's primary apply button
+const JobTopCardSelector = `.jobs-apply-button--top-card, .job-details-jobs-unified-top-card__container--two-pane, .jobs-unified-top-card`
+
+// JobCard is a job listed on a search results page
+type JobCard struct {
+	ID       int
+	Title    string
+	External bool // The card lists how to apply and it isn't Easy Apply
+}
+
+// JobCardFooterSelector matches the metadata items under a job card, one of
+// which reads "Easy Apply" for Easy Apply jobs. Cards LinkedIn hasn't
+// rendered yet have no footer.
+const JobCardFooterSelector = `.job-card-container__footer-item, .job-card-container__apply-method`
+
+// ParseJobCards extracts the jobs listed on a search results page, in page
+// order. Links without a job ID are skipped.
+func ParseJobCards(html string) []JobCard {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil
+	}
+
+	var cards []JobCard
+	doc.Find("div[data-job-id] a.job-card-container__link").Each(func(_ int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		jobID, ok := ExtractJobID(href)
+		if !ok {
+			fmt.Printf("Failed to extract job ID from link: %s\n", href)
+			return
+		}
+		footer := link.Closest("div[data-job-id]").Find(JobCardFooterSelector)
+		cards = append(cards, JobCard{
+			ID:       jobID,
+			Title:    strings.Join(strings.Fields(link.Text()), " "),
+			External: footer.Length() > 0 && !strings.Contains(strings.ToLower(footer.Text()), "easy apply"),
+		})
+	})
+	return cards
+}
+
 func ExtractJobID(href string) (int, bool) {
 	parsedURL, err := url.Parse(href)
 	if err != nil {
@@ -13,7 +130,7 @@ func ExtractJobID(href string) (int, bool) {
 	}
 
 	segments := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
-	if len(segments) < 2 {
+	if len(segments) < 3 {
 		return 0, false
 	}
 
@@ -26,3 +143,388 @@ func ExtractJobID(href string) (int, bool) {
 
 	return jobID, true
 }
+
+// JobIDFromURL extracts the job ID from a LinkedIn job URL as copied from
+// the browser: a /jobs/view/ page (with or without a title slug) or a
+// search page with a currentJobId parameter
+func JobIDFromURL(rawURL string) (int, bool) {
+	if !IsLinkedInURL(rawURL) {
+		return 0, false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, false
+	}
+	if current := u.Query().Get("currentJobId"); current != "" {
+		jobID, err := strconv.Atoi(current)
+		return jobID, err == nil && jobID > 0
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "jobs" || segments[1] != "view" {
+		return 0, false
+	}
+	// Public links end the slug with the ID, e.g. engineer-at-acme-4012345678
+	id := segments[2]
+	if i := strings.LastIndex(id, "-"); i >= 0 {
+		id = id[i+1:]
+	}
+	jobID, err := strconv.Atoi(id)
+	return jobID, err == nil && jobID > 0
+}
+
+// MatchExcludedKeyword returns the first keyword contained in the job title,
+// compared case-insensitively. Blank keywords are ignored.
+func MatchExcludedKeyword(title string, keywords []string) (string, bool) {
+	t := strings.ToLower(title)
+	for _, kw := range keywords {
+		k := strings.ToLower(strings.TrimSpace(kw))
+		if k == "" {
+			continue
+		}
+		if strings.Contains(t, k) {
+			return kw, true
+		}
+	}
+	return "", false
+}
+
+// ParseJobDetails extracts the job title and company name from a job detail
+// page. Missing elements yield empty strings.
+func ParseJobDetails(html string) (title, company string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return "", ""
+	}
+	return firstText(doc, JobTitleSelectors), firstText(doc, CompanyNameSelectors)
+}
+
+// JobInsightSelector matches the highlights under a job's title on a job
+// detail page, one of which lists the salary when the poster gave one
+const JobInsightSelector = ".job-details-jobs-unified-top-card__job-insight"
+
+// SalaryRange is a listed salary converted to yearly amounts. A single
+// figure gives equal Min and Max.
+type SalaryRange struct {
+	Min int
+	Max int
+}
+
+// Hours and months in a working year, for converting hourly and monthly pay
+const (
+	workHoursPerYear = 2080
+	monthsPerYear    = 12
+)
+
+// salaryAmountPattern matches a currency amount like "$90K", "$120,000" or
+// "$45.50". A K or M suffix must end the word, so the M of "monthly" isn't
+// read as millions.
+var salaryAmountPattern = regexp.MustCompile(`[$£€]\s*(\d[\d,]*(?:\.\d+)?)\s*([kKmM])?\b`)
+
+// ParseSalary reads a salary like "$90K/yr - $120K/yr" or "$45/hr" as a
+// yearly range. Hourly and monthly pay is annualised; text without a pay
+// period is taken as yearly. It reports false when text has no amount.
+func ParseSalary(text string) (SalaryRange, bool) {
+	matches := salaryAmountPattern.FindAllStringSubmatch(text, 2)
+	if len(matches) == 0 {
+		return SalaryRange{}, false
+	}
+
+	amounts := make([]float64, len(matches))
+	for i, m := range matches {
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			return SalaryRange{}, false
+		}
+		amounts[i] = amount * salaryMultiplier(m[2])
+	}
+	// "$90 - $120K" shares the upper bound's suffix
+	if len(matches) == 2 && matches[0][2] == "" && matches[1][2] != "" && amounts[0] < 1000 {
+		amounts[0] *= salaryMultiplier(matches[1][2])
+	}
+
+	period := float64(1)
+	lower := strings.ToLower(text)
+	switch {
+	case containsAny(lower, "/hr", "/hour", "per hour", "an hour", "hourly"):
+		period = workHoursPerYear
+	case containsAny(lower, "/mo", "per month", "a month", "monthly"):
+		period = monthsPerYear
+	}
+
+	salary := SalaryRange{Min: int(math.Round(amounts[0] * period))}
+	salary.Max = salary.Min
+	if len(amounts) == 2 {
+		salary.Max = int(math.Round(amounts[1] * period))
+	}
+	if salary.Max < salary.Min {
+		salary.Min, salary.Max = salary.Max, salary.Min
+	}
+	return salary, true
+}
+
+// salaryMultiplier returns the factor a K or M suffix stands for
+func salaryMultiplier(suffix string) float64 {
+	switch strings.ToLower(suffix) {
+	case "k":
+		return 1_000
+	case "m":
+		return 1_000_000
+	}
+	return 1
+}
+
+// ParseJobSalary extracts the listed salary from a job detail page,
+// reporting false when the job doesn't list one
+func ParseJobSalary(html string) (SalaryRange, bool) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return SalaryRange{}, false
+	}
+	var salary SalaryRange
+	found := false
+	doc.Find(JobInsightSelector).EachWithBreak(func(_ int, insight *goquery.Selection) bool {
+		salary, found = ParseSalary(insight.Text())
+		return !found
+	})
+	return salary, found
+}
+
+// belowSalaryFloor reports whether a listed salary tops out under floor.
+// A floor of 0 accepts every salary.
+func belowSalaryFloor(salary SalaryRange, floor int) bool {
+	return floor > 0 && salary.Max < floor
+}
+
+// firstText returns the trimmed text of the first selector that matches
+func firstText(doc *goquery.Document, selectors []string) string {
+	for _, sel := range selectors {
+		if text := strings.Join(strings.Fields(doc.Find(sel).First().Text()), " "); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// JobListSelectors match the scrollable job results container across the
+// layouts LinkedIn has served, most common first
+var JobListSelectors = []string{
+	".scaffold-layout__list",
+	".jobs-search-results-list",
+	".jobs-search-two-pane__results",
+	"ul.scaffold-layout__list-container",
+}
+
+// LoginOutcome is what a LinkedIn page shows after submitting the login form
+type LoginOutcome int
+
+const (
+	LoginPending            LoginOutcome = iota // Still loading or unrecognised
+	LoginSucceeded                              // Signed in to the feed
+	LoginInvalidCredentials                     // Wrong email or password
+	LoginChallengeRequired                      // Security check or 2FA prompt
+)
+
+// LoggedInSelectors match parts of LinkedIn's signed-in chrome. Any one is
+// enough, so a single renamed element doesn't look like a failed login.
+var LoggedInSelectors = []string{
+	"#caret-small",
+	"#global-nav",
+	"img.global-nav__me-photo",
+	`a[href*="/messaging/"]`,
+	".feed-identity-module",
+}
+
+// IsLoggedInPage reports whether a page shows any sign of a signed-in
+// session: the feed URL or one of LoggedInSelectors
+func IsLoggedInPage(pageURL, html string) bool {
+	if u, err := url.Parse(pageURL); err == nil && strings.HasPrefix(u.Path, "/feed") {
+		return true
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+	for _, sel := range LoggedInSelectors {
+		if doc.Find(sel).Length() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyLoginPage inspects the page reached after submitting the login form
+func ClassifyLoginPage(pageURL, html string) LoginOutcome {
+	if IsLoggedInPage(pageURL, html) {
+		return LoginSucceeded
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return LoginPending
+	}
+	if firstText(doc, []string{"#error-for-password", "#error-for-username"}) != "" {
+		return LoginInvalidCredentials
+	}
+	// Failed logins also land under /checkpoint/, so only challenges count here
+	if strings.Contains(pageURL, "/checkpoint/challenge") {
+		return LoginChallengeRequired
+	}
+	return LoginPending
+}
+
+// DefaultChallengeSelectors match the CAPTCHA and verification pages
+// LinkedIn shows mid-session. Override them with Config.ChallengeSelectors.
+var DefaultChallengeSelectors = []string{
+	"#captcha-internal",
+	`iframe[src*="captcha"]`,
+	`iframe[src*="arkoselabs"]`,
+	`form[action*="checkpoint/challenge"]`,
+}
+
+// IsChallengePage reports whether the page is a LinkedIn challenge, either
+// by its URL or by matching any of the given selectors
+func IsChallengePage(pageURL, html string, selectors []string) bool {
+	if strings.Contains(pageURL, "/checkpoint/challenge") {
+		return true
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+	for _, sel := range selectors {
+		if doc.Find(sel).Length() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultHiringTeamSelectors match the "Meet the hiring team" section a
+// job detail page shows when a recruiter or hiring manager is attached to
+// the posting. Override them with Config.HiringTeamSelectors.
+var DefaultHiringTeamSelectors = []string{
+	".job-details-people-who-can-help__section--two-pane",
+	".job-details-people-who-can-help__section",
+	".hirer-card__hirer-information",
+}
+
+// HasHiringTeam reports whether a job detail page lists a hiring team,
+// matching any of the given selectors
+func HasHiringTeam(html string, selectors []string) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+	for _, sel := range selectors {
+		if doc.Find(sel).Length() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadChallengeSelectors reads a JSON array of challenge selectors from
+// path. A missing file returns nil so callers fall back to the defaults.
+func LoadChallengeSelectors(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read challenge selectors: %w", err)
+	}
+
+	var selectors []string
+	if err := json.Unmarshal(data, &selectors); err != nil {
+		return nil, fmt.Errorf("failed to parse challenge selectors: %w", err)
+	}
+	return selectors, nil
+}
+
+// searchSortParams maps profile sort orders to LinkedIn's sortBy values
+var searchSortParams = map[string]string{
+	store.SearchSortDate:      "DD",
+	store.SearchSortRelevance: "R",
+}
+
+// postedWithinParams maps profile freshness windows to LinkedIn's f_TPR values
+var postedWithinParams = map[string]string{
+	store.PostedWithinDay:   "r86400",
+	store.PostedWithinWeek:  "r604800",
+	store.PostedWithinMonth: "r2592000",
+}
+
+// experienceLevelParams maps profile experience levels to LinkedIn's f_E codes
+var experienceLevelParams = map[string]string{
+	store.ExperienceInternship: "1",
+	store.ExperienceEntry:      "2",
+	store.ExperienceAssociate:  "3",
+	store.ExperienceMidSenior:  "4",
+	store.ExperienceDirector:   "5",
+	store.ExperienceExecutive:  "6",
+}
+
+// jobTypeParams maps profile job types to LinkedIn's f_JT codes
+var jobTypeParams = map[string]string{
+	store.JobTypeFullTime:   "F",
+	store.JobTypePartTime:   "P",
+	store.JobTypeContract:   "C",
+	store.JobTypeTemporary:  "T",
+	store.JobTypeInternship: "I",
+	store.JobTypeVolunteer:  "V",
+	store.JobTypeOther:      "O",
+}
+
+// joinFilterCodes maps values through codes and joins the known ones with
+// commas, sorted and without duplicates. Unknown values are ignored.
+func joinFilterCodes(values []string, codes map[string]string) string {
+	seen := map[string]bool{}
+	var joined []string
+	for _, v := range values {
+		code, ok := codes[v]
+		if !ok || seen[code] {
+			continue
+		}
+		seen[code] = true
+		joined = append(joined, code)
+	}
+	sort.Strings(joined)
+	return strings.Join(joined, ",")
+}
+
+// BuildJobSearchURL returns the Easy Apply job search URL for one results
+// page. Unknown or empty sort orders sort by date; an empty postedWithin
+// applies no freshness filter. Experience levels and job types become
+// comma-joined f_E and f_JT filters, skipping values LinkedIn doesn't know.
+func BuildJobSearchURL(position, location string, start int, sort, postedWithin string, experienceLevels, jobTypes []string) string {
+	sortBy, ok := searchSortParams[sort]
+	if !ok {
+		sortBy = searchSortParams[store.SearchSortDate]
+	}
+
+	u := fmt.Sprintf("https://www.linkedin.com/jobs/search/?f_LF=f_AL&keywords=%s&location=%s&sortBy=%s",
+		url.QueryEscape(position), url.QueryEscape(location), sortBy)
+	if tpr, ok := postedWithinParams[postedWithin]; ok {
+		u += "&f_TPR=" + tpr
+	}
+	if codes := joinFilterCodes(experienceLevels, experienceLevelParams); codes != "" {
+		u += "&f_E=" + codes
+	}
+	if codes := joinFilterCodes(jobTypes, jobTypeParams); codes != "" {
+		u += "&f_JT=" + codes
+	}
+	return u + fmt.Sprintf("&start=%d", start)
+}
+
+// IsLinkedInURL reports whether rawURL points at linkedin.com or one of its
+// subdomains. Unparseable URLs are treated as external.
+func IsLinkedInURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com")
+}
@@ -0,0 +1,430 @@
+package browser
+
+import (
+	"fmt"
+	"foxyapply/internal/store"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestMatchExcludedKeyword(t *testing.T) {
+	keywords := []string{"Senior", " clearance ", "", "unpaid"}
+
+	tests := []struct {
+		title   string
+		want    string
+		matched bool
+	}{
+		{title: "Senior Software Engineer", want: "Senior", matched: true},
+		{title: "Backend Engineer (Secret CLEARANCE required)", want: " clearance ", matched: true},
+		{title: "Unpaid Internship", want: "unpaid", matched: true},
+		{title: "Software Engineer II", matched: false},
+		{title: "", matched: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MatchExcludedKeyword(tt.title, keywords)
+		if ok != tt.matched || got != tt.want {
+			t.Errorf("MatchExcludedKeyword(%q) = (%q, %v), want (%q, %v)", tt.title, got, ok, tt.want, tt.matched)
+		}
+	}
+
+	if _, ok := MatchExcludedKeyword("Senior Engineer", nil); ok {
+		t.Error("expected no match with no keywords")
+	}
+}
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		text string
+		want SalaryRange
+		ok   bool
+	}{
+		{"$90K/yr - $120K/yr", SalaryRange{90000, 120000}, true},
+		{"$90k - $120k", SalaryRange{90000, 120000}, true},
+		{"$90 - $120K/yr", SalaryRange{90000, 120000}, true},
+		{"$120,000/yr - $150,000/yr", SalaryRange{120000, 150000}, true},
+		{"$150,000", SalaryRange{150000, 150000}, true},
+		{"$1.2M/yr", SalaryRange{1200000, 1200000}, true},
+		{"$92.5K/yr - $110K/yr · Full-time", SalaryRange{92500, 110000}, true},
+		{"$40/hr - $55/hr", SalaryRange{83200, 114400}, true},
+		{"$45.50 per hour", SalaryRange{94640, 94640}, true},
+		{"$8,000/mo - $10,000/mo", SalaryRange{96000, 120000}, true},
+		{"$5,000 monthly", SalaryRange{60000, 60000}, true},
+		{"$120,000 Medical, Dental", SalaryRange{120000, 120000}, true},
+		{"£60K/yr", SalaryRange{60000, 60000}, true},
+		{"$120K/yr - $90K/yr", SalaryRange{90000, 120000}, true},
+		{"Full-time · Mid-Senior level", SalaryRange{}, false},
+		{"", SalaryRange{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseSalary(tt.text)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseSalary(%q) = %+v, %v; want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseJobSalary(t *testing.T) {
+	html := `<ul>
+		<li class="job-details-jobs-unified-top-card__job-insight">Remote · Full-time</li>
+		<li class="job-details-jobs-unified-top-card__job-insight">$90K/yr - $120K/yr · Mid-Senior level</li>
+	</ul>`
+	if got, ok := ParseJobSalary(html); !ok || got != (SalaryRange{90000, 120000}) {
+		t.Errorf("expected $90K-$120K, got %+v, %v", got, ok)
+	}
+
+	html = `<li class="job-details-jobs-unified-top-card__job-insight">Remote · Full-time</li>`
+	if got, ok := ParseJobSalary(html); ok {
+		t.Errorf("expected no salary, got %+v", got)
+	}
+}
+
+func TestBelowSalaryFloor(t *testing.T) {
+	salary := SalaryRange{Min: 90000, Max: 120000}
+	for floor, want := range map[int]bool{0: false, 100000: false, 120000: false, 120001: true, 150000: true} {
+		if got := belowSalaryFloor(salary, floor); got != want {
+			t.Errorf("belowSalaryFloor(%+v, %d) = %v, want %v", salary, floor, got, want)
+		}
+	}
+}
+
+func TestParseJobDetails(t *testing.T) {
+	html := `<html><body>
+		<div class="job-details-jobs-unified-top-card__job-title">
+			<h1>  Senior   Go Engineer </h1>
+		</div>
+		<div class="job-details-jobs-unified-top-card__company-name">
+			<a href="/company/acme">Acme Corp</a>
+		</div>
+	</body></html>`
+
+	title, company := ParseJobDetails(html)
+	if title != "Senior Go Engineer" {
+		t.Errorf("expected title 'Senior Go Engineer', got %q", title)
+	}
+	if company != "Acme Corp" {
+		t.Errorf("expected company 'Acme Corp', got %q", company)
+	}
+
+	// Older layouts use different containers
+	title, company = ParseJobDetails(`<h2 class="top-card-layout__title">Engineer</h2>
+		<a class="topcard__org-name-link">Initech</a>`)
+	if title != "Engineer" || company != "Initech" {
+		t.Errorf("expected fallback selectors to match, got (%q, %q)", title, company)
+	}
+
+	title, company = ParseJobDetails(`<html><body><p>Nothing here</p></body></html>`)
+	if title != "" || company != "" {
+		t.Errorf("expected empty strings for missing elements, got (%q, %q)", title, company)
+	}
+}
+
+func TestParseJobDescription(t *testing.T) {
+	html := `<html><body>
+		<div class="jobs-description__content">
+			<h2>About the job</h2>
+			<p>Build   reliable services.</p>
+		</div>
+	</body></html>`
+	if got := ParseJobDescription(html); got != "About the job Build reliable services." {
+		t.Errorf("unexpected description %q", got)
+	}
+
+	if got := ParseJobDescription(`<div id="job-details"><span>Write Go</span></div>`); got != "Write Go" {
+		t.Errorf("expected fallback selector to match, got %q", got)
+	}
+
+	if got := ParseJobDescription(`<p>Nothing here</p>`); got != "" {
+		t.Errorf("expected empty description, got %q", got)
+	}
+}
+
+func TestJobListSelectors(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []int
+	}{
+		{"job-search-results.html", []int{3901, 3902}},
+		{"job-search-results-legacy.html", []int{2801, 2802}},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+		if err != nil {
+			t.Fatalf("failed to read fixture %s: %v", tt.fixture, err)
+		}
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatalf("%s: failed to parse HTML: %v", tt.fixture, err)
+		}
+
+		var list *goquery.Selection
+		for _, sel := range JobListSelectors {
+			if found := doc.Find(sel); found.Length() > 0 {
+				list = found.First()
+				break
+			}
+		}
+		if list == nil {
+			t.Errorf("%s: no job list selector matched", tt.fixture)
+			continue
+		}
+
+		// The container scrolled by LoadPage holds the search results,
+		// not the similar jobs in the detail pane
+		html, err := goquery.OuterHtml(list)
+		if err != nil {
+			t.Fatalf("%s: failed to render job list: %v", tt.fixture, err)
+		}
+		var ids []int
+		for _, card := range ParseJobCards(html) {
+			ids = append(ids, card.ID)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: expected the job list to hold jobs %v, got %v", tt.fixture, tt.want, ids)
+		}
+	}
+}
+
+func TestClassifyLoginPage(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		html string
+		want LoginOutcome
+	}{
+		{"feed", "https://www.linkedin.com/feed/", "<html></html>", LoginSucceeded},
+		{"nav caret", "https://www.linkedin.com/", `<span id="caret-small"></span>`, LoginSucceeded},
+		{"wrong password", "https://www.linkedin.com/checkpoint/lg/login-submit",
+			`<div id="error-for-password">Wrong email or password. Try again.</div>`, LoginInvalidCredentials},
+		{"unknown email", "https://www.linkedin.com/uas/login-submit",
+			`<div id="error-for-username">Couldn't find a LinkedIn account</div><div id="error-for-password"></div>`, LoginInvalidCredentials},
+		{"challenge", "https://www.linkedin.com/checkpoint/challenge/abc", "<html></html>", LoginChallengeRequired},
+		{"still loading", "https://www.linkedin.com/uas/login-submit", `<div id="error-for-password"> </div>`, LoginPending},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyLoginPage(tt.url, tt.html); got != tt.want {
+			t.Errorf("%s: ClassifyLoginPage() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsLoggedInPage(t *testing.T) {
+	readFixture := func(name string) string {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("failed to read fixture %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		html string
+		want bool
+	}{
+		{"feed fixture", "https://www.linkedin.com/jobs/", readFixture("feed-logged-in.html"), true},
+		{"login fixture", "https://www.linkedin.com/login", readFixture("login-page.html"), false},
+		{"feed url", "https://www.linkedin.com/feed/?trk=homepage", "<html></html>", true},
+		{"redirect to feed after login", "https://www.linkedin.com/login?session_redirect=%2Ffeed%2F", "<html></html>", false},
+		{"nav caret only", "https://www.linkedin.com/", `<span id="caret-small"></span>`, true},
+		{"messaging icon only", "https://www.linkedin.com/jobs/", `<a href="https://www.linkedin.com/messaging/">Messaging</a>`, true},
+		{"profile photo only", "https://www.linkedin.com/jobs/", `<img class="global-nav__me-photo" src="me.jpg">`, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsLoggedInPage(tt.url, tt.html); got != tt.want {
+			t.Errorf("%s: IsLoggedInPage() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsChallengePage(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		html string
+		want bool
+	}{
+		{"challenge url", "https://www.linkedin.com/checkpoint/challenge/xyz", "<html></html>", true},
+		{"captcha element", "https://www.linkedin.com/jobs/view/1", `<div id="captcha-internal"></div>`, true},
+		{"captcha iframe", "https://www.linkedin.com/jobs/view/1", `<iframe src="https://client-api.arkoselabs.com/fc"></iframe>`, true},
+		{"job page", "https://www.linkedin.com/jobs/view/1", `<h1 class="top-card-layout__title">Engineer</h1>`, false},
+	}
+	for _, tt := range tests {
+		if got := IsChallengePage(tt.url, tt.html, DefaultChallengeSelectors); got != tt.want {
+			t.Errorf("%s: IsChallengePage() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if !IsChallengePage("https://www.linkedin.com/jobs/view/1", `<div class="verify-me"></div>`, []string{".verify-me"}) {
+		t.Error("expected custom selector to match")
+	}
+}
+
+func TestHasHiringTeam(t *testing.T) {
+	hiringTeam := `<div class="job-details-people-who-can-help__section--two-pane">
+		<h2>Meet the hiring team</h2>
+		<div class="hirer-card__hirer-information"><strong>Jane Doe</strong></div>
+	</div>`
+	if !HasHiringTeam(hiringTeam, DefaultHiringTeamSelectors) {
+		t.Error("expected the hiring team section to be found")
+	}
+	if HasHiringTeam(`<h1 class="top-card-layout__title">Engineer</h1>`, DefaultHiringTeamSelectors) {
+		t.Error("expected no hiring team on a plain job page")
+	}
+	if !HasHiringTeam(`<div class="recruiter"></div>`, []string{".recruiter"}) {
+		t.Error("expected custom selector to match")
+	}
+}
+
+func TestIsAlreadyApplied(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{"applied badge", `<div class="jobs-s-apply"><div class="artdeco-inline-feedback artdeco-inline-feedback--success">
+			<span class="artdeco-inline-feedback__message">Applied 3 days ago</span></div></div>`, true},
+		{"application timeline", `<div class="post-apply-timeline__entity">Application submitted. Applied on LinkedIn</div>`, true},
+		{"disabled Easy Apply", `<button aria-label="Easy Apply to Go Engineer at Acme" disabled>Easy Apply</button>`, true},
+		{"aria-disabled Easy Apply", `<button aria-label="Easy Apply to Go Engineer at Acme" aria-disabled="true">Easy Apply</button>`, true},
+		{"open job", `<button aria-label="Easy Apply to Go Engineer at Acme">Easy Apply</button>`, false},
+		{"other success note", `<div class="jobs-s-apply"><div class="artdeco-inline-feedback--success">Job saved</div></div>`, false},
+	}
+	for _, tt := range tests {
+		if got := IsAlreadyApplied(tt.html); got != tt.want {
+			t.Errorf("%s: IsAlreadyApplied() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadChallengeSelectors(t *testing.T) {
+	dir := t.TempDir()
+
+	selectors, err := LoadChallengeSelectors(filepath.Join(dir, "missing.json"))
+	if err != nil || selectors != nil {
+		t.Errorf("expected nil selectors for missing file, got %v, %v", selectors, err)
+	}
+
+	path := filepath.Join(dir, "selectors.json")
+	if err := os.WriteFile(path, []byte(`["#verify", ".captcha"]`), 0644); err != nil {
+		t.Fatalf("failed to write selectors: %v", err)
+	}
+	selectors, err = LoadChallengeSelectors(path)
+	if err != nil {
+		t.Fatalf("failed to load selectors: %v", err)
+	}
+	if len(selectors) != 2 || selectors[0] != "#verify" {
+		t.Errorf("expected loaded selectors, got %v", selectors)
+	}
+
+	if err := os.WriteFile(path, []byte(`not json`), 0644); err != nil {
+		t.Fatalf("failed to write selectors: %v", err)
+	}
+	if _, err := LoadChallengeSelectors(path); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestBuildJobSearchURL(t *testing.T) {
+	const base = "https://www.linkedin.com/jobs/search/?f_LF=f_AL&keywords=Go+Developer&location=New+York"
+
+	tests := []struct {
+		sort         string
+		postedWithin string
+		want         string
+	}{
+		{"", "", base + "&sortBy=DD&start=25"},
+		{store.SearchSortDate, "", base + "&sortBy=DD&start=25"},
+		{store.SearchSortRelevance, "", base + "&sortBy=R&start=25"},
+		{store.SearchSortDate, store.PostedWithinDay, base + "&sortBy=DD&f_TPR=r86400&start=25"},
+		{store.SearchSortDate, store.PostedWithinWeek, base + "&sortBy=DD&f_TPR=r604800&start=25"},
+		{store.SearchSortRelevance, store.PostedWithinMonth, base + "&sortBy=R&f_TPR=r2592000&start=25"},
+		{"bogus", "bogus", base + "&sortBy=DD&start=25"},
+	}
+
+	for _, tt := range tests {
+		got := BuildJobSearchURL("Go Developer", "New York", 25, tt.sort, tt.postedWithin, nil, nil)
+		if got != tt.want {
+			t.Errorf("BuildJobSearchURL(%q, %q) =\n  %s\nwant\n  %s", tt.sort, tt.postedWithin, got, tt.want)
+		}
+	}
+}
+
+func TestBuildJobSearchURLFilters(t *testing.T) {
+	const base = "https://www.linkedin.com/jobs/search/?f_LF=f_AL&keywords=Go+Developer&location=New+York&sortBy=DD"
+
+	tests := []struct {
+		experience []string
+		jobTypes   []string
+		want       string
+	}{
+		{nil, nil, base + "&start=0"},
+		{[]string{store.ExperienceEntry}, nil, base + "&f_E=2&start=0"},
+		{[]string{store.ExperienceAssociate, store.ExperienceEntry}, nil, base + "&f_E=2,3&start=0"},
+		{nil, []string{store.JobTypeFullTime, store.JobTypeContract}, base + "&f_JT=C,F&start=0"},
+		{[]string{store.ExperienceMidSenior}, []string{store.JobTypeFullTime}, base + "&f_E=4&f_JT=F&start=0"},
+		{[]string{"bogus", store.ExperienceEntry, store.ExperienceEntry}, []string{"bogus"}, base + "&f_E=2&start=0"},
+	}
+
+	for _, tt := range tests {
+		got := BuildJobSearchURL("Go Developer", "New York", 0, "", "", tt.experience, tt.jobTypes)
+		if got != tt.want {
+			t.Errorf("BuildJobSearchURL(%v, %v) =\n  %s\nwant\n  %s", tt.experience, tt.jobTypes, got, tt.want)
+		}
+	}
+}
+
+func TestIsLinkedInURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.linkedin.com/jobs/view/123", true},
+		{"https://linkedin.com/", true},
+		{"https://WWW.LinkedIn.com/jobs", true},
+		{"https://boards.greenhouse.io/acme/jobs/1", false},
+		{"https://acme.wd5.myworkdayjobs.com/en-US/careers", false},
+		{"https://linkedin.com.evil.example/apply", false},
+		{"https://notlinkedin.com/", false},
+		{"about:blank", false},
+		{"://bad", false},
+	}
+	for _, tt := range tests {
+		if got := IsLinkedInURL(tt.url); got != tt.want {
+			t.Errorf("IsLinkedInURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestJobIDFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want int
+		ok   bool
+	}{
+		{url: "https://www.linkedin.com/jobs/view/4012345678/", want: 4012345678, ok: true},
+		{url: "https://www.linkedin.com/jobs/view/4012345678/?refId=abc&trackingId=def", want: 4012345678, ok: true},
+		{url: "https://www.linkedin.com/jobs/view/senior-engineer-at-acme-4012345678", want: 4012345678, ok: true},
+		{url: "https://www.linkedin.com/jobs/search/?currentJobId=4012345678&keywords=go", want: 4012345678, ok: true},
+		{url: "https://linkedin.com/jobs/collections/recommended/?currentJobId=42", want: 42, ok: true},
+		{url: "https://www.linkedin.com/jobs/search/?keywords=go", ok: false},
+		{url: "https://www.linkedin.com/in/someone", ok: false},
+		{url: "https://example.com/jobs/view/4012345678", ok: false},
+		{url: "not a url", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := JobIDFromURL(tt.url)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("JobIDFromURL(%q) = (%d, %v), want (%d, %v)", tt.url, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package browser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DefaultLocale is the browser locale used when Config.Locale is unset.
+// Selectors such as EasyApplyButtonSelector match LinkedIn's English
+// labels, so running in another locale needs a matching set of selectors.
+const DefaultLocale = "en-US"
+
+// linkedInURLPattern matches the requests whose Accept-Language header is
+// overridden
+const linkedInURLPattern = "*linkedin.com*"
+
+// locale returns the configured locale, or DefaultLocale
+func (bm *BrowserManager) locale() string {
+	if locale := strings.TrimSpace(bm.cfg.Locale); locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// acceptLanguage returns an Accept-Language value preferring locale and
+// then its base language, e.g. "en-US,en;q=0.9"
+func acceptLanguage(locale string) string {
+	if base, _, ok := strings.Cut(locale, "-"); ok && base != "" {
+		return locale + "," + base + ";q=0.9"
+	}
+	return locale
+}
+
+// withAcceptLanguage returns headers as Fetch header entries, with any
+// Accept-Language replaced by value. Entries are sorted by name so the
+// result is stable.
+func withAcceptLanguage(headers proto.NetworkHeaders, value string) []*proto.FetchHeaderEntry {
+	entries := []*proto.FetchHeaderEntry{{Name: "Accept-Language", Value: value}}
+	for name, v := range headers {
+		if strings.EqualFold(name, "Accept-Language") {
+			continue
+		}
+		entries = append(entries, &proto.FetchHeaderEntry{Name: name, Value: v.String()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// overrideAcceptLanguage hijacks page's LinkedIn requests to send the
+// configured locale's Accept-Language, whatever languages the Chrome
+// profile prefers. Failing to set it up is logged, not fatal.
+func (bm *BrowserManager) overrideAcceptLanguage(page *rod.Page) {
+	value := acceptLanguage(bm.locale())
+	router := page.HijackRequests()
+	err := router.Add(linkedInURLPattern, "", func(h *rod.Hijack) {
+		h.ContinueRequest(&proto.FetchContinueRequest{
+			Headers: withAcceptLanguage(h.Request.Headers(), value),
+		})
+	})
+	if err != nil {
+		fmt.Printf("⚠️ Could not override Accept-Language: %v\n", err)
+		return
+	}
+	go router.Run()
+}
@@ -0,0 +1,103 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// PageController is the narrow set of page operations the apply loop
+// depends on. rodPage implements it over a real browser tab; tests swap in
+// a fake to exercise the loop without Chrome.
+type PageController interface {
+	// Navigate loads url and waits for the page to finish loading
+	Navigate(url string) error
+	// URL returns the address the page is currently showing
+	URL() (string, error)
+	// HTML returns the page's current markup
+	HTML() (string, error)
+	// Click clicks the first element matching the CSS selector and reports
+	// whether it did. A missing or hidden element isn't an error.
+	Click(selector string) (bool, error)
+	// ClickEasyApply clicks the job's primary Easy Apply button, chosen by
+	// selectEasyApplyButton, and reports whether there was one
+	ClickEasyApply() (bool, error)
+	// Input types text into the first element matching the CSS selector
+	Input(selector, text string) error
+	// Scroll scrolls the page vertically by deltaY pixels
+	Scroll(deltaY float64) error
+}
+
+// rodPage is the production PageController, backed by a rod page and
+// bounded by the manager's operation timeout
+type rodPage struct {
+	bm   *BrowserManager
+	page *rod.Page
+}
+
+// controller wraps page in the manager's PageController
+func (bm *BrowserManager) controller(page *rod.Page) *rodPage {
+	return &rodPage{bm: bm, page: page}
+}
+
+func (p *rodPage) Navigate(url string) error {
+	return p.bm.navigateWithRetry(p.page, url)
+}
+
+func (p *rodPage) URL() (string, error) {
+	info, err := p.page.Info()
+	if err != nil {
+		return "", fmt.Errorf("failed to read page info: %w", err)
+	}
+	return info.URL, nil
+}
+
+func (p *rodPage) HTML() (string, error) {
+	return p.bm.timed(p.page).HTML()
+}
+
+func (p *rodPage) Click(selector string) (bool, error) {
+	has, el, err := p.bm.timed(p.page).Has(selector)
+	if err != nil || !has {
+		return false, err
+	}
+	if visible, err := el.Visible(); err != nil || !visible {
+		return false, err
+	}
+	if err := el.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return false, fmt.Errorf("failed to click %s: %w", selector, err)
+	}
+	return true, nil
+}
+
+func (p *rodPage) ClickEasyApply() (bool, error) {
+	els, err := p.bm.timed(p.page).Elements(EasyApplyButtonSelector)
+	if err != nil {
+		return false, fmt.Errorf("failed to find Easy Apply buttons: %w", err)
+	}
+	button, err := selectEasyApplyButton(easyApplyCandidates(els))
+	if errors.Is(err, ErrEasyApplyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := button.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return false, fmt.Errorf("failed to click Easy Apply: %w", err)
+	}
+	return true, nil
+}
+
+func (p *rodPage) Input(selector, text string) error {
+	el, err := p.bm.timed(p.page).Element(selector)
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", selector, err)
+	}
+	return typeText(el, text, p.bm.cfg.Timing)
+}
+
+func (p *rodPage) Scroll(deltaY float64) error {
+	return p.page.Mouse.Scroll(0, deltaY, 1)
+}
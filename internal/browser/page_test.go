@@ -0,0 +1,466 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"foxyapply/internal/store"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// fakePage is a PageController serving canned HTML per URL. Clicking a
+// selector listed in redirects moves the page to the given URL. Easy Apply
+// is chosen from easyApply when set, and otherwise clicked like any other
+// selector.
+type fakePage struct {
+	pages     map[string]string // URL -> HTML
+	clickable map[string]bool   // Selectors that match a visible element
+	redirects map[string]string // Selector -> URL it navigates to
+	url       string
+	clicks    []string
+
+	easyApply []easyApplyCandidate // Elements labelled for Easy Apply, in page order
+	clicked   *rod.Element         // The Easy Apply button clicked from easyApply
+}
+
+func (f *fakePage) Navigate(url string) error {
+	if _, ok := f.pages[url]; !ok {
+		return fmt.Errorf("no page at %s", url)
+	}
+	f.url = url
+	return nil
+}
+
+func (f *fakePage) URL() (string, error) { return f.url, nil }
+
+func (f *fakePage) HTML() (string, error) { return f.pages[f.url], nil }
+
+func (f *fakePage) Click(selector string) (bool, error) {
+	if !f.clickable[selector] {
+		return false, nil
+	}
+	f.clicks = append(f.clicks, selector)
+	if to, ok := f.redirects[selector]; ok {
+		f.url = to
+	}
+	return true, nil
+}
+
+func (f *fakePage) ClickEasyApply() (bool, error) {
+	if f.easyApply == nil {
+		return f.Click(EasyApplyButtonSelector)
+	}
+	button, err := selectEasyApplyButton(f.easyApply)
+	if errors.Is(err, ErrEasyApplyNotFound) {
+		return false, nil
+	}
+	f.clicked = button
+	f.clicks = append(f.clicks, EasyApplyButtonSelector)
+	return true, err
+}
+
+func (f *fakePage) Input(selector, text string) error { return nil }
+
+func (f *fakePage) Scroll(deltaY float64) error { return nil }
+
+// fakeRecorder keeps recorded applications in memory
+type fakeRecorder struct {
+	apps []store.Application
+}
+
+func (r *fakeRecorder) RecordApplication(app store.Application) (*store.Application, error) {
+	r.apps = append(r.apps, app)
+	return &app, nil
+}
+
+func jobURL(id int) string {
+	return fmt.Sprintf("https://www.linkedin.com/jobs/view/%d", id)
+}
+
+func TestCollectJobIDsDedupsAndExcludes(t *testing.T) {
+	results := `<div data-job-id="1"><a class="job-card-container__link" href="/jobs/view/101/">Go Engineer</a></div>
+		<div data-job-id="2"><a class="job-card-container__link" href="/jobs/view/102/">Senior Manager</a></div>
+		<div data-job-id="3"><a class="job-card-container__link" href="/jobs/view/101/">Go Engineer</a></div>
+		<div data-job-id="4"><a class="job-card-container__link" href="/jobs/view/">Broken link</a></div>`
+	page := &fakePage{pages: map[string]string{"search": results}, url: "search"}
+
+	seen := map[int]bool{}
+	var session ApplySession
+	skipped := map[int]string{}
+	ids, listed, err := collectJobIDs(page, seen, []string{"manager"}, &session, func(card JobCard, reason string) {
+		skipped[card.ID] = reason
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(ids) != "[101]" || listed != 3 {
+		t.Errorf("expected [101] from 3 listed cards, got %v from %d", ids, listed)
+	}
+	if session.Found != 2 || session.Excluded != 1 {
+		t.Errorf("expected 2 found and 1 excluded, got %+v", session)
+	}
+	if len(skipped) != 1 || skipped[102] != store.SkipReasonTitleExcluded {
+		t.Errorf("expected job 102 to be reported title-excluded, got %v", skipped)
+	}
+
+	// The same results page again yields nothing new
+	ids, _, err = collectJobIDs(page, seen, nil, &session, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 0 || session.Found != 2 {
+		t.Errorf("expected seen jobs to be skipped, got %v with %+v", ids, session)
+	}
+}
+
+func TestCollectJobIDsSkipsExternalJobs(t *testing.T) {
+	results := `<div data-job-id="1"><a class="job-card-container__link" href="/jobs/view/101/">Go Engineer</a>
+			<ul><li class="job-card-container__footer-item">Promoted</li><li class="job-card-container__footer-item">Easy Apply</li></ul></div>
+		<div data-job-id="2"><a class="job-card-container__link" href="/jobs/view/102/">Rust Engineer</a>
+			<ul><li class="job-card-container__footer-item">Promoted</li></ul></div>
+		<div data-job-id="3"><a class="job-card-container__link" href="/jobs/view/103/">Platform Engineer</a></div>
+		<div data-job-id="4"><a class="job-card-container__link" href="/jobs/view/104/">Data Engineer</a>
+			<ul><li class="job-card-container__footer-item">Viewed</li></ul></div>`
+	page := &fakePage{pages: map[string]string{"search": results}, url: "search"}
+
+	// Job 104 was skipped as external on an earlier run
+	seen := map[int]bool{104: true}
+	var session ApplySession
+	var external []JobCard
+	ids, listed, err := collectJobIDs(page, seen, nil, &session, func(card JobCard, reason string) {
+		if reason == store.SkipReasonExternal {
+			external = append(external, card)
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A card without a footer may not be rendered yet, so it's still visited
+	if fmt.Sprint(ids) != "[101 103]" || listed != 4 {
+		t.Errorf("expected [101 103] from 4 listed cards, got %v from %d", ids, listed)
+	}
+	if len(external) != 1 || external[0].ID != 102 || external[0].Title != "Rust Engineer" {
+		t.Errorf("expected job 102 to be reported external, got %+v", external)
+	}
+	if session.Found != 3 || session.Skipped != 1 {
+		t.Errorf("expected 3 found and 1 skipped, got %+v", session)
+	}
+}
+
+func TestApplyToJobOutcomes(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	detail := `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>
+		<div class="job-details-jobs-unified-top-card__company-name">Acme</div>
+		<div class="jobs-description__content">Write Go.</div>`
+	profile := &store.LinkedInProfile{ID: 7}
+
+	tests := []struct {
+		name      string
+		clickable bool
+		redirect  string
+		submitted bool
+		fillErr   error
+		want      string
+	}{
+		{"applied", true, "", true, nil, store.ApplicationStatusApplied},
+		{"no easy apply", false, "", false, nil, store.ApplicationStatusSkipped},
+		{"external", true, "https://careers.example.com/apply", false, nil, store.ApplicationStatusExternalRedirect},
+		{"not submitted", true, "", false, nil, store.ApplicationStatusFailed},
+		{"fill error", true, "", false, errors.New("stuck"), store.ApplicationStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond})
+			bm.SetRecorder(recorder)
+			page := &fakePage{
+				pages:     map[string]string{jobURL(1): detail},
+				clickable: map[string]bool{EasyApplyButtonSelector: tt.clickable},
+				redirects: map[string]string{},
+			}
+			if tt.redirect != "" {
+				page.redirects[EasyApplyButtonSelector] = tt.redirect
+			}
+
+			filled := false
+			status, err := bm.applyToJob(page, profile, 1, func(string) (bool, error) {
+				filled = true
+				return tt.submitted, tt.fillErr
+			})
+			if status != tt.want {
+				t.Errorf("expected status %q, got %q", tt.want, status)
+			}
+			if !errors.Is(err, tt.fillErr) {
+				t.Errorf("expected error %v, got %v", tt.fillErr, err)
+			}
+			if wantFill := tt.clickable && tt.redirect == ""; filled != wantFill {
+				t.Errorf("expected form filled = %v, got %v", wantFill, filled)
+			}
+
+			if len(recorder.apps) != 1 {
+				t.Fatalf("expected 1 recorded application, got %d", len(recorder.apps))
+			}
+			app := recorder.apps[0]
+			if app.Status != tt.want || app.Title != "Go Engineer" || app.Company != "Acme" || app.Description != "Write Go." {
+				t.Errorf("unexpected recorded application %+v", app)
+			}
+		})
+	}
+}
+
+func TestApplyToJobSkipsBelowSalaryFloor(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	detail := `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>
+		<li class="job-details-jobs-unified-top-card__job-insight">$90K/yr - $120K/yr</li>`
+	unlisted := `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>`
+
+	tests := []struct {
+		name  string
+		html  string
+		floor int
+		skip  bool
+	}{
+		{"below floor", detail, 150000, true},
+		{"within range", detail, 100000, false},
+		{"no floor", detail, 0, false},
+		{"no salary listed", unlisted, 150000, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond})
+			bm.SetRecorder(recorder)
+			page := &fakePage{
+				pages:     map[string]string{jobURL(1): tt.html},
+				clickable: map[string]bool{EasyApplyButtonSelector: true},
+			}
+
+			status, err := bm.applyToJob(page, &store.LinkedInProfile{DesiredSalary: tt.floor}, 1, func(string) (bool, error) {
+				return true, nil
+			})
+			if err != nil {
+				t.Fatalf("applyToJob failed: %v", err)
+			}
+			if tt.skip {
+				if status != store.ApplicationStatusSkipped || len(page.clicks) != 0 {
+					t.Errorf("expected a skip without opening Easy Apply, got %q after clicks %v", status, page.clicks)
+				}
+				if len(recorder.apps) != 1 || recorder.apps[0].Reason != store.SkipReasonBelowSalaryFloor {
+					t.Errorf("expected the skip recorded as below the salary floor, got %+v", recorder.apps)
+				}
+			} else if status != store.ApplicationStatusApplied {
+				t.Errorf("expected the job to be applied to, got %q", status)
+			}
+		})
+	}
+}
+
+func TestApplyToJobSkipsAlreadyApplied(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	recorder := &fakeRecorder{}
+	bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond})
+	bm.SetRecorder(recorder)
+	page := &fakePage{
+		pages: map[string]string{jobURL(1): `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>
+			<div class="jobs-s-apply"><div class="artdeco-inline-feedback--success">Applied 2 weeks ago</div></div>`},
+		clickable: map[string]bool{EasyApplyButtonSelector: true},
+	}
+
+	status, err := bm.applyToJob(page, &store.LinkedInProfile{}, 1, func(string) (bool, error) {
+		t.Error("expected no form to be filled")
+		return true, nil
+	})
+	if err != nil || status != store.ApplicationStatusSkipped || len(page.clicks) != 0 {
+		t.Errorf("expected a skip without opening Easy Apply, got %q, %v after clicks %v", status, err, page.clicks)
+	}
+	if len(recorder.apps) != 1 || recorder.apps[0].Reason != store.SkipReasonAlreadyApplied || recorder.apps[0].Title != "Go Engineer" {
+		t.Errorf("expected the skip recorded as already applied, got %+v", recorder.apps)
+	}
+}
+
+func TestApplyToJobPosterPreference(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	company := `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>`
+	recruiter := company + `<div class="job-details-people-who-can-help__section"><h2>Meet the hiring team</h2></div>`
+
+	tests := []struct {
+		name string
+		pref PosterPreference
+		html string
+		skip bool
+	}{
+		{"any", PosterAny, company, false},
+		{"recruiter wanted and listed", PosterRecruiter, recruiter, false},
+		{"recruiter wanted but missing", PosterRecruiter, company, true},
+		{"company wanted", PosterCompany, company, false},
+		{"company wanted but recruiter listed", PosterCompany, recruiter, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond, PosterPreference: tt.pref})
+			bm.SetRecorder(recorder)
+			page := &fakePage{
+				pages:     map[string]string{jobURL(1): tt.html},
+				clickable: map[string]bool{EasyApplyButtonSelector: true},
+			}
+
+			status, err := bm.applyToJob(page, &store.LinkedInProfile{}, 1, func(string) (bool, error) {
+				return true, nil
+			})
+			if err != nil {
+				t.Fatalf("applyToJob failed: %v", err)
+			}
+			if tt.skip {
+				if status != store.ApplicationStatusSkipped || len(page.clicks) != 0 {
+					t.Errorf("expected a skip without opening Easy Apply, got %q after clicks %v", status, page.clicks)
+				}
+				if len(recorder.apps) != 1 || recorder.apps[0].Reason != store.SkipReasonPosterPreference {
+					t.Errorf("expected the skip recorded as a poster preference, got %+v", recorder.apps)
+				}
+			} else if status != store.ApplicationStatusApplied {
+				t.Errorf("expected the job to be applied to, got %q", status)
+			}
+		})
+	}
+
+	// A custom selector replaces the defaults
+	bm := NewBrowserManager(&Config{PosterPreference: PosterRecruiter, HiringTeamSelectors: []string{".recruiter"}})
+	if bm.matchesPoster(recruiter) || !bm.matchesPoster(`<div class="recruiter"></div>`) {
+		t.Error("expected only the configured selector to find a hiring team")
+	}
+}
+
+func TestApplyToJobClicksPrimaryEasyApply(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	hidden, sticky, primary := &rod.Element{}, &rod.Element{}, &rod.Element{}
+	label := "Easy Apply to Go Engineer at Acme"
+	tests := []struct {
+		name    string
+		buttons []easyApplyCandidate
+		want    *rod.Element
+	}{
+		{"first match hidden", []easyApplyCandidate{
+			{el: hidden, label: label, visible: false, enabled: true, inTopCard: true},
+			{el: primary, label: label, visible: true, enabled: true, inTopCard: true},
+		}, primary},
+		{"first match outside the top card", []easyApplyCandidate{
+			{el: sticky, label: label, visible: true, enabled: true, inTopCard: false},
+			{el: primary, label: label, visible: true, enabled: true, inTopCard: true},
+		}, primary},
+		{"only a hidden match", []easyApplyCandidate{
+			{el: hidden, label: label, visible: false, enabled: true, inTopCard: true},
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond})
+			bm.SetRecorder(&fakeRecorder{})
+			page := &fakePage{
+				pages:     map[string]string{jobURL(1): `<h1>Go Engineer</h1>`},
+				easyApply: tt.buttons,
+			}
+
+			status, err := bm.applyToJob(page, &store.LinkedInProfile{}, 1, func(string) (bool, error) {
+				return true, nil
+			})
+			if err != nil {
+				t.Fatalf("applyToJob failed: %v", err)
+			}
+			if page.clicked != tt.want {
+				t.Errorf("expected button %p clicked, got %p", tt.want, page.clicked)
+			}
+			want := store.ApplicationStatusApplied
+			if tt.want == nil {
+				want = store.ApplicationStatusSkipped
+			}
+			if status != want {
+				t.Errorf("expected %q, got %q", want, status)
+			}
+		})
+	}
+}
+
+func TestSessionLimit(t *testing.T) {
+	tests := []struct{ maxApplications, maxThisSession, want int }{
+		{0, 0, 0},
+		{0, 10, 10},
+		{25, 0, 25},
+		{25, 10, 10},
+		{5, 10, 5},
+	}
+	for _, tt := range tests {
+		if got := sessionLimit(tt.maxApplications, tt.maxThisSession); got != tt.want {
+			t.Errorf("sessionLimit(%d, %d) = %d, want %d", tt.maxApplications, tt.maxThisSession, got, tt.want)
+		}
+	}
+}
+
+func TestApplyToJobsStopsAtMaxJobsThisSession(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	detail := `<div class="job-details-jobs-unified-top-card__job-title"><h1>Go Engineer</h1></div>`
+	lowPay := detail + `<li class="job-details-jobs-unified-top-card__job-insight">$50K/yr</li>`
+	page := &fakePage{
+		pages: map[string]string{
+			jobURL(1): detail, jobURL(2): lowPay, jobURL(3): detail,
+			jobURL(4): detail, jobURL(5): detail, jobURL(6): detail,
+		},
+		clickable: map[string]bool{EasyApplyButtonSelector: true},
+	}
+	profile := &store.LinkedInProfile{DesiredSalary: 100000}
+	bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond})
+	bm.SetRecorder(&fakeRecorder{})
+
+	var attempted []int
+	apply := func(jobID int) (string, error) {
+		attempted = append(attempted, jobID)
+		return bm.applyToJob(page, profile, jobID, func(string) (bool, error) {
+			return jobID != 3, nil // Job 3 fails to submit
+		})
+	}
+
+	// Job 2 is skipped and job 3 fails, so neither counts towards the two
+	var session ApplySession
+	reached, err := applyToJobs([]int{1, 2, 3, 4, 5, 6}, &session, sessionLimit(0, 2), nil, nil, apply)
+	if err != nil {
+		t.Fatalf("applyToJobs failed: %v", err)
+	}
+	if !reached || fmt.Sprint(attempted) != "[1 2 3 4]" {
+		t.Errorf("expected to stop right after the second submission, attempted %v", attempted)
+	}
+	if session.Applied != 2 || session.Skipped != 1 || session.Failed != 1 {
+		t.Errorf("unexpected tallies %+v", session)
+	}
+}
+
+func TestApplyToJobNavigationFailure(t *testing.T) {
+	recorder := &fakeRecorder{}
+	bm := NewBrowserManager(nil)
+	bm.SetRecorder(recorder)
+
+	status, err := bm.applyToJob(&fakePage{}, &store.LinkedInProfile{}, 1, func(string) (bool, error) {
+		t.Fatal("form should not be filled when navigation fails")
+		return false, nil
+	})
+	if status != store.ApplicationStatusFailed || err == nil {
+		t.Errorf("expected failed status with error, got %q, %v", status, err)
+	}
+	if len(recorder.apps) != 0 {
+		t.Errorf("expected nothing recorded, got %+v", recorder.apps)
+	}
+}
@@ -0,0 +1,44 @@
+package browser
+
+import "fmt"
+
+// PosterPreference picks which jobs to apply to by who posted them
+type PosterPreference string
+
+// Poster preferences
+const (
+	PosterAny       PosterPreference = ""          // Apply regardless of who posted the job
+	PosterRecruiter PosterPreference = "recruiter" // Only jobs with a hiring team to contact
+	PosterCompany   PosterPreference = "company"   // Only jobs without one
+)
+
+// ParsePosterPreference validates a poster preference from settings or the
+// config file
+func ParsePosterPreference(value string) (PosterPreference, error) {
+	switch pref := PosterPreference(value); pref {
+	case PosterAny, PosterRecruiter, PosterCompany:
+		return pref, nil
+	}
+	return PosterAny, fmt.Errorf("unknown poster preference %q", value)
+}
+
+// hiringTeamSelectors returns the configured hiring team selectors, or
+// DefaultHiringTeamSelectors
+func (bm *BrowserManager) hiringTeamSelectors() []string {
+	if bm.cfg.HiringTeamSelectors != nil {
+		return bm.cfg.HiringTeamSelectors
+	}
+	return DefaultHiringTeamSelectors
+}
+
+// matchesPoster reports whether a job detail page fits the configured
+// poster preference
+func (bm *BrowserManager) matchesPoster(html string) bool {
+	switch bm.cfg.PosterPreference {
+	case PosterRecruiter:
+		return HasHiringTeam(html, bm.hiringTeamSelectors())
+	case PosterCompany:
+		return !HasHiringTeam(html, bm.hiringTeamSelectors())
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package browser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// QuestionKind is what an application form question asks for
+type QuestionKind int
+
+const (
+	QuestionUnknown    QuestionKind = iota // Not recognised
+	QuestionPhone                          // A phone number
+	QuestionLocation                       // City, state or zip code
+	QuestionSalary                         // Desired pay
+	QuestionYesNo                          // A yes or no answer
+	QuestionExperience                     // Years of experience
+	QuestionDate                           // A date, such as when you can start
+	QuestionFreeText                       // A sentence or more of prose
+	QuestionProfileURL                     // The LinkedIn profile URL
+)
+
+var questionKindNames = map[QuestionKind]string{
+	QuestionUnknown:    "unknown",
+	QuestionPhone:      "phone",
+	QuestionLocation:   "location",
+	QuestionSalary:     "salary",
+	QuestionYesNo:      "yes/no",
+	QuestionExperience: "experience",
+	QuestionDate:       "date",
+	QuestionFreeText:   "free text",
+	QuestionProfileURL: "profile URL",
+}
+
+func (k QuestionKind) String() string {
+	if name, ok := questionKindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Question is what's known about a form field when classifying it
+type Question struct {
+	Label     string
+	InputType string   // The input's type attribute, or "textarea" or "select"
+	Hint      string   // Text of the elements aria-describedby names
+	Options   []string // Choices offered by a select or radio group
+}
+
+// yesNoOpeners start questions answered with yes or no
+var yesNoOpeners = map[string]bool{
+	"are": true, "is": true, "were": true, "do": true, "does": true, "did": true,
+	"have": true, "has": true, "will": true, "would": true, "can": true, "could": true, "should": true,
+}
+
+// ClassifyQuestion works out what a form question asks for. Label words are
+// matched whole, so "resident" isn't a city and "United States" isn't a
+// state. The input type and offered options take precedence over the label,
+// and the hint is used when the label alone isn't recognised.
+func ClassifyQuestion(q Question) QuestionKind {
+	switch strings.ToLower(strings.TrimSpace(q.InputType)) {
+	case "tel":
+		return QuestionPhone
+	case "date":
+		return QuestionDate
+	case "textarea":
+		return QuestionFreeText
+	}
+	if isYesNoOptions(q.Options) {
+		return QuestionYesNo
+	}
+
+	kind := classifyLabel(q.Label, q.InputType)
+	if kind == QuestionUnknown && q.Hint != "" {
+		kind = classifyLabel(q.Hint, q.InputType)
+	}
+	return kind
+}
+
+// classifyLabel classifies a question from its wording
+func classifyLabel(label, inputType string) QuestionKind {
+	words := questionWords(label)
+	if len(words) == 0 {
+		return QuestionUnknown
+	}
+	numeric := strings.EqualFold(strings.TrimSpace(inputType), "number")
+
+	switch {
+	case hasPhrase(words, "phone", "mobile", "telephone", "cell", "contact number"):
+		return QuestionPhone
+	case yesNoOpeners[words[0]] && !numeric && !hasPhrase(words, "how many", "how much"):
+		return QuestionYesNo
+	case hasPhrase(words, "linkedin", "linked in"):
+		return QuestionProfileURL
+	case hasPhrase(words, "salary", "salaries", "wage", "wages", "income", "compensation"):
+		return QuestionSalary
+	case hasPhrase(words, "experience") && hasPhrase(words, "year", "years"):
+		return QuestionExperience
+	case hasPhrase(words, "city", "location", "reside", "residence", "state", "zip", "zipcode", "postal", "postcode"):
+		return QuestionLocation
+	case hasPhrase(words, "date", "mm dd yyyy", "dd mm yyyy", "start"):
+		return QuestionDate
+	case hasPhrase(words, "why", "describe", "explain", "tell us", "tell me"):
+		return QuestionFreeText
+	}
+	return QuestionUnknown
+}
+
+// isYesNoOptions reports whether options offer just yes and no, ignoring
+// LinkedIn's "Select an option" placeholder
+func isYesNoOptions(options []string) bool {
+	yes, no := false, false
+	for _, option := range options {
+		switch strings.ToLower(strings.TrimSpace(option)) {
+		case "yes":
+			yes = true
+		case "no":
+			no = true
+		case "", "select an option":
+		default:
+			return false
+		}
+	}
+	return yes && no
+}
+
+// questionWords splits text into lowercase words
+func questionWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// hasPhrase reports whether words contains any of phrases, each one or
+// more whole words
+func hasPhrase(words []string, phrases ...string) bool {
+	text := " " + strings.Join(words, " ") + " "
+	for _, phrase := range phrases {
+		if strings.Contains(text, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// questionHint returns the text of the elements el's aria-describedby
+// names, such as LinkedIn's format hints
+func questionHint(form FormContainer, el FormElement) string {
+	var hints []string
+	for _, id := range strings.Fields(attr(el, "aria-describedby")) {
+		hint, err := form.ElementByID(id)
+		if err != nil {
+			continue
+		}
+		if text, err := hint.Text(); err == nil && strings.TrimSpace(text) != "" {
+			hints = append(hints, strings.TrimSpace(text))
+		}
+	}
+	return strings.Join(hints, " ")
+}
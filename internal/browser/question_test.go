@@ -0,0 +1,110 @@
+package browser
+
+import (
+	"foxyapply/internal/store"
+	"testing"
+	"time"
+)
+
+func TestClassifyQuestion(t *testing.T) {
+	tests := []struct {
+		q    Question
+		want QuestionKind
+	}{
+		{Question{Label: "Mobile phone number", InputType: "text"}, QuestionPhone},
+		{Question{Label: "Contact number"}, QuestionPhone},
+		{Question{Label: "Your number", InputType: "tel"}, QuestionPhone},
+		{Question{Label: "City"}, QuestionLocation},
+		{Question{Label: "Location (city)"}, QuestionLocation},
+		{Question{Label: "Where do you currently reside?"}, QuestionLocation},
+		{Question{Label: "State"}, QuestionLocation},
+		{Question{Label: "ZIP / Postal code"}, QuestionLocation},
+		{Question{Label: "What is your desired salary?", InputType: "number"}, QuestionSalary},
+		{Question{Label: "Expected annual compensation (USD)"}, QuestionSalary},
+		{Question{Label: "How many years of work experience do you have with Go?", InputType: "number"}, QuestionExperience},
+		{Question{Label: "Years of experience with Kubernetes"}, QuestionExperience},
+		{Question{Label: "LinkedIn Profile"}, QuestionProfileURL},
+		{Question{Label: "Have you ever worked for Acme?"}, QuestionYesNo},
+		{Question{Label: "Do you have 5+ years of experience with Go?"}, QuestionYesNo},
+		{Question{Label: "Are you comfortable commuting to this job's location?"}, QuestionYesNo},
+		{Question{Label: "When can you start?"}, QuestionDate},
+		{Question{Label: "Earliest start date", InputType: "date"}, QuestionDate},
+		{Question{Label: "Why do you want to join Acme?"}, QuestionFreeText},
+		{Question{Label: "Anything else?", InputType: "textarea"}, QuestionFreeText},
+		{Question{Label: "Rate your Kubernetes skills from 1 to 10", InputType: "number"}, QuestionUnknown},
+		{Question{Label: ""}, QuestionUnknown},
+
+		// Words inside longer words and place names don't count
+		{Question{Label: "Are you a U.S. citizen or permanent resident?"}, QuestionYesNo},
+		{Question{Label: "Citizenship"}, QuestionUnknown},
+		{Question{Label: "Ethnicity"}, QuestionUnknown},
+		{Question{Label: "Are you legally authorized to work in the United States?"}, QuestionYesNo},
+		{Question{Label: "Which United States time zone do you work in?"}, QuestionUnknown},
+		{Question{Label: "Describe your experience with electricity grids"}, QuestionFreeText},
+
+		// Options and hints fill in what the label leaves out
+		{Question{Label: "Sponsorship", InputType: "select", Options: []string{"Select an option", "Yes", "No"}}, QuestionYesNo},
+		{Question{Label: "Team size", InputType: "select", Options: []string{"1-10", "11-50"}}, QuestionUnknown},
+		{Question{Label: "Availability", Hint: "Enter a date, MM/DD/YYYY"}, QuestionDate},
+		{Question{Label: "Go", Hint: "Years of experience, as a whole number", InputType: "number"}, QuestionExperience},
+		{Question{Label: "City", Hint: "Enter a date"}, QuestionLocation},
+	}
+	for _, tt := range tests {
+		if got := ClassifyQuestion(tt.q); got != tt.want {
+			t.Errorf("ClassifyQuestion(%+v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestChooseValueByKind(t *testing.T) {
+	profile := &store.LinkedInProfile{
+		PhoneNumber:     "+1 555 123 4567",
+		UserCity:        "Austin",
+		UserState:       "TX",
+		ZipCode:         "78701",
+		YearsExperience: 7,
+		CoverLetter:     "Hello there",
+	}
+	tests := []struct {
+		q      Question
+		value  string
+		reason string
+	}{
+		{Question{Label: "Are you a U.S. citizen or permanent resident?"}, "", "unanswered yes/no question"},
+		{Question{Label: "Are you legally authorized to work in the United States?"}, "", "unanswered yes/no question"},
+		{Question{Label: "Have you ever worked for Acme?"}, "No", "never worked here before"},
+		{Question{Label: "State"}, "TX", "state"},
+		{Question{Label: "City and state"}, "Austin, TX", "city and state"},
+		{Question{Label: "Postal code"}, "78701", "zip code"},
+		{Question{Label: "Why do you want to join Acme?"}, "Hello there", "cover letter"},
+		{Question{Label: "Start date", InputType: "date"}, time.Now().Format("2006-01-02"), "today's date"},
+		{Question{Label: "Availability", Hint: "MM/DD/YYYY"}, time.Now().Format("01/02/2006"), "today's date"},
+		{Question{Label: "Ethnicity"}, "7", "unrecognised question, years of experience"},
+	}
+	for _, tt := range tests {
+		value, reason := chooseValue(tt.q, profile, nil)
+		if value != tt.value || reason != tt.reason {
+			t.Errorf("chooseValue(%+v) = %q (%s), want %q (%s)", tt.q, value, reason, tt.value, tt.reason)
+		}
+	}
+
+	// Yes/no questions go to the answer provider when there is one
+	provider := func(label, inputType string) (string, error) { return "No", nil }
+	if value, reason := chooseValue(Question{Label: "Will you require visa sponsorship?"}, profile, provider); value != "No" || reason != "answer provider" {
+		t.Errorf("expected the provider's answer, got %q (%s)", value, reason)
+	}
+}
+
+func TestQuestionHint(t *testing.T) {
+	form := &fakeForm{rerendered: []*fakeElement{
+		{attrs: map[string]string{"id": "hint-1"}, value: " MM/DD/YYYY "},
+		{attrs: map[string]string{"id": "error-1"}},
+	}}
+	el := &fakeElement{attrs: map[string]string{"aria-describedby": "hint-1 error-1 missing"}}
+	if got := questionHint(form, el); got != "MM/DD/YYYY" {
+		t.Errorf("expected the hint text, got %q", got)
+	}
+	if got := questionHint(form, &fakeElement{attrs: map[string]string{}}); got != "" {
+		t.Errorf("expected no hint without aria-describedby, got %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+package browser
+
+import (
+	"fmt"
+	"foxyapply/internal/store"
+)
+
+// JobQueue persists the jobs an apply run has found, so jobs a run didn't
+// get to are picked up by the next one. *store.Store implements it;
+// StartApplying uses it through the recorder.
+type JobQueue interface {
+	EnqueueJobs(profileID int64, jobs []store.QueuedJob) error
+	ListQueuedJobs(profileID int64, status string) ([]*store.QueuedJob, error)
+	SetQueuedJobStatus(id int64, status string) error
+}
+
+// applyQueue is a profile's job queue for one apply run. Methods on a nil
+// *applyQueue do nothing, so runs without a JobQueue skip queueing.
+type applyQueue struct {
+	queue     JobQueue
+	profileID int64
+	pending   map[int]int64 // Job ID to queue row ID
+}
+
+// openJobQueue returns the profile's queue, or nil if the recorder can't
+// queue jobs
+func (bm *BrowserManager) openJobQueue(profileID int64) *applyQueue {
+	bm.mu.RLock()
+	queue, ok := bm.recorder.(JobQueue)
+	bm.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return &applyQueue{queue: queue, profileID: profileID, pending: map[int]int64{}}
+}
+
+// load refreshes the pending jobs from the queue, returning their job IDs
+// in queue order
+func (q *applyQueue) load() []int {
+	jobs, err := q.queue.ListQueuedJobs(q.profileID, store.QueueStatusPending)
+	if err != nil {
+		fmt.Printf("⚠️ Could not read the job queue: %v\n", err)
+		return nil
+	}
+	ids := make([]int, 0, len(jobs))
+	for _, job := range jobs {
+		q.pending[job.JobID] = job.ID
+		ids = append(ids, job.JobID)
+	}
+	return ids
+}
+
+// leftover returns the jobs an earlier run queued but didn't finish, in
+// queue order
+func (q *applyQueue) leftover() []int {
+	if q == nil {
+		return nil
+	}
+	return q.load()
+}
+
+// add queues jobIDs as pending. Jobs queued before keep their status.
+func (q *applyQueue) add(jobIDs []int) {
+	if q == nil || len(jobIDs) == 0 {
+		return
+	}
+	jobs := make([]store.QueuedJob, len(jobIDs))
+	for i, id := range jobIDs {
+		jobs[i] = store.QueuedJob{JobID: id}
+	}
+	if err := q.queue.EnqueueJobs(q.profileID, jobs); err != nil {
+		fmt.Printf("⚠️ Could not queue jobs: %v\n", err)
+		return
+	}
+	q.load()
+}
+
+// done marks a job finished, whatever its outcome. Jobs the run never
+// finished stay pending.
+func (q *applyQueue) done(jobID int) {
+	if q == nil {
+		return
+	}
+	id, ok := q.pending[jobID]
+	if !ok {
+		return
+	}
+	if err := q.queue.SetQueuedJobStatus(id, store.QueueStatusDone); err != nil {
+		fmt.Printf("⚠️ Could not update the job queue: %v\n", err)
+		return
+	}
+	delete(q.pending, jobID)
+}
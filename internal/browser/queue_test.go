@@ -0,0 +1,83 @@
+package browser
+
+import (
+	"fmt"
+	"foxyapply/internal/store"
+	"slices"
+	"testing"
+)
+
+// fakeJobQueue is a recorder that keeps queued jobs in memory
+type fakeJobQueue struct {
+	fakeRecorder
+	jobs []*store.QueuedJob
+}
+
+func (q *fakeJobQueue) EnqueueJobs(profileID int64, jobs []store.QueuedJob) error {
+	for _, job := range jobs {
+		if slices.ContainsFunc(q.jobs, func(j *store.QueuedJob) bool { return j.ProfileID == profileID && j.JobID == job.JobID }) {
+			continue
+		}
+		q.jobs = append(q.jobs, &store.QueuedJob{ID: int64(len(q.jobs) + 1), ProfileID: profileID, JobID: job.JobID, Status: store.QueueStatusPending})
+	}
+	return nil
+}
+
+func (q *fakeJobQueue) ListQueuedJobs(profileID int64, status string) ([]*store.QueuedJob, error) {
+	var jobs []*store.QueuedJob
+	for _, job := range q.jobs {
+		if job.ProfileID == profileID && (status == "" || job.Status == status) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (q *fakeJobQueue) SetQueuedJobStatus(id int64, status string) error {
+	for _, job := range q.jobs {
+		if job.ID == id {
+			job.Status = status
+			return nil
+		}
+	}
+	return fmt.Errorf("queued job not found: %d", id)
+}
+
+func TestApplyQueue(t *testing.T) {
+	queue := &fakeJobQueue{}
+	bm := NewBrowserManager(nil)
+	bm.SetRecorder(queue)
+
+	q := bm.openJobQueue(1)
+	if leftover := q.leftover(); len(leftover) != 0 {
+		t.Errorf("expected an empty queue, got %v", leftover)
+	}
+	q.add([]int{101, 102, 103})
+	q.done(101)
+	q.done(999) // Never queued
+
+	// A later run picks up the jobs this one didn't finish
+	next := bm.openJobQueue(1)
+	if leftover := next.leftover(); !slices.Equal(leftover, []int{102, 103}) {
+		t.Errorf("expected 102 and 103 left over, got %v", leftover)
+	}
+	next.add([]int{101, 104})
+	if leftover := next.leftover(); !slices.Equal(leftover, []int{102, 103, 104}) {
+		t.Errorf("expected finished jobs not to be queued again, got %v", leftover)
+	}
+	if other := bm.openJobQueue(2).leftover(); len(other) != 0 {
+		t.Errorf("expected other profiles' queues to be separate, got %v", other)
+	}
+
+	// Recorders without a queue are ignored
+	bm.SetRecorder(&fakeRecorder{})
+	q = bm.openJobQueue(1)
+	if q != nil {
+		t.Fatalf("expected no queue without a JobQueue, got %+v", q)
+	}
+	q.add([]int{105})
+	q.done(105)
+	if leftover := q.leftover(); leftover != nil {
+		t.Errorf("expected nothing left over without a queue, got %v", leftover)
+	}
+}
@@ -0,0 +1,114 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DefaultRateLimitCooldown is how long applying stays paused after LinkedIn
+// throttles it, when Config.RateLimitCooldown is unset. The Easy Apply
+// limit resets daily.
+const DefaultRateLimitCooldown = 24 * time.Hour
+
+// ErrThrottled is returned when LinkedIn shows its Easy Apply limit message
+// or starts answering with HTTP 429 during an apply run. It wraps
+// ErrRateLimited.
+var ErrThrottled = fmt.Errorf("%w: applications are being throttled", ErrRateLimited)
+
+// ErrCoolingDown is returned by StartApplying while a rate-limit cooldown
+// is in effect
+var ErrCoolingDown = errors.New("applying is paused after LinkedIn rate limiting")
+
+// isThrottled reports whether err means LinkedIn is rate limiting the session
+func isThrottled(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// easyApplyLimitPhrases appear in LinkedIn's "reached the limit" notice
+var easyApplyLimitPhrases = []string{
+	"easy apply limit",
+	"easy apply application limit",
+	"you've reached the limit",
+	"limit daily submissions",
+}
+
+// isEasyApplyLimitReached reports whether html shows LinkedIn's daily Easy
+// Apply limit notice
+func isEasyApplyLimitReached(html string) bool {
+	lower := strings.ReplaceAll(strings.ToLower(html), "’", "'")
+	for _, phrase := range easyApplyLimitPhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchThrottling flags LinkedIn responses with HTTP 429 on page until stop
+// is called or the page closes. The returned flag is set once one is seen.
+func watchThrottling(page *rod.Page) (throttled *atomic.Bool, stop func()) {
+	throttled = &atomic.Bool{}
+	if err := (proto.NetworkEnable{}).Call(page); err != nil {
+		fmt.Printf("⚠️ Could not watch for rate limiting: %v\n", err)
+		return throttled, func() {}
+	}
+	ctx, cancel := context.WithCancel(page.GetContext())
+	go page.Context(ctx).EachEvent(func(e *proto.NetworkResponseReceived) {
+		if e.Response.Status == 429 && IsLinkedInURL(e.Response.URL) {
+			throttled.Store(true)
+		}
+	})()
+	return throttled, cancel
+}
+
+// SetCooldownUntil pauses applying until t, e.g. to restore a cooldown
+// persisted before a restart. A zero time clears it.
+func (bm *BrowserManager) SetCooldownUntil(t time.Time) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.cooldownUntil = t
+}
+
+// CooldownUntil returns when the current rate-limit cooldown ends, or the
+// zero time if applying isn't paused
+func (bm *BrowserManager) CooldownUntil() time.Time {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	if time.Now().After(bm.cooldownUntil) {
+		return time.Time{}
+	}
+	return bm.cooldownUntil
+}
+
+// CheckCooldown returns ErrCoolingDown while a rate-limit cooldown is in
+// effect, and nil otherwise
+func (bm *BrowserManager) CheckCooldown() error {
+	if until := bm.CooldownUntil(); !until.IsZero() {
+		return fmt.Errorf("%w until %s", ErrCoolingDown, until.Format(time.RFC1123))
+	}
+	return nil
+}
+
+// startCooldown pauses applying for Config.RateLimitCooldown after cause
+// and tells the UI
+func (bm *BrowserManager) startCooldown(cause error) {
+	cooldown := bm.cfg.RateLimitCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultRateLimitCooldown
+	}
+	until := time.Now().Add(cooldown)
+	bm.SetCooldownUntil(until)
+
+	fmt.Printf("🛑 %v, pausing applications until %s\n", cause, until.Format(time.RFC1123))
+	bm.emit("browser:rate-limited", map[string]interface{}{
+		"until":  until,
+		"reason": cause.Error(),
+	})
+}
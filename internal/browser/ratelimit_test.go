@@ -0,0 +1,157 @@
+package browser
+
+import (
+	"encoding/json"
+	"errors"
+	"foxyapply/internal/store"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+func TestIsEasyApplyLimitReached(t *testing.T) {
+	tests := []struct {
+		html string
+		want bool
+	}{
+		{"<h2>You’ve reached the Easy Apply application limit for today</h2>", true},
+		{"<p>You've reached the Easy Apply limit for today.</p>", true},
+		{"<p>We limit daily submissions to help ensure each applicant gets seen.</p>", true},
+		{"<p>YOU’VE REACHED THE LIMIT</p>", true},
+		{"<div class=\"jobs-easy-apply-modal\">Contact info</div>", false},
+	}
+	for _, tt := range tests {
+		if got := isEasyApplyLimitReached(tt.html); got != tt.want {
+			t.Errorf("isEasyApplyLimitReached(%q) = %v, want %v", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestApplyToJobsStopsWhenThrottled(t *testing.T) {
+	var session ApplySession
+	calls := 0
+	_, err := applyToJobs([]int{1, 2, 3}, &session, 0, nil, nil, func(jobID int) (string, error) {
+		calls++
+		if jobID == 2 {
+			return store.ApplicationStatusFailed, ErrThrottled
+		}
+		return store.ApplicationStatusApplied, nil
+	})
+	if !errors.Is(err, ErrThrottled) {
+		t.Fatalf("expected ErrThrottled, got %v", err)
+	}
+	if calls != 2 || session.Applied != 1 {
+		t.Errorf("expected the run to stop at the throttled job, got %d calls and %+v", calls, session)
+	}
+}
+
+func TestApplyToJobDetectsEasyApplyLimit(t *testing.T) {
+	defer func(d time.Duration) { redirectSettleDelay = d }(redirectSettleDelay)
+	redirectSettleDelay = 0
+
+	recorder := &fakeRecorder{}
+	bm := NewBrowserManager(&Config{ApplyDelay: time.Millisecond})
+	bm.SetRecorder(recorder)
+	limited := "https://www.linkedin.com/jobs/view/1/?limit"
+	page := &fakePage{
+		pages: map[string]string{
+			jobURL(1): `<div class="jobs-description__content">Write Go.</div>`,
+			limited:   `<div role="dialog">You've reached the Easy Apply limit for today.</div>`,
+		},
+		clickable: map[string]bool{EasyApplyButtonSelector: true},
+		redirects: map[string]string{EasyApplyButtonSelector: limited},
+	}
+
+	_, err := bm.applyToJob(page, &store.LinkedInProfile{}, 1, func(string) (bool, error) {
+		t.Fatal("form should not be filled once the limit is reached")
+		return false, nil
+	})
+	if !errors.Is(err, ErrThrottled) {
+		t.Errorf("expected ErrThrottled, got %v", err)
+	}
+	if len(recorder.apps) != 0 {
+		t.Errorf("expected nothing recorded for a throttled job, got %+v", recorder.apps)
+	}
+}
+
+func TestCooldownRefusesApplying(t *testing.T) {
+	var events []string
+	bm := NewBrowserManager(&Config{RateLimitCooldown: time.Hour})
+	bm.SetEventHandler(func(name string, data map[string]interface{}) {
+		events = append(events, name)
+	})
+	if err := bm.CheckCooldown(); err != nil {
+		t.Fatalf("expected no cooldown initially, got %v", err)
+	}
+
+	bm.startCooldown(ErrThrottled)
+	if len(events) != 1 || events[0] != "browser:rate-limited" {
+		t.Errorf("expected a rate-limited event, got %v", events)
+	}
+	until := bm.CooldownUntil()
+	if d := time.Until(until); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("expected cooldown about an hour out, got %s", d)
+	}
+
+	profile := &store.LinkedInProfile{Positions: []string{"Engineer"}, Locations: []string{"Remote"}}
+	if _, err := bm.StartApplying(profile, nil); !errors.Is(err, ErrCoolingDown) {
+		t.Errorf("expected StartApplying to refuse during cooldown, got %v", err)
+	}
+	if bm.IsApplying() {
+		t.Error("expected a refused run not to claim the applying flag")
+	}
+
+	// An elapsed cooldown no longer blocks
+	bm.SetCooldownUntil(time.Now().Add(-time.Minute))
+	if !bm.CooldownUntil().IsZero() || bm.CheckCooldown() != nil {
+		t.Error("expected an elapsed cooldown to be cleared")
+	}
+}
+
+// eventCDP is a fakeCDP that also delivers the events sent on events
+type eventCDP struct {
+	fakeCDP
+	events chan *cdp.Event
+}
+
+func (e *eventCDP) Event() <-chan *cdp.Event {
+	return e.events
+}
+
+func TestWatchThrottlingStops(t *testing.T) {
+	client := &eventCDP{
+		fakeCDP: fakeCDP{params: map[string]interface{}{}, results: map[string]string{
+			"Target.createTarget":   `{"targetId": "tab-1"}`,
+			"Target.attachToTarget": `{"sessionId": "session-1"}`,
+		}},
+		events: make(chan *cdp.Event),
+	}
+	browser := rod.New().Client(client).MustConnect()
+	page, err := NewBrowserManager(nil).openPage(browser)
+	if err != nil {
+		t.Fatalf("failed to open page: %v", err)
+	}
+
+	stopped, stop := watchThrottling(page)
+	stop()
+	watching, stopWatching := watchThrottling(page)
+	defer stopWatching()
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"response": map[string]interface{}{"url": "https://www.linkedin.com/voyager/api/jobs", "status": 429},
+	})
+	client.events <- &cdp.Event{SessionID: "session-1", Method: "Network.responseReceived", Params: params}
+
+	deadline := time.Now().Add(time.Second)
+	for !watching.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !watching.Load() {
+		t.Fatal("expected a LinkedIn 429 to be flagged")
+	}
+	if stopped.Load() {
+		t.Error("expected a stopped watcher to ignore responses")
+	}
+}
@@ -0,0 +1,93 @@
+package browser
+
+import (
+	"math/rand"
+	"time"
+)
+
+// scrollPattern generates randomized scroll steps so job list scrolling
+// doesn't move in identical, easily fingerprinted increments
+type scrollPattern struct {
+	rng *rand.Rand
+
+	MinDelta, MaxDelta float64       // Pixels per downward scroll
+	CorrectionChance   float64       // Probability of a small upward scroll
+	MaxCorrection      float64       // Largest upward scroll, in pixels
+	WiggleChance       float64       // Probability of moving the mouse after a scroll
+	MinDwell, MaxDwell time.Duration // Pause after each scroll
+}
+
+// scrollStep is a single scroll and the pause that follows it
+type scrollStep struct {
+	Delta  float64 // Negative for an upward correction
+	Dwell  time.Duration
+	Wiggle bool
+}
+
+// newScrollPattern returns the default pattern driven by a seeded RNG
+func newScrollPattern(seed int64) *scrollPattern {
+	return &scrollPattern{
+		rng:              rand.New(rand.NewSource(seed)),
+		MinDelta:         150,
+		MaxDelta:         450,
+		CorrectionChance: 0.15,
+		MaxCorrection:    120,
+		WiggleChance:     0.3,
+		MinDwell:         800 * time.Millisecond,
+		MaxDwell:         2500 * time.Millisecond,
+	}
+}
+
+// next returns the next scroll step
+func (sp *scrollPattern) next() scrollStep {
+	step := scrollStep{
+		Delta:  sp.MinDelta + sp.rng.Float64()*(sp.MaxDelta-sp.MinDelta),
+		Dwell:  sp.MinDwell + time.Duration(sp.rng.Int63n(int64(sp.MaxDwell-sp.MinDwell)+1)),
+		Wiggle: sp.rng.Float64() < sp.WiggleChance,
+	}
+	if sp.rng.Float64() < sp.CorrectionChance {
+		step.Delta = -(10 + sp.rng.Float64()*(sp.MaxCorrection-10))
+	}
+	return step
+}
+
+// offset returns a random offset in [-max, max], used for mouse wiggles
+func (sp *scrollPattern) offset(max float64) float64 {
+	return (sp.rng.Float64()*2 - 1) * max
+}
+
+// stableCounter detects when a growing count, such as the number of loaded
+// jobs, has stopped increasing
+type stableCounter struct {
+	rounds    int // Observations without growth needed to count as stable
+	best      int
+	unchanged int
+}
+
+// observe records the latest count and reports whether it has now gone
+// rounds observations without exceeding its previous best
+func (c *stableCounter) observe(n int) bool {
+	if n > c.best {
+		c.best = n
+		c.unchanged = 0
+		return false
+	}
+	c.unchanged++
+	return c.unchanged >= c.rounds
+}
+
+// scrollUntilStable calls scroll until count stops growing for stableRounds
+// consecutive scrolls, or maxScrolls is reached. It returns the final count.
+func scrollUntilStable(maxScrolls, stableRounds int, scroll func(i int) error, count func() int) (int, error) {
+	counter := stableCounter{rounds: stableRounds}
+	counter.observe(count())
+	for i := 0; i < maxScrolls; i++ {
+		if err := scroll(i); err != nil {
+			return counter.best, err
+		}
+		if counter.observe(count()) {
+			break
+		}
+	}
+	return counter.best, nil
+}
@@ -0,0 +1,107 @@
+package browser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScrollPatternStaysInBounds(t *testing.T) {
+	sp := newScrollPattern(42)
+	steps := make([]scrollStep, 500)
+	for i := range steps {
+		steps[i] = sp.next()
+	}
+
+	var total float64
+	corrections := 0
+	for i, step := range steps {
+		switch {
+		case step.Delta < 0:
+			corrections++
+			if -step.Delta > sp.MaxCorrection {
+				t.Errorf("step %d: correction %.1f exceeds %.1f", i, -step.Delta, sp.MaxCorrection)
+			}
+		case step.Delta < sp.MinDelta || step.Delta > sp.MaxDelta:
+			t.Errorf("step %d: delta %.1f outside [%.0f, %.0f]", i, step.Delta, sp.MinDelta, sp.MaxDelta)
+		}
+		if step.Dwell < sp.MinDwell || step.Dwell > sp.MaxDwell {
+			t.Errorf("step %d: dwell %v outside [%v, %v]", i, step.Dwell, sp.MinDwell, sp.MaxDwell)
+		}
+		total += step.Delta
+	}
+
+	if corrections == 0 || corrections == len(steps) {
+		t.Errorf("expected some but not all steps to be corrections, got %d", corrections)
+	}
+	if total <= 0 {
+		t.Errorf("expected net downward progress, got %.1f", total)
+	}
+}
+
+func TestScrollPatternIsSeedable(t *testing.T) {
+	a, b, c := newScrollPattern(7), newScrollPattern(7), newScrollPattern(8)
+	differs := false
+	for i := 0; i < 20; i++ {
+		stepA, stepB, stepC := a.next(), b.next(), c.next()
+		if stepA != stepB {
+			t.Fatalf("step %d differs for the same seed: %+v vs %+v", i, stepA, stepB)
+		}
+		if stepA != stepC {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Error("expected different seeds to give different steps")
+	}
+}
+
+func TestScrollUntilStable(t *testing.T) {
+	tests := []struct {
+		name    string
+		counts  []int // Job count before the first scroll, then after each one
+		max     int
+		scrolls int
+		final   int
+	}{
+		{"stops once count stops growing", []int{7, 14, 21, 25, 25, 25, 25}, 30, 6, 25},
+		{"keeps going through a brief stall", []int{7, 7, 14, 14, 21, 21, 21, 21}, 30, 7, 21},
+		{"short page stops early", []int{3, 3, 3, 3}, 30, 3, 3},
+		{"drop after a correction isn't growth", []int{10, 12, 11, 12, 12}, 30, 4, 12},
+		{"capped when jobs keep loading", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5, 5, 6},
+	}
+
+	for _, tt := range tests {
+		calls, scrolls := 0, 0
+		count := func() int {
+			n := tt.counts[min(calls, len(tt.counts)-1)]
+			calls++
+			return n
+		}
+		scroll := func(i int) error {
+			scrolls++
+			return nil
+		}
+
+		final, err := scrollUntilStable(tt.max, 3, scroll, count)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if scrolls != tt.scrolls || final != tt.final {
+			t.Errorf("%s: got %d scrolls and final count %d, want %d and %d", tt.name, scrolls, final, tt.scrolls, tt.final)
+		}
+	}
+}
+
+func TestScrollUntilStableStopsOnError(t *testing.T) {
+	scrolls := 0
+	_, err := scrollUntilStable(30, 3, func(i int) error {
+		scrolls++
+		if i == 2 {
+			return errors.New("target closed")
+		}
+		return nil
+	}, func() int { return scrolls })
+	if err == nil || scrolls != 3 {
+		t.Errorf("expected error after 3 scrolls, got %v after %d", err, scrolls)
+	}
+}
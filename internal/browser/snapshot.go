@@ -0,0 +1,94 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// snapshotTimeFormat names snapshot folders so they sort by time
+const snapshotTimeFormat = "2006-01-02_15-04-05.000"
+
+// Snapshot saves page's current HTML and a full-page screenshot, for
+// debugging selectors that stop matching. Each call writes page.html and
+// page.png to a new timestamped folder under dir.
+func (bm *BrowserManager) Snapshot(page *rod.Page, dir string) (htmlPath, pngPath string, err error) {
+	if page == nil {
+		return "", "", ErrBrowserNotRunning
+	}
+
+	html, err := bm.timed(page).HTML()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read page: %w", err)
+	}
+	// Capture the whole scrollable page rather than just the viewport
+	png, err := bm.timed(page).Screenshot(true, &proto.PageCaptureScreenshot{
+		Format:                proto.PageCaptureScreenshotFormatPng,
+		CaptureBeyondViewport: true,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to take screenshot: %w", err)
+	}
+
+	folder, err := snapshotFolder(dir, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	htmlPath = filepath.Join(folder, "page.html")
+	if err := os.WriteFile(htmlPath, []byte(html), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write snapshot HTML: %w", err)
+	}
+	pngPath = filepath.Join(folder, "page.png")
+	if err := os.WriteFile(pngPath, png, 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write snapshot screenshot: %w", err)
+	}
+	return htmlPath, pngPath, nil
+}
+
+// snapshotFolder creates a folder under dir named for t, adding a counter
+// when a snapshot was already taken in the same millisecond
+func snapshotFolder(dir string, t time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	name := t.Format(snapshotTimeFormat)
+	for i := 1; ; i++ {
+		folder := filepath.Join(dir, name)
+		if i > 1 {
+			folder += "-" + strconv.Itoa(i)
+		}
+		err := os.Mkdir(folder, 0755)
+		if err == nil {
+			return folder, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("failed to create snapshot folder: %w", err)
+		}
+	}
+}
+
+// snapshotFailure saves a snapshot of page to Config.SnapshotDir after a
+// job fails, so the page that broke can be looked at later. Failures to
+// save are logged rather than returned.
+func (bm *BrowserManager) snapshotFailure(page *rod.Page, jobID int) {
+	if bm.cfg.SnapshotDir == "" {
+		return
+	}
+	htmlPath, _, err := bm.Snapshot(page, bm.cfg.SnapshotDir)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to save a snapshot for job ID %d: %v\n", jobID, err)
+		return
+	}
+	dir := filepath.Dir(htmlPath)
+	fmt.Printf("📸 Saved a snapshot of job ID %d to %s\n", jobID, dir)
+	bm.emit("browser:snapshot", map[string]interface{}{
+		"jobId": jobID,
+		"dir":   dir,
+	})
+}
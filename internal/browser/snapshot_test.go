@@ -0,0 +1,89 @@
+package browser
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+)
+
+func TestSnapshotFolder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	now := time.Date(2024, 5, 1, 9, 30, 15, 123e6, time.UTC)
+
+	first, err := snapshotFolder(dir, now)
+	if err != nil {
+		t.Fatalf("snapshotFolder failed: %v", err)
+	}
+	if filepath.Base(first) != "2024-05-01_09-30-15.123" {
+		t.Errorf("expected a timestamped folder, got %s", first)
+	}
+
+	// A second snapshot in the same millisecond gets its own folder
+	second, err := snapshotFolder(dir, now)
+	if err != nil {
+		t.Fatalf("snapshotFolder failed: %v", err)
+	}
+	if second != first+"-2" {
+		t.Errorf("expected %s-2, got %s", first, second)
+	}
+	if info, err := os.Stat(second); err != nil || !info.IsDir() {
+		t.Errorf("expected %s to be created, got %v", second, err)
+	}
+}
+
+func TestSnapshotWithoutPage(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	if _, _, err := bm.Snapshot(nil, t.TempDir()); !errors.Is(err, ErrBrowserNotRunning) {
+		t.Errorf("expected ErrBrowserNotRunning, got %v", err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	bm := NewBrowserManager(nil)
+	bin := bm.findSystemBrowser()
+	if bin == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+
+	u, err := launcher.New().Bin(bin).Headless(true).NoSandbox(true).Launch()
+	if err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	browser := rod.New().ControlURL(u).MustConnect()
+	defer browser.MustClose()
+
+	page := browser.MustPage("about:blank")
+	page.MustSetDocumentContent(`<div id="tall" style="height: 5000px">Job details</div>`)
+	htmlPath, pngPath, err := bm.Snapshot(page, t.TempDir())
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if filepath.Dir(htmlPath) != filepath.Dir(pngPath) {
+		t.Errorf("expected both files in one folder, got %s and %s", htmlPath, pngPath)
+	}
+
+	html, err := os.ReadFile(htmlPath)
+	if err != nil || !strings.Contains(string(html), `id="tall"`) {
+		t.Errorf("expected the page HTML, got %q, %v", html, err)
+	}
+	data, err := os.ReadFile(pngPath)
+	if err != nil {
+		t.Fatalf("failed to read screenshot: %v", err)
+	}
+	img, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected a PNG screenshot: %v", err)
+	}
+	// The whole page, not just the viewport
+	if img.Height < 5000 {
+		t.Errorf("expected a full-page screenshot, got height %d", img.Height)
+	}
+}
@@ -0,0 +1,94 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/go-rod/stealth"
+)
+
+// Stealth audit signals, the keys of AuditStealth's result
+const (
+	StealthWebdriver = "webdriver" // navigator.webdriver is hidden
+	StealthChrome    = "chrome"    // window.chrome exists, as in a normal Chrome
+	StealthPlugins   = "plugins"   // navigator.plugins isn't empty
+	StealthLanguages = "languages" // navigator.language and languages are set
+)
+
+// fingerprint is what a page can read about the browser that commonly
+// gives automation away
+type fingerprint struct {
+	Webdriver bool     `json:"webdriver"`
+	Chrome    bool     `json:"chrome"`
+	Plugins   int      `json:"plugins"`
+	Language  string   `json:"language"`
+	Languages []string `json:"languages"`
+}
+
+// fingerprintJS collects a fingerprint from the page
+const fingerprintJS = `() => ({
+	webdriver: navigator.webdriver === true,
+	chrome: typeof window.chrome === "object" && window.chrome !== null,
+	plugins: navigator.plugins ? navigator.plugins.length : 0,
+	language: navigator.language || "",
+	languages: Array.isArray(navigator.languages) ? navigator.languages : [],
+})`
+
+// humanSignals reports, for each stealth signal, whether fp looks like a
+// person's browser rather than an automated one
+func humanSignals(fp fingerprint) map[string]bool {
+	return map[string]bool{
+		StealthWebdriver: !fp.Webdriver,
+		StealthChrome:    fp.Chrome,
+		StealthPlugins:   fp.Plugins > 0,
+		StealthLanguages: fp.Language != "" && len(fp.Languages) > 0,
+	}
+}
+
+// AuditStealth opens a page the way apply runs do and checks whether the
+// anti-detection measures took effect. Each signal maps to true when it
+// looks human and false when it gives automation away.
+func (bm *BrowserManager) AuditStealth() (map[string]bool, error) {
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return nil, ErrBrowserNotRunning
+	}
+
+	page, err := stealth.Page(browser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open page: %w", err)
+	}
+	defer page.Close()
+	bm.overrideUserAgent(page)
+
+	// Scripts injected for new documents only run once the page navigates
+	if err := bm.timed(page).Navigate("about:blank"); err != nil {
+		return nil, fmt.Errorf("failed to navigate: %w", err)
+	}
+	result, err := bm.timed(page).Eval(fingerprintJS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read browser fingerprint: %w", err)
+	}
+	var fp fingerprint
+	if err := result.Value.Unmarshal(&fp); err != nil {
+		return nil, fmt.Errorf("failed to parse browser fingerprint: %w", err)
+	}
+
+	signals := humanSignals(fp)
+	var automated []string
+	for name, human := range signals {
+		if !human {
+			automated = append(automated, name)
+		}
+	}
+	if len(automated) == 0 {
+		log.Printf("Stealth audit: all %d signals look human", len(signals))
+	} else {
+		slices.Sort(automated)
+		log.Printf("Stealth audit: %d of %d signals look automated: %s",
+			len(automated), len(signals), strings.Join(automated, ", "))
+	}
+	return signals, nil
+}
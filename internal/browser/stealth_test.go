@@ -0,0 +1,46 @@
+package browser
+
+import "testing"
+
+func TestHumanSignals(t *testing.T) {
+	human := fingerprint{Chrome: true, Plugins: 5, Language: "en-US", Languages: []string{"en-US", "en"}}
+	for name, ok := range humanSignals(human) {
+		if !ok {
+			t.Errorf("expected %s to look human for %+v", name, human)
+		}
+	}
+
+	automated := fingerprint{Webdriver: true}
+	signals := humanSignals(automated)
+	for _, name := range []string{StealthWebdriver, StealthChrome, StealthPlugins, StealthLanguages} {
+		human, ok := signals[name]
+		if !ok {
+			t.Errorf("expected a %s signal", name)
+		} else if human {
+			t.Errorf("expected %s to look automated for %+v", name, automated)
+		}
+	}
+}
+
+func TestAuditStealth(t *testing.T) {
+	bm := NewBrowserManager(&Config{Headless: true})
+	if _, err := bm.AuditStealth(); err == nil {
+		t.Error("expected an error without a running browser")
+	}
+
+	if bm.findSystemBrowser() == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+	if err := bm.Launch(); err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	defer bm.Close()
+
+	signals, err := bm.AuditStealth()
+	if err != nil {
+		t.Fatalf("AuditStealth failed: %v", err)
+	}
+	if !signals[StealthWebdriver] {
+		t.Errorf("expected stealth pages to hide navigator.webdriver, got %v", signals)
+	}
+}
@@ -0,0 +1,100 @@
+package browser
+
+import (
+	"fmt"
+	"foxyapply/internal/store"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/stealth"
+)
+
+// Kinds of form field a FilledField describes
+const (
+	FieldInput       = "input"
+	FieldTextarea    = "textarea"
+	FieldCoverLetter = "cover-letter"
+	FieldSelect      = "select"
+	FieldCheckbox    = "checkbox"
+)
+
+// FilledField is a form field the filler changed, with why it chose the value
+type FilledField struct {
+	Label  string `json:"label"`
+	Kind   string `json:"kind"` // One of the Field* kinds
+	Value  string `json:"value"`
+	Reason string `json:"reason"` // The heuristic or source that chose Value
+}
+
+// reportFill passes field to report, if it's set
+func reportFill(report func(FilledField), field FilledField) {
+	if report != nil {
+		report(field)
+	}
+}
+
+// checkboxValue describes a checkbox state as a FilledField value
+func checkboxValue(checked bool) string {
+	if checked {
+		return "checked"
+	}
+	return "unchecked"
+}
+
+// readOnlyMemory looks answers up in an AnswerMemory without saving new ones
+type readOnlyMemory struct {
+	AnswerMemory
+}
+
+func (m readOnlyMemory) SaveQuestionAnswer(profileID int64, question, inputType, answer string) (*store.QuestionAnswer, error) {
+	return &store.QuestionAnswer{ProfileID: profileID, QuestionText: question, InputType: inputType, Answer: answer}, nil
+}
+
+// TestFormFill loads a saved Easy Apply form into a new page and fills it the
+// way an apply run would, without submitting anything. It returns every field
+// it filled and why. Remembered answers are used, but new ones aren't saved.
+func (bm *BrowserManager) TestFormFill(profile *store.LinkedInProfile, html string) ([]FilledField, error) {
+	browser := bm.GetBrowser()
+	if browser == nil {
+		return nil, ErrBrowserNotRunning
+	}
+
+	page, err := stealth.Page(browser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open page: %w", err)
+	}
+	defer page.Close()
+	if err := bm.timed(page).SetDocumentContent(html); err != nil {
+		return nil, fmt.Errorf("failed to load form: %w", err)
+	}
+
+	roots := easyApplyRoots(page)
+	if len(roots) == 0 {
+		// A snapshot of just the form, without the modal around it
+		body, err := bm.timed(page).Element("body")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find form: %w", err)
+		}
+		roots = []*rod.Element{body.CancelTimeout()}
+	}
+
+	bm.mu.RLock()
+	opts := fillOptions{answers: bm.answers}
+	if bm.answerMemory != nil {
+		opts.memory = readOnlyMemory{bm.answerMemory}
+	}
+	follow := bm.cfg.FollowCompany
+	bm.mu.RUnlock()
+
+	filled := []FilledField{}
+	opts.report = func(field FilledField) { filled = append(filled, field) }
+	for _, root := range roots {
+		fillCoverLetter(root, profile, opts.answers, opts.report, bm.cfg.Timing)
+		fillPhoneCountryCode(root, profile, opts.report)
+		setFollowCompany(root, follow, opts.report)
+		checkConsentBoxes(root, opts.report)
+		if err := fillInvalids(rodForm{root: root, typing: bm.cfg.Timing}, profile, opts); err != nil {
+			return filled, err
+		}
+	}
+	return filled, nil
+}
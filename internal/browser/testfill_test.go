@@ -0,0 +1,146 @@
+package browser
+
+import (
+	"foxyapply/internal/store"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// snapshotProfile is the profile the Easy Apply snapshots are filled from
+var snapshotProfile = &store.LinkedInProfile{
+	PhoneNumber:     "+1 (512) 555-0100",
+	UserCity:        "Austin",
+	UserState:       "TX",
+	YearsExperience: 7,
+	DesiredSalary:   150000,
+	ProfileURL:      "https://www.linkedin.com/in/jane",
+	CoverLetter:     "I'd love to help Acme ship.",
+}
+
+// snapshotForm builds a fakeForm from the text inputs and textareas of a
+// saved Easy Apply form in testdata
+func snapshotForm(t *testing.T, name string) *fakeForm {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("failed to parse snapshot: %v", err)
+	}
+
+	element := func(s *goquery.Selection) *fakeElement {
+		el := &fakeElement{attrs: map[string]string{}}
+		for _, a := range s.Nodes[0].Attr {
+			el.attrs[a.Key] = a.Val
+		}
+		el.label = strings.TrimSpace(doc.Find(`label[for="` + el.attrs["id"] + `"]`).Text())
+		el.value = el.attrs["value"]
+		if goquery.NodeName(s) == "textarea" {
+			el.value = s.Text()
+		}
+		return el
+	}
+
+	form := &fakeForm{}
+	doc.Find(`[id^="single-line-text-form-component-formElement-urn-li-jobs-applyformcommon-easyApplyFormElement-"]`).Each(func(_ int, s *goquery.Selection) {
+		form.inputs = append(form.inputs, element(s))
+	})
+	doc.Find("textarea").Each(func(_ int, s *goquery.Selection) {
+		form.textareas = append(form.textareas, element(s))
+	})
+	return form
+}
+
+// snapshotFills is what the heuristics fill in each snapshot's text fields
+var snapshotFills = map[string][]FilledField{
+	"easy-apply-contact.html": {
+		{Label: "Mobile phone number", Kind: FieldInput, Value: "+1 (512) 555-0100", Reason: "phone number"},
+		{Label: "City", Kind: FieldInput, Value: "Austin, TX", Reason: "city and state"},
+		{Label: "How many years of work experience do you have with Go?", Kind: FieldInput, Value: "5",
+			Reason: "years of experience, clamped to the allowed range"},
+	},
+	"easy-apply-questions.html": {
+		{Label: "What is your desired salary?", Kind: FieldInput, Value: "150000", Reason: "desired salary"},
+		{Label: "LinkedIn Profile", Kind: FieldInput, Value: "https://www.linkedin.com/in/jane", Reason: "LinkedIn profile URL"},
+		{Label: "Have you ever worked for Acme?", Kind: FieldInput, Value: "No", Reason: "never worked here before"},
+		{Label: "Rate your Kubernetes skills from 1 to 10", Kind: FieldInput, Value: "7",
+			Reason: "unrecognised number, years of experience"},
+		{Label: "Why do you want to join Acme?", Kind: FieldTextarea, Value: "I'd love to help Acme ship.", Reason: "cover letter"},
+	},
+}
+
+func TestFillInvalidsSnapshots(t *testing.T) {
+	for name, want := range snapshotFills {
+		t.Run(name, func(t *testing.T) {
+			var filled []FilledField
+			opts := fillOptions{report: func(field FilledField) { filled = append(filled, field) }}
+			if err := fillInvalids(snapshotForm(t, name), snapshotProfile, opts); err != nil {
+				t.Fatalf("fillInvalids failed: %v", err)
+			}
+			if len(filled) != len(want) {
+				t.Fatalf("expected %d filled fields, got %d: %+v", len(want), len(filled), filled)
+			}
+			for i := range want {
+				if filled[i] != want[i] {
+					t.Errorf("field %d:\n  got  %+v\n  want %+v", i, filled[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTestFormFill(t *testing.T) {
+	bm := NewBrowserManager(&Config{Headless: true})
+	if _, err := bm.TestFormFill(snapshotProfile, "<html></html>"); err == nil {
+		t.Error("expected an error without a running browser")
+	}
+
+	if bm.findSystemBrowser() == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+	if err := bm.Launch(); err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	defer bm.Close()
+
+	// Besides the text fields, the live page also gets its dropdowns and
+	// checkboxes set and the optional cover letter filled
+	extra := map[string][]FilledField{
+		"easy-apply-contact.html": {
+			{Label: "Phone country code", Kind: FieldSelect, Value: "(+1)", Reason: "phone number country code"},
+			{Label: "Follow company", Kind: FieldCheckbox, Value: "unchecked", Reason: "follow company setting"},
+			{Label: "I agree to the terms and privacy policy", Kind: FieldCheckbox, Value: "checked", Reason: "required agreement"},
+		},
+		"easy-apply-questions.html": {
+			{Label: "Cover letter", Kind: FieldCoverLetter, Value: "I'd love to help Acme ship.", Reason: "cover letter"},
+		},
+	}
+	for name, want := range snapshotFills {
+		t.Run(name, func(t *testing.T) {
+			html, err := os.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatalf("failed to read snapshot: %v", err)
+			}
+			filled, err := bm.TestFormFill(snapshotProfile, string(html))
+			if err != nil {
+				t.Fatalf("TestFormFill failed: %v", err)
+			}
+
+			got := map[FilledField]bool{}
+			for _, field := range filled {
+				got[field] = true
+			}
+			for _, field := range append(extra[name], want...) {
+				if !got[field] {
+					t.Errorf("expected %+v among %+v", field, filled)
+				}
+			}
+		})
+	}
+}
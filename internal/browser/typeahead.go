@@ -0,0 +1,70 @@
+package browser
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// typeaheadWait is how long a typeahead gets to show its suggestions
+const typeaheadWait = 2 * time.Second
+
+// isTypeahead reports whether el is an autocomplete control, such as
+// LinkedIn's city and skills fields, that only accepts a picked suggestion
+func isTypeahead(el attributer) bool {
+	if strings.EqualFold(attr(el, "role"), "combobox") {
+		return true
+	}
+	autocomplete := strings.ToLower(strings.TrimSpace(attr(el, "aria-autocomplete")))
+	return autocomplete != "" && autocomplete != "none"
+}
+
+// pickSuggestion returns the first option whose text contains value,
+// ignoring case. Failing that it tries the part of value before the first
+// comma, so "Austin, TX" still finds "Austin, Texas, United States". It
+// returns nil when no option matches, rather than guess an unrelated one.
+func pickSuggestion(options []FormElement, value string) (FormElement, string) {
+	texts := make([]string, len(options))
+	for i, option := range options {
+		text, _ := option.Text()
+		texts[i] = strings.TrimSpace(text)
+	}
+
+	wants := []string{value}
+	if before, _, ok := strings.Cut(value, ","); ok {
+		wants = append(wants, before)
+	}
+	for _, want := range wants {
+		want = strings.ToLower(strings.TrimSpace(want))
+		if want == "" {
+			continue
+		}
+		for i, text := range texts {
+			if strings.Contains(strings.ToLower(text), want) {
+				return options[i], text
+			}
+		}
+	}
+	return nil, ""
+}
+
+// confirmTypeahead picks a suggestion for the value just typed into el,
+// since typeaheads reject typed text that wasn't chosen from their list.
+// Without a listbox, or when no suggestion matches, it presses Enter
+// instead. It returns the suggestion picked, or "" if Enter was pressed.
+func confirmTypeahead(form FormContainer, el FormElement, label, value string) (string, error) {
+	options, err := form.Suggestions(el)
+	if err != nil {
+		return "", err
+	}
+	option, text := pickSuggestion(options, value)
+	if option == nil {
+		log.Printf("No matching suggestion for '%s', pressing Enter", label)
+		return "", el.PressEnter()
+	}
+	if err := option.Click(); err != nil {
+		return "", err
+	}
+	log.Printf("Selected suggestion '%s' for '%s'", text, label)
+	return text, nil
+}
@@ -0,0 +1,106 @@
+package browser
+
+import (
+	"foxyapply/internal/store"
+	"testing"
+)
+
+func TestIsTypeahead(t *testing.T) {
+	tests := []struct {
+		attrs map[string]string
+		want  bool
+	}{
+		{map[string]string{"role": "combobox"}, true},
+		{map[string]string{"aria-autocomplete": "list"}, true},
+		{map[string]string{"aria-autocomplete": "both"}, true},
+		{map[string]string{"aria-autocomplete": "none"}, false},
+		{map[string]string{"type": "text"}, false},
+	}
+	for _, tt := range tests {
+		if got := isTypeahead(&fakeElement{attrs: tt.attrs}); got != tt.want {
+			t.Errorf("isTypeahead(%v) = %v, want %v", tt.attrs, got, tt.want)
+		}
+	}
+}
+
+func TestPickSuggestion(t *testing.T) {
+	options := func(texts ...string) []FormElement {
+		els := make([]*fakeElement, len(texts))
+		for i, text := range texts {
+			els[i] = &fakeElement{value: text}
+		}
+		return toFormElements(els)
+	}
+
+	tests := []struct {
+		options []FormElement
+		value   string
+		want    string
+	}{
+		{options("Go (Programming Language)", "Golang"), "golang", "Golang"},
+		{options("Houston, Texas, United States", "Austin, Texas, United States"), "Austin, TX", "Austin, Texas, United States"},
+		{options("Kubernetes", "Docker"), "Terraform", ""},
+	}
+	for _, tt := range tests {
+		if _, got := pickSuggestion(tt.options, tt.value); got != tt.want {
+			t.Errorf("pickSuggestion(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+	if option, _ := pickSuggestion(nil, "Austin"); option != nil {
+		t.Errorf("expected no option without suggestions, got %v", option)
+	}
+}
+
+func TestFillInvalidsConfirmsTypeaheads(t *testing.T) {
+	houston := &fakeElement{value: "Houston, Texas, United States"}
+	austin := &fakeElement{value: "Austin, Texas, United States"}
+	city := &fakeElement{
+		attrs: map[string]string{"required": "", "role": "combobox"},
+		label: "City",
+	}
+	form := &fakeForm{inputs: []*fakeElement{city}, suggestions: []*fakeElement{houston, austin}}
+
+	var filled []FilledField
+	report := func(field FilledField) { filled = append(filled, field) }
+	profile := &store.LinkedInProfile{UserCity: "Austin", UserState: "TX"}
+	if err := fillInvalids(form, profile, fillOptions{report: report}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if austin.clicks != 1 || houston.clicks != 0 || city.enters != 0 {
+		t.Errorf("expected the matching suggestion to be clicked, got austin %d, houston %d, enters %d", austin.clicks, houston.clicks, city.enters)
+	}
+	if len(filled) != 1 || filled[0].Value != "Austin, Texas, United States" {
+		t.Errorf("expected the picked suggestion to be reported, got %+v", filled)
+	}
+
+	// Without a listbox, Enter confirms the typed value
+	autocomplete := &fakeElement{
+		attrs: map[string]string{"required": "", "aria-autocomplete": "list"},
+		label: "City",
+	}
+	form = &fakeForm{inputs: []*fakeElement{autocomplete}}
+	if err := fillInvalids(form, profile, fillOptions{}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if autocomplete.enters != 1 || len(autocomplete.typed) != 1 {
+		t.Errorf("expected Enter after typing, got %d enters and typed %q", autocomplete.enters, autocomplete.typed)
+	}
+
+	// Unrelated suggestions are left alone and the typed value stands
+	dallas := &fakeElement{value: "Dallas, Texas, United States"}
+	city = &fakeElement{
+		attrs: map[string]string{"required": "", "role": "combobox"},
+		label: "City",
+	}
+	filled = nil
+	form = &fakeForm{inputs: []*fakeElement{city}, suggestions: []*fakeElement{houston, dallas}}
+	if err := fillInvalids(form, profile, fillOptions{report: report}); err != nil {
+		t.Fatalf("fillInvalids failed: %v", err)
+	}
+	if houston.clicks != 0 || dallas.clicks != 0 || city.enters != 1 {
+		t.Errorf("expected Enter instead of a non-matching suggestion, got houston %d, dallas %d, enters %d", houston.clicks, dallas.clicks, city.enters)
+	}
+	if len(filled) != 1 || filled[0].Value != "Austin, TX" {
+		t.Errorf("expected the typed value to be reported, got %+v", filled)
+	}
+}
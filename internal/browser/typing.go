@@ -0,0 +1,69 @@
+package browser
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// Humanized typing defaults used when Timing leaves them unset
+const (
+	DefaultMinKeyDelay = 40 * time.Millisecond
+	DefaultMaxKeyDelay = 160 * time.Millisecond
+)
+
+// Timing controls how text is typed into fields. The zero value types
+// each string at once.
+type Timing struct {
+	HumanTyping bool          // Type character by character with random pauses, like a person
+	MinKeyDelay time.Duration // Shortest pause after a character (0 = DefaultMinKeyDelay)
+	MaxKeyDelay time.Duration // Longest pause after a character (0 = DefaultMaxKeyDelay)
+}
+
+// keyDelays returns the pause bounds with defaults applied, swapped if
+// given the wrong way round
+func (t Timing) keyDelays() (min, max time.Duration) {
+	min, max = t.MinKeyDelay, t.MaxKeyDelay
+	if min <= 0 {
+		min = DefaultMinKeyDelay
+	}
+	if max <= 0 {
+		max = DefaultMaxKeyDelay
+	}
+	if max < min {
+		min, max = max, min
+	}
+	return min, max
+}
+
+// keyDelay returns a random pause between two characters
+func (t Timing) keyDelay(rng *rand.Rand) time.Duration {
+	min, max := t.keyDelays()
+	return min + time.Duration(rng.Int63n(int64(max-min)+1))
+}
+
+// typeText types text into el. With Timing.HumanTyping off it is inserted
+// in one go; otherwise see typeKeys.
+func typeText(el *rod.Element, text string, timing Timing) error {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return typeKeys(text, timing, rng, time.Sleep, el.Input)
+}
+
+// typeKeys passes text to input, either whole or, with HumanTyping on, one
+// character at a time with a random pause after each but the last
+func typeKeys(text string, timing Timing, rng *rand.Rand, sleep func(time.Duration), input func(string) error) error {
+	if !timing.HumanTyping || text == "" {
+		return input(text)
+	}
+	chars := []rune(text)
+	for i, r := range chars {
+		if err := input(string(r)); err != nil {
+			return err
+		}
+		if i < len(chars)-1 {
+			sleep(timing.keyDelay(rng))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTypeKeysInstant(t *testing.T) {
+	var inputs []string
+	sleep := func(time.Duration) { t.Error("expected no pauses without human typing") }
+	err := typeKeys("hello@example.com", Timing{}, rand.New(rand.NewSource(1)), sleep, func(s string) error {
+		inputs = append(inputs, s)
+		return nil
+	})
+	if err != nil || fmt.Sprint(inputs) != "[hello@example.com]" {
+		t.Errorf("expected the whole string at once, got %q, %v", inputs, err)
+	}
+}
+
+func TestTypeKeysHuman(t *testing.T) {
+	timing := Timing{HumanTyping: true, MinKeyDelay: 20 * time.Millisecond, MaxKeyDelay: 50 * time.Millisecond}
+	var inputs []string
+	var pauses []time.Duration
+	err := typeKeys("Zoë 5", timing, rand.New(rand.NewSource(1)), func(d time.Duration) {
+		pauses = append(pauses, d)
+	}, func(s string) error {
+		inputs = append(inputs, s)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("typeKeys failed: %v", err)
+	}
+	if fmt.Sprintf("%q", inputs) != `["Z" "o" "ë" " " "5"]` {
+		t.Errorf("expected one input per character, got %q", inputs)
+	}
+	if len(pauses) != len(inputs)-1 {
+		t.Errorf("expected a pause between each character, got %d", len(pauses))
+	}
+	for _, d := range pauses {
+		if d < timing.MinKeyDelay || d > timing.MaxKeyDelay {
+			t.Errorf("pause %v outside [%v, %v]", d, timing.MinKeyDelay, timing.MaxKeyDelay)
+		}
+	}
+
+	// A failed character stops typing
+	failed := errors.New("detached")
+	calls := 0
+	err = typeKeys("abc", timing, rand.New(rand.NewSource(1)), func(time.Duration) {}, func(string) error {
+		calls++
+		return failed
+	})
+	if !errors.Is(err, failed) || calls != 1 {
+		t.Errorf("expected to stop at the first error, got %v after %d calls", err, calls)
+	}
+}
+
+func TestTimingKeyDelays(t *testing.T) {
+	tests := []struct {
+		timing   Timing
+		min, max time.Duration
+	}{
+		{Timing{}, DefaultMinKeyDelay, DefaultMaxKeyDelay},
+		{Timing{MinKeyDelay: 10 * time.Millisecond, MaxKeyDelay: 20 * time.Millisecond}, 10 * time.Millisecond, 20 * time.Millisecond},
+		{Timing{MinKeyDelay: 20 * time.Millisecond, MaxKeyDelay: 10 * time.Millisecond}, 10 * time.Millisecond, 20 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if min, max := tt.timing.keyDelays(); min != tt.min || max != tt.max {
+			t.Errorf("%+v.keyDelays() = %v, %v, want %v, %v", tt.timing, min, max, tt.min, tt.max)
+		}
+	}
+}
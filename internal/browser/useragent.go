@@ -0,0 +1,60 @@
+package browser
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DefaultUserAgent is the User-Agent sent when Config.UserAgent is unset.
+// It reads like stable desktop Chrome on this OS at the major version of
+// LatestStableVersion, without the "HeadlessChrome" token headless Chrome
+// for Testing reports.
+var DefaultUserAgent = chromeUserAgent(runtime.GOOS, LatestStableVersion)
+
+// userAgentPlatforms maps GOOS to the platform token in Chrome's
+// User-Agent and the matching navigator.platform
+var userAgentPlatforms = map[string][2]string{
+	"darwin":  {"Macintosh; Intel Mac OS X 10_15_7", "MacIntel"},
+	"linux":   {"X11; Linux x86_64", "Linux x86_64"},
+	"windows": {"Windows NT 10.0; Win64; x64", "Win32"},
+}
+
+// chromeUserAgent returns desktop Chrome's User-Agent for goos and a full
+// Chrome version. Like Chrome it reports only the major version.
+func chromeUserAgent(goos, version string) string {
+	platform, ok := userAgentPlatforms[goos]
+	if !ok {
+		platform = userAgentPlatforms["windows"]
+	}
+	major, _, _ := strings.Cut(version, ".")
+	return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s.0.0.0 Safari/537.36", platform[0], major)
+}
+
+// userAgentOverride returns the override sent to each new page: the
+// configured User-Agent, or DefaultUserAgent along with this OS's
+// navigator.platform
+func (bm *BrowserManager) userAgentOverride() *proto.NetworkSetUserAgentOverride {
+	override := &proto.NetworkSetUserAgentOverride{
+		UserAgent:      strings.TrimSpace(bm.cfg.UserAgent),
+		AcceptLanguage: acceptLanguage(bm.locale()),
+	}
+	if override.UserAgent == "" {
+		override.UserAgent = DefaultUserAgent
+		if platform, ok := userAgentPlatforms[runtime.GOOS]; ok {
+			override.Platform = platform[1]
+		}
+	}
+	return override
+}
+
+// overrideUserAgent sets page's User-Agent with userAgentOverride. Failing
+// to set it is logged, not fatal.
+func (bm *BrowserManager) overrideUserAgent(page *rod.Page) {
+	if err := page.SetUserAgent(bm.userAgentOverride()); err != nil {
+		fmt.Printf("⚠️ Could not override the User-Agent: %v\n", err)
+	}
+}
@@ -0,0 +1,73 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestChromeUserAgent(t *testing.T) {
+	want := "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36"
+	if got := chromeUserAgent("darwin", "131.0.6778.85"); got != want {
+		t.Errorf("chromeUserAgent() = %q, want %q", got, want)
+	}
+	if got := chromeUserAgent("plan9", "131.0.6778.85"); !strings.Contains(got, "Windows NT 10.0") {
+		t.Errorf("expected unknown systems to look like Windows, got %q", got)
+	}
+	if strings.Contains(DefaultUserAgent, "Headless") || !strings.Contains(DefaultUserAgent, "Chrome/") {
+		t.Errorf("expected a desktop Chrome User-Agent, got %q", DefaultUserAgent)
+	}
+}
+
+func TestNewPageOverridesUserAgent(t *testing.T) {
+	client := &fakeCDP{params: map[string]interface{}{}, results: map[string]string{
+		"Target.createTarget":   `{"targetId": "tab-1"}`,
+		"Target.attachToTarget": `{"sessionId": "session-1"}`,
+	}}
+	bm := NewBrowserManager(&Config{UserAgent: "Mozilla/5.0 FoxyTest", Locale: "de-DE"})
+	bm.browser = rod.New().Client(client).MustConnect()
+	if _, err := bm.NewPage(); err != nil {
+		t.Fatalf("failed to open page: %v", err)
+	}
+
+	client.mu.Lock()
+	override, ok := client.params["Network.setUserAgentOverride"].(proto.NetworkSetUserAgentOverride)
+	client.mu.Unlock()
+	if !ok {
+		t.Fatalf("expected the new page's User-Agent to be overridden, calls %v", client.calls)
+	}
+	if override.UserAgent != "Mozilla/5.0 FoxyTest" || override.AcceptLanguage != "de-DE,de;q=0.9" {
+		t.Errorf("unexpected override %+v", override)
+	}
+
+	// Without a configured one, pages get the default
+	if got := NewBrowserManager(nil).userAgentOverride(); got.UserAgent != DefaultUserAgent {
+		t.Errorf("expected DefaultUserAgent, got %q", got.UserAgent)
+	}
+}
+
+func TestNewPageUserAgentInChrome(t *testing.T) {
+	bm := NewBrowserManager(&Config{Headless: true})
+	bin := bm.findSystemBrowser()
+	if bin == "" {
+		t.Skip("no Chrome/Chromium installed")
+	}
+	u, err := launcher.New().Bin(bin).Headless(true).NoSandbox(true).Launch()
+	if err != nil {
+		t.Skipf("failed to launch browser: %v", err)
+	}
+	bm.browser = rod.New().ControlURL(u).MustConnect()
+	defer bm.Close()
+
+	page, err := bm.NewPage()
+	if err != nil {
+		t.Fatalf("failed to open page: %v", err)
+	}
+	page.MustNavigate("about:blank")
+	if got := page.MustEval(`() => navigator.userAgent`).String(); got != DefaultUserAgent {
+		t.Errorf("expected navigator.userAgent %q, got %q", DefaultUserAgent, got)
+	}
+}
@@ -0,0 +1,398 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Application statuses
+const (
+	ApplicationStatusApplied = "applied"
+	ApplicationStatusFailed  = "failed"
+	ApplicationStatusSkipped = "skipped"
+
+	// ApplicationStatusExternalRedirect marks jobs whose Easy Apply button
+	// left LinkedIn for a third-party application form
+	ApplicationStatusExternalRedirect = "external-redirect"
+)
+
+// Reasons a job was skipped
+const (
+	// SkipReasonExternal marks jobs that are applied to on the company's
+	// own site rather than through Easy Apply
+	SkipReasonExternal = "external"
+	// SkipReasonBelowSalaryFloor marks jobs whose listed salary tops out
+	// below the profile's desired salary
+	SkipReasonBelowSalaryFloor = "below-salary-floor"
+	// SkipReasonPosterPreference marks jobs whose hiring team section, or
+	// lack of one, doesn't match the configured poster preference
+	SkipReasonPosterPreference = "poster-preference"
+	// SkipReasonCompanyLimit marks jobs at a company that already got the
+	// configured number of applications
+	SkipReasonCompanyLimit = "company-limit"
+	// SkipReasonAlreadyApplied marks jobs LinkedIn shows as applied to,
+	// such as ones the user applied to by hand
+	SkipReasonAlreadyApplied = "already-applied"
+	// SkipReasonTitleExcluded marks jobs whose title contains one of the
+	// profile's excluded keywords
+	SkipReasonTitleExcluded = "title-excluded"
+)
+
+// Application is a job the bot attempted to apply to
+type Application struct {
+	ID          int64     `json:"id"`
+	ProfileID   int64     `json:"profileId"`
+	JobID       int       `json:"jobId"`
+	Title       string    `json:"title"`
+	Company     string    `json:"company"`
+	Status      string    `json:"status"`
+	Description string    `json:"description,omitempty"` // Job description text, when it could be scraped
+	Reason      string    `json:"reason,omitempty"`      // Why a skipped job was skipped, e.g. SkipReasonExternal
+	AppliedAt   time.Time `json:"appliedAt"`
+}
+
+// JobURL returns the LinkedIn page for a job ID
+func JobURL(jobID int) string {
+	return fmt.Sprintf("https://www.linkedin.com/jobs/view/%d", jobID)
+}
+
+// applicationColumns lists the columns read by scanApplication, in order
+const applicationColumns = `id, profile_id, job_id, title, company, status, description, reason, applied_at`
+
+// scanApplication reads a row selected with applicationColumns
+func scanApplication(row rowScanner) (Application, error) {
+	var app Application
+	err := row.Scan(&app.ID, &app.ProfileID, &app.JobID, &app.Title, &app.Company, &app.Status, &app.Description, &app.Reason, &app.AppliedAt)
+	return app, err
+}
+
+// RecordApplication stores an application attempt. Status defaults to
+// ApplicationStatusApplied when empty.
+func (s *Store) RecordApplication(app Application) (*Application, error) {
+	db, done := s.conn()
+	defer done()
+	if app.Status == "" {
+		app.Status = ApplicationStatusApplied
+	}
+
+	result, err := db.Exec(
+		"INSERT INTO applications (profile_id, job_id, title, company, status, description, reason) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		app.ProfileID, app.JobID, app.Title, app.Company, app.Status, app.Description, app.Reason,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record application: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application id: %w", err)
+	}
+
+	recorded, err := scanApplication(db.QueryRow(
+		`SELECT `+applicationColumns+` FROM applications WHERE id = ?`, id,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	return &recorded, nil
+}
+
+// ListApplications returns a page of a profile's applications, newest
+// first. An empty status returns every status; a limit <= 0 returns all rows.
+func (s *Store) ListApplications(profileID int64, status string, limit, offset int) ([]Application, error) {
+	db, done := s.conn()
+	defer done()
+	query := `SELECT ` + applicationColumns + ` FROM applications WHERE profile_id = ?`
+	args := []any{profileID}
+
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+
+	query += ` ORDER BY applied_at DESC, id DESC`
+	if limit > 0 {
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, limit, max(offset, 0))
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	return collectApplications(rows)
+}
+
+// ListFailedApplications returns the latest attempt at each job whose most
+// recent attempt failed and that was never applied to, oldest first, so
+// the jobs can be retried
+func (s *Store) ListFailedApplications(profileID int64) ([]Application, error) {
+	db, done := s.conn()
+	defer done()
+	rows, err := db.Query(
+		`SELECT `+applicationColumns+` FROM applications a
+		 WHERE profile_id = ? AND status = ?
+		   AND NOT EXISTS (
+			SELECT 1 FROM applications b
+			WHERE b.profile_id = a.profile_id AND b.job_id = a.job_id
+			  AND (b.status = ? OR b.id > a.id)
+		   )
+		 ORDER BY applied_at, id`,
+		profileID, ApplicationStatusFailed, ApplicationStatusApplied,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed applications: %w", err)
+	}
+	return collectApplications(rows)
+}
+
+// SkippedJobIDs returns the IDs of a profile's jobs recorded as skipped for
+// reason, so later runs can leave them out without visiting them again
+func (s *Store) SkippedJobIDs(profileID int64, reason string) ([]int, error) {
+	db, done := s.conn()
+	defer done()
+	rows, err := db.Query(
+		`SELECT DISTINCT job_id FROM applications
+		 WHERE profile_id = ? AND status = ? AND reason = ?
+		 ORDER BY job_id`,
+		profileID, ApplicationStatusSkipped, reason,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skipped jobs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan skipped job: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skipped jobs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// CountApplications returns how many applications a profile submitted at
+// or after since, for showing quota usage without loading the rows.
+// Skipped and failed attempts aren't counted; a zero since counts them all.
+func (s *Store) CountApplications(profileID int64, since time.Time) (int, error) {
+	db, done := s.conn()
+	defer done()
+	var count int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM applications WHERE profile_id = ? AND status = ? AND applied_at >= ?",
+		profileID, ApplicationStatusApplied, since.UTC().Format("2006-01-02 15:04:05"),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count applications: %w", err)
+	}
+	return count, nil
+}
+
+// CompanyApplicationCounts returns how many applications the profile
+// submitted to each company on or after since, keyed by company name as
+// recorded. Applications without a company are left out.
+func (s *Store) CompanyApplicationCounts(profileID int64, since time.Time) (map[string]int, error) {
+	db, done := s.conn()
+	defer done()
+	rows, err := db.Query(
+		`SELECT company, COUNT(*) FROM applications
+		 WHERE profile_id = ? AND status = ? AND applied_at >= ? AND company != ''
+		 GROUP BY company`,
+		profileID, ApplicationStatusApplied, since.UTC().Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count applications by company: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var company string
+		var count int
+		if err := rows.Scan(&company, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan company count: %w", err)
+		}
+		counts[company] = count
+	}
+	return counts, rows.Err()
+}
+
+// UpdateApplicationStatus changes the status of a recorded application
+func (s *Store) UpdateApplicationStatus(id int64, status string) error {
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec("UPDATE applications SET status = ? WHERE id = ?", status, id)
+	if err != nil {
+		return fmt.Errorf("failed to update application status: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("application not found: %d", id)
+	}
+	return nil
+}
+
+// ensureApplicationsFTS creates the FTS5 index over application titles and
+// companies if it's missing. It runs on every start rather than as a
+// migration because whether it can exist depends on the SQLite build, so a
+// database first opened without FTS5 still gets the index later.
+func (s *Store) ensureApplicationsFTS() error {
+	db, done := s.conn()
+	defer done()
+	if s.hasApplicationsFTS() {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := createApplicationsFTS(tx); err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit search index: %w", err)
+	}
+	return nil
+}
+
+// createApplicationsFTS creates an FTS5 index over application titles and
+// companies, kept in sync by triggers. It is a no-op when the SQLite build
+// lacks FTS5, in which case SearchApplications falls back to LIKE queries.
+func createApplicationsFTS(tx *sql.Tx) error {
+	var available int
+	if err := tx.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available); err != nil || available == 0 {
+		return nil
+	}
+
+	return execSQL(
+		`CREATE VIRTUAL TABLE IF NOT EXISTS applications_fts USING fts5(
+			title, company, content='applications', content_rowid='id'
+		)`,
+		`CREATE TRIGGER IF NOT EXISTS applications_fts_insert AFTER INSERT ON applications BEGIN
+			INSERT INTO applications_fts(rowid, title, company) VALUES (new.id, new.title, new.company);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS applications_fts_delete AFTER DELETE ON applications BEGIN
+			INSERT INTO applications_fts(applications_fts, rowid, title, company) VALUES ('delete', old.id, old.title, old.company);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS applications_fts_update AFTER UPDATE ON applications BEGIN
+			INSERT INTO applications_fts(applications_fts, rowid, title, company) VALUES ('delete', old.id, old.title, old.company);
+			INSERT INTO applications_fts(rowid, title, company) VALUES (new.id, new.title, new.company);
+		END`,
+		`INSERT INTO applications_fts(applications_fts) VALUES ('rebuild')`,
+	)(tx)
+}
+
+// hasApplicationsFTS reports whether the FTS5 index was created
+func (s *Store) hasApplicationsFTS() bool {
+	db, done := s.conn()
+	defer done()
+	var count int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'applications_fts'",
+	).Scan(&count)
+	return err == nil && count > 0
+}
+
+// SearchApplications finds a profile's applications whose title or company
+// matches query. Every word in the query must match (as a prefix when FTS5
+// is available, as a substring otherwise).
+func (s *Store) SearchApplications(profileID int64, query string) ([]Application, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return []Application{}, nil
+	}
+
+	if s.hasApplicationsFTS() {
+		return s.searchApplicationsFTS(profileID, terms)
+	}
+	return s.searchApplicationsLike(profileID, terms)
+}
+
+// searchApplicationsFTS searches using the FTS5 index
+func (s *Store) searchApplicationsFTS(profileID int64, terms []string) ([]Application, error) {
+	db, done := s.conn()
+	defer done()
+	// Quote each term so user input can't inject FTS query syntax
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+
+	rows, err := db.Query(
+		`SELECT `+applicationColumns+` FROM applications
+		 WHERE profile_id = ? AND id IN (
+			SELECT rowid FROM applications_fts WHERE applications_fts MATCH ?
+		 )
+		 ORDER BY applied_at DESC, id DESC`,
+		profileID, strings.Join(quoted, " "),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search applications: %w", err)
+	}
+	return collectApplications(rows)
+}
+
+// searchApplicationsLike searches with LIKE for builds without FTS5
+func (s *Store) searchApplicationsLike(profileID int64, terms []string) ([]Application, error) {
+	db, done := s.conn()
+	defer done()
+	where := []string{"profile_id = ?"}
+	args := []any{profileID}
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		where = append(where, `(title LIKE ? ESCAPE '\' OR company LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	rows, err := db.Query(
+		`SELECT `+applicationColumns+` FROM applications
+		 WHERE `+strings.Join(where, " AND ")+`
+		 ORDER BY applied_at DESC, id DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search applications: %w", err)
+	}
+	return collectApplications(rows)
+}
+
+// escapeLike escapes LIKE wildcards so they match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// collectApplications scans and closes rows
+func collectApplications(rows *sql.Rows) ([]Application, error) {
+	defer rows.Close()
+
+	applications := []Application{}
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan application: %w", err)
+		}
+		applications = append(applications, app)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applications: %w", err)
+	}
+
+	return applications, nil
+}
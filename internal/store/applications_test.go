@@ -0,0 +1,458 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func seedApplications(t *testing.T, store *Store) *LinkedInProfile {
+	t.Helper()
+
+	profile, err := store.CreateLinkedInProfile("apps@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	for _, app := range []Application{
+		{ProfileID: profile.ID, JobID: 1, Title: "Software Engineer", Company: "Google"},
+		{ProfileID: profile.ID, JobID: 2, Title: "Backend Developer", Company: "Googly Eyes Inc"},
+		{ProfileID: profile.ID, JobID: 3, Title: "Site Reliability Engineer", Company: "Acme 100%"},
+	} {
+		if _, err := store.RecordApplication(app); err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+	}
+
+	return profile
+}
+
+func TestRecordApplication(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("apps@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	app, err := store.RecordApplication(Application{ProfileID: profile.ID, JobID: 42, Title: "Engineer", Description: "Build things"})
+	if err != nil {
+		t.Fatalf("failed to record application: %v", err)
+	}
+	if app.ID == 0 || app.JobID != 42 || app.Description != "Build things" {
+		t.Errorf("unexpected application: %+v", app)
+	}
+	if app.Status != ApplicationStatusApplied {
+		t.Errorf("expected default status %q, got %q", ApplicationStatusApplied, app.Status)
+	}
+	if app.AppliedAt.IsZero() {
+		t.Error("expected appliedAt to be set")
+	}
+}
+
+func TestSearchApplications(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile := seedApplications(t, store)
+
+	search := func(query string) []Application {
+		t.Helper()
+		results, err := store.SearchApplications(profile.ID, query)
+		if err != nil {
+			t.Fatalf("failed to search %q: %v", query, err)
+		}
+		return results
+	}
+
+	if got := search("google"); len(got) != 1 || got[0].JobID != 1 {
+		t.Errorf("expected only job 1 for 'google', got %+v", got)
+	}
+	if got := search("goog"); len(got) != 2 {
+		t.Errorf("expected prefix 'goog' to match 2 applications, got %d", len(got))
+	}
+	if got := search("engineer google"); len(got) != 1 {
+		t.Errorf("expected all terms to be required, got %d results", len(got))
+	}
+	if got := search(`"unbalanced`); len(got) != 0 {
+		t.Errorf("expected no results for quoted input, got %d", len(got))
+	}
+	if got := search("   "); len(got) != 0 {
+		t.Errorf("expected empty query to return nothing, got %d", len(got))
+	}
+	if got, err := store.SearchApplications(profile.ID+1, "google"); err != nil || len(got) != 0 {
+		t.Errorf("expected other profiles to be excluded, got %d (%v)", len(got), err)
+	}
+
+	// The index follows updates and deletes
+	db, done := store.DB()
+	defer done()
+	if _, err := db.Exec("UPDATE applications SET company = 'Alphabet' WHERE job_id = 1"); err != nil {
+		t.Fatalf("failed to update application: %v", err)
+	}
+	if got := search("alphabet"); len(got) != 1 {
+		t.Errorf("expected updated company to be searchable, got %d", len(got))
+	}
+	if _, err := db.Exec("DELETE FROM applications WHERE job_id = 1"); err != nil {
+		t.Fatalf("failed to delete application: %v", err)
+	}
+	if got := search("alphabet"); len(got) != 0 {
+		t.Errorf("expected deleted application to be gone from the index, got %d", len(got))
+	}
+}
+
+func TestApplicationsFTSCreatedOnStart(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if !store.hasApplicationsFTS() {
+		t.Skip("SQLite build lacks FTS5")
+	}
+	profile := seedApplications(t, store)
+
+	// A database last opened by a build without FTS5 has no index
+	db, done := store.DB()
+	defer done()
+	if _, err := db.Exec(`DROP TABLE applications_fts`); err != nil {
+		t.Fatalf("failed to drop index: %v", err)
+	}
+	for _, trigger := range []string{"insert", "delete", "update"} {
+		if _, err := db.Exec(`DROP TRIGGER applications_fts_` + trigger); err != nil {
+			t.Fatalf("failed to drop %s trigger: %v", trigger, err)
+		}
+	}
+	store.Close()
+
+	reopened, err := NewWithPath(store.path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+	if !reopened.hasApplicationsFTS() {
+		t.Fatal("expected the index to be created on start")
+	}
+	if got, err := reopened.searchApplicationsFTS(profile.ID, []string{"google"}); err != nil || len(got) != 1 {
+		t.Errorf("expected the new index to cover existing applications, got %d (%v)", len(got), err)
+	}
+}
+
+func TestSearchApplicationsLikeFallback(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile := seedApplications(t, store)
+
+	results, err := store.searchApplicationsLike(profile.ID, []string{"oogl"})
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected substring match on 2 applications, got %d", len(results))
+	}
+
+	// Wildcards in the query match literally
+	results, err = store.searchApplicationsLike(profile.ID, []string{"100%"})
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(results) != 1 || results[0].JobID != 3 {
+		t.Errorf("expected literal '%%' match on job 3, got %+v", results)
+	}
+}
+
+func TestListApplications(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile := seedApplications(t, store)
+	if _, err := store.RecordApplication(Application{
+		ProfileID: profile.ID, JobID: 4, Title: "Data Engineer", Status: ApplicationStatusFailed,
+	}); err != nil {
+		t.Fatalf("failed to record application: %v", err)
+	}
+
+	all, err := store.ListApplications(profile.ID, "", 0, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("expected 4 applications, got %d", len(all))
+	}
+	if all[0].JobID != 4 {
+		t.Errorf("expected newest application first, got job %d", all[0].JobID)
+	}
+
+	failed, err := store.ListApplications(profile.ID, ApplicationStatusFailed, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if len(failed) != 1 || failed[0].JobID != 4 {
+		t.Errorf("expected only the failed application, got %+v", failed)
+	}
+
+	page1, err := store.ListApplications(profile.ID, "", 2, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	page2, err := store.ListApplications(profile.ID, "", 2, 2)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if len(page1) != 2 || len(page2) != 2 {
+		t.Fatalf("expected two pages of 2, got %d and %d", len(page1), len(page2))
+	}
+	if page1[1].ID == page2[0].ID {
+		t.Error("expected pages not to overlap")
+	}
+
+	empty, err := store.ListApplications(profile.ID, "", 2, 10)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("expected an empty, non-nil page past the end, got %v", empty)
+	}
+}
+
+func TestListFailedApplications(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("retry@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	record := func(jobID int, status string) *Application {
+		t.Helper()
+		app, err := store.RecordApplication(Application{ProfileID: profile.ID, JobID: jobID, Status: status})
+		if err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+		return app
+	}
+
+	record(1, ApplicationStatusFailed) // Still failing
+	record(2, ApplicationStatusFailed) // Applied on a later attempt
+	record(2, ApplicationStatusApplied)
+	record(3, ApplicationStatusApplied) // Applied, then a stray failed attempt
+	record(3, ApplicationStatusFailed)
+	record(4, ApplicationStatusFailed) // Failed twice; only the latest is listed
+	latest := record(4, ApplicationStatusFailed)
+	record(5, ApplicationStatusSkipped)
+
+	failed, err := store.ListFailedApplications(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to list failed applications: %v", err)
+	}
+	if len(failed) != 2 || failed[0].JobID != 1 || failed[1].ID != latest.ID {
+		t.Fatalf("expected jobs 1 and 4 to be retryable, got %+v", failed)
+	}
+
+	// A successful retry takes the job off the list
+	if err := store.UpdateApplicationStatus(failed[0].ID, ApplicationStatusApplied); err != nil {
+		t.Fatalf("failed to update application status: %v", err)
+	}
+	failed, err = store.ListFailedApplications(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to list failed applications: %v", err)
+	}
+	if len(failed) != 1 || failed[0].JobID != 4 {
+		t.Errorf("expected only job 4 to remain, got %+v", failed)
+	}
+
+	if err := store.UpdateApplicationStatus(9999, ApplicationStatusApplied); err == nil {
+		t.Error("expected error updating a missing application")
+	}
+}
+
+func TestSkippedJobIDs(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("skipped@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	for _, app := range []Application{
+		{ProfileID: profile.ID, JobID: 3, Status: ApplicationStatusSkipped, Reason: SkipReasonExternal},
+		{ProfileID: profile.ID, JobID: 1, Status: ApplicationStatusSkipped, Reason: SkipReasonExternal},
+		{ProfileID: profile.ID, JobID: 1, Status: ApplicationStatusSkipped, Reason: SkipReasonExternal},
+		{ProfileID: profile.ID, JobID: 2, Status: ApplicationStatusSkipped}, // No Easy Apply button
+		{ProfileID: profile.ID, JobID: 4, Status: ApplicationStatusExternalRedirect},
+	} {
+		if _, err := store.RecordApplication(app); err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+	}
+
+	ids, err := store.SkippedJobIDs(profile.ID, SkipReasonExternal)
+	if err != nil {
+		t.Fatalf("failed to list skipped jobs: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("expected jobs [1 3], got %v", ids)
+	}
+
+	apps, err := store.ListApplications(profile.ID, ApplicationStatusSkipped, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	reasons := map[string]int{}
+	for _, app := range apps {
+		reasons[app.Reason]++
+	}
+	if reasons[SkipReasonExternal] != 3 || reasons[""] != 1 {
+		t.Errorf("expected reasons to round-trip, got %v", reasons)
+	}
+}
+
+func TestCountApplications(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile := seedApplications(t, store)
+	other, err := store.CreateLinkedInProfile("other@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	for _, app := range []Application{
+		{ProfileID: profile.ID, JobID: 4, Status: ApplicationStatusSkipped, Reason: SkipReasonExternal},
+		{ProfileID: profile.ID, JobID: 5, Status: ApplicationStatusFailed},
+		{ProfileID: other.ID, JobID: 1},
+	} {
+		if _, err := store.RecordApplication(app); err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+	}
+
+	// Backdate job 1 to last month
+	db, done := store.DB()
+	defer done()
+	if _, err := db.Exec(
+		"UPDATE applications SET applied_at = ? WHERE profile_id = ? AND job_id = 1",
+		time.Now().UTC().AddDate(0, -1, 0).Format("2006-01-02 15:04:05"), profile.ID,
+	); err != nil {
+		t.Fatalf("failed to backdate application: %v", err)
+	}
+
+	count := func(profileID int64, since time.Time) int {
+		t.Helper()
+		n, err := store.CountApplications(profileID, since)
+		if err != nil {
+			t.Fatalf("failed to count applications: %v", err)
+		}
+		return n
+	}
+
+	if n := count(profile.ID, time.Time{}); n != 3 {
+		t.Errorf("expected 3 submitted applications in total, got %d", n)
+	}
+	if n := count(profile.ID, time.Now().Add(-24*time.Hour)); n != 2 {
+		t.Errorf("expected 2 submitted applications in the last day, got %d", n)
+	}
+	if n := count(other.ID, time.Time{}); n != 1 {
+		t.Errorf("expected 1 application for the other profile, got %d", n)
+	}
+	if n := count(profile.ID, time.Now().Add(time.Hour)); n != 0 {
+		t.Errorf("expected no applications in the future, got %d", n)
+	}
+}
+
+func TestApplicationIndexes(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("indexed@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	db, done := store.DB()
+	defer done()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	statuses := []string{ApplicationStatusApplied, ApplicationStatusFailed, ApplicationStatusSkipped}
+	for i := 0; i < 2000; i++ {
+		if _, err := tx.Exec(
+			"INSERT INTO applications (profile_id, job_id, status) VALUES (?, ?, ?)",
+			profile.ID, i/2, statuses[i%len(statuses)],
+		); err != nil {
+			t.Fatalf("failed to insert application: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if _, err := db.Exec("ANALYZE"); err != nil {
+		t.Fatalf("failed to analyze: %v", err)
+	}
+
+	plan := func(query string, args ...any) string {
+		t.Helper()
+		rows, err := db.Query("EXPLAIN QUERY PLAN "+query, args...)
+		if err != nil {
+			t.Fatalf("failed to explain %q: %v", query, err)
+		}
+		defer rows.Close()
+		var details []string
+		for rows.Next() {
+			var id, parent, unused int
+			var detail string
+			if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+				t.Fatalf("failed to scan plan: %v", err)
+			}
+			details = append(details, detail)
+		}
+		return strings.Join(details, "; ")
+	}
+
+	for query, index := range map[string]string{
+		"SELECT id FROM applications WHERE profile_id = 1 AND job_id = 42":       "idx_applications_profile_job",
+		"SELECT id FROM applications WHERE profile_id = 1 AND status = 'failed'": "idx_applications_profile_status",
+	} {
+		if got := plan(query); !strings.Contains(got, index) {
+			t.Errorf("expected %q to use %s, got plan %q", query, index, got)
+		}
+	}
+
+	// Retrying a job records another attempt for it
+	if _, err := store.RecordApplication(Application{ProfileID: profile.ID, JobID: 0, Status: ApplicationStatusApplied}); err != nil {
+		t.Errorf("expected a second attempt at a job to be recorded, got %v", err)
+	}
+}
+
+func TestCompanyApplicationCounts(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile := seedApplications(t, store)
+	for _, app := range []Application{
+		{ProfileID: profile.ID, JobID: 4, Company: "Google"},
+		{ProfileID: profile.ID, JobID: 5, Company: "Google", Status: ApplicationStatusFailed},
+		{ProfileID: profile.ID, JobID: 6},
+	} {
+		if _, err := store.RecordApplication(app); err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+	}
+	db, done := store.DB()
+	defer done()
+	if _, err := db.Exec(
+		"UPDATE applications SET applied_at = ? WHERE profile_id = ? AND job_id = 3",
+		time.Now().UTC().AddDate(0, -1, 0).Format("2006-01-02 15:04:05"), profile.ID,
+	); err != nil {
+		t.Fatalf("failed to backdate application: %v", err)
+	}
+
+	counts, err := store.CompanyApplicationCounts(profile.ID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to count applications by company: %v", err)
+	}
+	if len(counts) != 2 || counts["Google"] != 2 || counts["Googly Eyes Inc"] != 1 {
+		t.Errorf("expected recent submitted applications per company, got %v", counts)
+	}
+}
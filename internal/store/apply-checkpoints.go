@@ -0,0 +1,54 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ApplyCheckpoint records where in the job search an apply run got to, so
+// a run cut short by a crash can pick up from the same search page
+type ApplyCheckpoint struct {
+	Position string    `json:"position"`
+	Location string    `json:"location"`
+	Start    int       `json:"start"` // Search results offset of the next page to work through
+	SavedAt  time.Time `json:"savedAt"`
+}
+
+// SaveApplyCheckpoint stores the profile's checkpoint, replacing any
+// earlier one. SavedAt defaults to now.
+func (s *Store) SaveApplyCheckpoint(profileID int64, checkpoint ApplyCheckpoint) error {
+	if checkpoint.SavedAt.IsZero() {
+		checkpoint.SavedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal apply checkpoint: %w", err)
+	}
+	return s.SetSetting(ProfileSetting(SettingApplyCheckpoint, profileID), string(data))
+}
+
+// LoadApplyCheckpoint returns the profile's saved checkpoint, or nil if
+// there isn't one
+func (s *Store) LoadApplyCheckpoint(profileID int64) (*ApplyCheckpoint, error) {
+	value, ok, err := s.GetSetting(ProfileSetting(SettingApplyCheckpoint, profileID))
+	if err != nil || !ok {
+		return nil, err
+	}
+	var checkpoint ApplyCheckpoint
+	if err := json.Unmarshal([]byte(value), &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse apply checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// ClearApplyCheckpoint removes the profile's checkpoint once a run
+// finishes. Clearing a missing checkpoint is not an error.
+func (s *Store) ClearApplyCheckpoint(profileID int64) error {
+	db, done := s.conn()
+	defer done()
+	if _, err := db.Exec("DELETE FROM settings WHERE key = ?", ProfileSetting(SettingApplyCheckpoint, profileID)); err != nil {
+		return fmt.Errorf("failed to clear apply checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyCheckpoint(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if checkpoint, err := store.LoadApplyCheckpoint(1); err != nil || checkpoint != nil {
+		t.Fatalf("expected no checkpoint, got %+v, %v", checkpoint, err)
+	}
+
+	saved := ApplyCheckpoint{Position: "Go Engineer", Location: "Berlin", Start: 50}
+	if err := store.SaveApplyCheckpoint(1, saved); err != nil {
+		t.Fatalf("failed to save checkpoint: %v", err)
+	}
+	checkpoint, err := store.LoadApplyCheckpoint(1)
+	if err != nil || checkpoint == nil {
+		t.Fatalf("failed to load checkpoint: %+v, %v", checkpoint, err)
+	}
+	if checkpoint.Position != saved.Position || checkpoint.Location != saved.Location || checkpoint.Start != 50 {
+		t.Errorf("expected %+v back, got %+v", saved, checkpoint)
+	}
+	if time.Since(checkpoint.SavedAt) > time.Minute {
+		t.Errorf("expected SavedAt to default to now, got %v", checkpoint.SavedAt)
+	}
+
+	// Checkpoints are per profile and a later page replaces the earlier one
+	if other, err := store.LoadApplyCheckpoint(2); err != nil || other != nil {
+		t.Errorf("expected no checkpoint for another profile, got %+v, %v", other, err)
+	}
+	saved.Start = 75
+	if err := store.SaveApplyCheckpoint(1, saved); err != nil {
+		t.Fatalf("failed to save checkpoint: %v", err)
+	}
+	if checkpoint, err := store.LoadApplyCheckpoint(1); err != nil || checkpoint.Start != 75 {
+		t.Errorf("expected the later offset, got %+v, %v", checkpoint, err)
+	}
+
+	if err := store.ClearApplyCheckpoint(1); err != nil {
+		t.Fatalf("failed to clear checkpoint: %v", err)
+	}
+	if checkpoint, err := store.LoadApplyCheckpoint(1); err != nil || checkpoint != nil {
+		t.Errorf("expected the checkpoint gone, got %+v, %v", checkpoint, err)
+	}
+	if err := store.ClearApplyCheckpoint(1); err != nil {
+		t.Errorf("expected clearing twice to succeed, got %v", err)
+	}
+}
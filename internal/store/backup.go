@@ -0,0 +1,201 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// requiredTables must exist in a database file for it to be restorable
+var requiredTables = []string{"schema_version", "linkedin_profiles"}
+
+// Backup writes a consistent copy of the database to destPath.
+// It uses VACUUM INTO, so it is safe to call while the app is running.
+// The copy is written next to destPath and renamed over it, so an existing
+// backup there is only replaced once the new one is complete. destPath
+// can't be the live database.
+func (s *Store) Backup(destPath string) error {
+	if s.path != "" {
+		if dest, err := os.Stat(destPath); err == nil {
+			if live, err := os.Stat(s.path); err == nil && os.SameFile(dest, live) {
+				return fmt.Errorf("cannot back up over the live database: %s", destPath)
+			}
+		}
+	}
+
+	// VACUUM INTO needs a missing or empty file
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), filepath.Base(destPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath) // Gone after the rename; cleans up after a failure
+
+	db, done := s.conn()
+	defer done()
+	if _, err := db.Exec("VACUUM INTO ?", tmpPath); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	if err := renameFile(tmpPath, destPath); err != nil {
+		return fmt.Errorf("failed to replace existing backup: %w", err)
+	}
+	return nil
+}
+
+// renameFile renames files for Backup and Restore; tests swap it to
+// simulate failures
+var renameFile = os.Rename
+
+// Restore replaces the database with the backup at srcPath.
+// The backup is copied next to the live database, migrated and opened
+// before the live database is touched. The connection is swapped only once
+// the restored one is open; on any failure the original database is put
+// back and stays in use.
+func (s *Store) Restore(srcPath string) error {
+	if s.path == "" {
+		return fmt.Errorf("cannot restore into an in-memory database")
+	}
+
+	if err := validateBackup(srcPath); err != nil {
+		return err
+	}
+
+	// Stage the copy next to the live database so the final swap is a rename
+	stagedPath := s.path + ".restore"
+	defer removeDBFiles(stagedPath)
+	if err := copyFile(srcPath, stagedPath); err != nil {
+		return fmt.Errorf("failed to stage backup: %w", err)
+	}
+	if err := migrateStaged(stagedPath, s.opts); err != nil {
+		return err
+	}
+
+	// Wait for running operations, so none is left using the closed
+	// connection, and hold off new ones until the swap is done
+	endSwap := s.gate.swap()
+	defer endSwap()
+
+	// Closing checkpoints the WAL, so the live file is complete on its own
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+	reopen := func(cause error) error {
+		db, err := openDB(s.path, true, s.opts)
+		if err != nil {
+			return fmt.Errorf("%w; reopening the original database also failed: %v", cause, err)
+		}
+		s.db = db
+		return cause
+	}
+
+	previousPath := s.path + ".previous"
+	removeDBFiles(previousPath)
+	if err := renameFile(s.path, previousPath); err != nil {
+		return reopen(fmt.Errorf("failed to move aside the current database: %w", err))
+	}
+	removeDBFiles(s.path) // Leftover WAL files belong to the old database
+	if err := renameFile(stagedPath, s.path); err != nil {
+		return reopen(putBack(previousPath, s.path, fmt.Errorf("failed to replace database: %w", err)))
+	}
+	db, err := openDB(s.path, true, s.opts)
+	if err != nil {
+		removeDBFiles(s.path)
+		return reopen(putBack(previousPath, s.path, err))
+	}
+	s.db = db
+	removeDBFiles(previousPath)
+	return nil
+}
+
+// migrateStaged brings the staged backup at path up to date, checking that
+// it opens before it replaces the live database
+func migrateStaged(path string, opts Options) error {
+	db, err := openDB(path, true, opts)
+	if err != nil {
+		return err
+	}
+	staged := &Store{db: db, path: path, opts: opts}
+	if err := staged.migrate(); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("failed to close staged database: %w", err)
+	}
+	return nil
+}
+
+// putBack moves the database set aside at previousPath back to path,
+// returning cause, or a combined error if that fails too
+func putBack(previousPath, path string, cause error) error {
+	if err := renameFile(previousPath, path); err != nil {
+		return fmt.Errorf("%w; restoring the original database also failed: %v", cause, err)
+	}
+	return cause
+}
+
+// removeDBFiles deletes the database file at path and its WAL files
+func removeDBFiles(path string) {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		os.Remove(path + suffix)
+	}
+}
+
+// validateBackup checks that path is a readable SQLite database with the
+// expected schema
+func validateBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("backup not found: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("backup is not a valid database: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("backup failed integrity check: %s", result)
+	}
+
+	for _, table := range requiredTables {
+		var count int
+		if err := db.QueryRow(
+			"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table,
+		).Scan(&count); err != nil {
+			return fmt.Errorf("failed to read backup schema: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("backup is missing table: %s", table)
+		}
+	}
+
+	return nil
+}
+
+// copyFile copies src to dest, replacing dest if it exists
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
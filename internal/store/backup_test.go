@@ -0,0 +1,223 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("backup@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := store.Backup(backupPath); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+
+	if err := store.DeleteLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("failed to delete LinkedIn profile: %v", err)
+	}
+	if _, err := store.GetLinkedInProfile(profile.ID); err == nil {
+		t.Fatal("expected profile to be deleted before restore")
+	}
+
+	if err := store.Restore(backupPath); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	restored, err := store.GetLinkedInProfile(profile.ID)
+	if err != nil {
+		t.Fatalf("expected profile after restore: %v", err)
+	}
+	if restored.Email != "backup@example.com" {
+		t.Errorf("expected email 'backup@example.com', got '%s'", restored.Email)
+	}
+
+	// Backing up over an existing file should replace it
+	if err := store.Backup(backupPath); err != nil {
+		t.Fatalf("failed to overwrite backup: %v", err)
+	}
+}
+
+func TestRestoreRejectsInvalidBackup(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if _, err := store.CreateLinkedInProfile("keep@example.com", "password123"); err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	dir := t.TempDir()
+
+	notDB := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(notDB, []byte("definitely not sqlite"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := store.Restore(notDB); err == nil {
+		t.Error("expected error restoring a non-database file")
+	}
+
+	emptyDB := filepath.Join(dir, "empty.db")
+	other, err := NewWithPath(filepath.Join(dir, "other.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	db, done := other.DB()
+	defer done()
+	if _, err := db.Exec("DROP TABLE linkedin_profiles"); err != nil {
+		t.Fatalf("failed to drop table: %v", err)
+	}
+	if err := other.Backup(emptyDB); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+	other.Close()
+	if err := store.Restore(emptyDB); err == nil {
+		t.Error("expected error restoring a backup with missing tables")
+	}
+
+	if err := store.Restore(filepath.Join(dir, "missing.db")); err == nil {
+		t.Error("expected error restoring a missing file")
+	}
+
+	// The live database must be untouched after failed restores
+	profiles, err := store.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Errorf("expected 1 profile, got %d", len(profiles))
+	}
+}
+
+func TestRestoreKeepsDatabaseOnFailure(t *testing.T) {
+	defer func(rename func(string, string) error) { renameFile = rename }(renameFile)
+
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := store.Backup(backupPath); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+	if _, err := store.CreateLinkedInProfile("keep@example.com", "password123"); err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	// Fail each rename in turn: moving the live database aside, then
+	// moving the restored one into place
+	for failAt := 1; failAt <= 2; failAt++ {
+		renames := 0
+		renameFile = func(from, to string) error {
+			renames++
+			if renames == failAt {
+				return errors.New("disk full")
+			}
+			return os.Rename(from, to)
+		}
+		if err := store.Restore(backupPath); err == nil {
+			t.Fatalf("rename %d: expected the restore to fail", failAt)
+		}
+
+		profiles, err := store.ListLinkedInProfiles()
+		if err != nil {
+			t.Fatalf("rename %d: expected the store to keep working, got %v", failAt, err)
+		}
+		if len(profiles) != 1 || profiles[0].Email != "keep@example.com" {
+			t.Errorf("rename %d: expected the original data, got %+v", failAt, profiles)
+		}
+		if _, err := os.Stat(store.path + ".restore"); !os.IsNotExist(err) {
+			t.Errorf("rename %d: expected the staged copy to be cleaned up, got %v", failAt, err)
+		}
+	}
+}
+
+func TestRestoreWaitsForRunningOperations(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := store.Backup(backupPath); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+
+	db, done := store.DB()
+	restored := make(chan error, 1)
+	go func() { restored <- store.Restore(backupPath) }()
+	select {
+	case err := <-restored:
+		t.Fatalf("expected Restore to wait for the running operation, got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The running operation's connection stays open, and other operations
+	// still start while the restore waits
+	var one int
+	if err := db.QueryRow("SELECT 1").Scan(&one); err != nil {
+		t.Errorf("expected the connection to stay usable until done, got %v", err)
+	}
+	if _, err := store.ListLinkedInProfiles(); err != nil {
+		t.Errorf("expected operations to run while the restore waits, got %v", err)
+	}
+	done()
+
+	if err := <-restored; err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	if _, err := store.ListLinkedInProfiles(); err != nil {
+		t.Errorf("expected the restored database to be usable, got %v", err)
+	}
+}
+
+func TestBackupKeepsPreviousBackupOnFailure(t *testing.T) {
+	defer func(rename func(string, string) error) { renameFile = rename }(renameFile)
+
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if _, err := store.CreateLinkedInProfile("keep@example.com", "password123"); err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	dir := t.TempDir()
+	backupPath := filepath.Join(dir, "backup.db")
+	if err := store.Backup(backupPath); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+	previous, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+
+	renameFile = func(from, to string) error { return errors.New("disk full") }
+	if err := store.Backup(backupPath); err == nil {
+		t.Fatal("expected the backup to fail")
+	}
+	if current, err := os.ReadFile(backupPath); err != nil || string(current) != string(previous) {
+		t.Errorf("expected the previous backup to be kept, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected the partial backup to be cleaned up, got %d files", len(entries))
+	}
+}
+
+func TestBackupRejectsLiveDatabase(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if _, err := store.CreateLinkedInProfile("keep@example.com", "password123"); err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if err := store.Backup(store.path); err == nil {
+		t.Error("expected backing up over the live database to fail")
+	}
+	if profiles, err := store.ListLinkedInProfiles(); err != nil || len(profiles) != 1 {
+		t.Errorf("expected the live database to be untouched, got %d profiles (%v)", len(profiles), err)
+	}
+}
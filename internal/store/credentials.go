@@ -0,0 +1,295 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CredentialStore keeps secrets outside the database, keyed by account
+type CredentialStore interface {
+	// Get returns the secret stored for account, or ErrCredentialNotFound
+	Get(account string) (string, error)
+	// Set stores secret for account, replacing any existing one
+	Set(account, secret string) error
+	// Delete removes account's secret. Deleting a missing secret isn't an error.
+	Delete(account string) error
+}
+
+var (
+	// ErrCredentialNotFound is returned by CredentialStore.Get for unknown accounts
+	ErrCredentialNotFound = errors.New("credential not found")
+
+	// ErrNoKeychain is returned by NewKeychain when the platform has no
+	// usable secret store
+	ErrNoKeychain = errors.New("no OS keychain available")
+)
+
+// keychainService is the service name credentials are filed under in the
+// OS keychain
+const keychainService = "foxyapply"
+
+// NewKeychain returns the OS secret store for this platform: the macOS
+// Keychain, Windows Credential Manager, or the freedesktop Secret Service
+// (GNOME Keyring, KWallet) on Linux. It returns an error wrapping
+// ErrNoKeychain when none is available.
+func NewKeychain() (CredentialStore, error) {
+	return newKeychain()
+}
+
+// SetCredentialStore moves profile passwords saved from now on into
+// credentials, keeping only a reference in the database. Without a
+// credential store passwords are kept encrypted in the password column.
+func (s *Store) SetCredentialStore(credentials CredentialStore) {
+	s.credentials = credentials
+}
+
+// encryptedPasswordPrefix marks a password column value encrypted with the
+// data directory's password key
+const encryptedPasswordPrefix = "enc:"
+
+// passwordKeyFile holds the key passwords kept in the database are
+// encrypted with. It lives next to the database, so the database and its
+// backups never hold a readable password on their own.
+const passwordKeyFile = "password.key"
+
+// passwordCipher returns the cipher passwords kept in the database are
+// encrypted with, creating its key on first use. An in-memory database
+// gets a key that lasts as long as the process.
+func (s *Store) passwordCipher() (cipher.AEAD, error) {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+	if s.passwordKey == nil {
+		key, err := loadPasswordKey(s.path)
+		if err != nil {
+			return nil, err
+		}
+		s.passwordKey = key
+	}
+	block, err := aes.NewCipher(s.passwordKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// loadPasswordKey reads the password key kept next to the database at
+// dbPath, creating it if there isn't one yet
+func loadPasswordKey(dbPath string) ([]byte, error) {
+	key := make([]byte, 32)
+	if dbPath == "" {
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate password key: %w", err)
+		}
+		return key, nil
+	}
+
+	path := filepath.Join(filepath.Dir(dbPath), passwordKeyFile)
+	if existing, err := os.ReadFile(path); err == nil {
+		if len(existing) != len(key) {
+			return nil, fmt.Errorf("password key %s is corrupt", path)
+		}
+		return existing, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read password key: %w", err)
+	}
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate password key: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password key: %w", err)
+	}
+	if _, err := f.Write(key); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write password key: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write password key: %w", err)
+	}
+	return key, nil
+}
+
+// encryptPassword returns password encrypted for the password column
+func (s *Store) encryptPassword(password string) (string, error) {
+	aead, err := s.passwordCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(password), nil)
+	return encryptedPasswordPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptPassword reverses encryptPassword. Values without the encrypted
+// prefix, such as passwords saved before they were encrypted, are returned
+// as they are.
+func (s *Store) decryptPassword(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedPasswordPrefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode password: %w", err)
+	}
+	aead, err := s.passwordCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("failed to decrypt password: too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	password, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt password: %w", err)
+	}
+	return string(password), nil
+}
+
+// newPasswordRef returns a fresh credential store account for a profile
+// password. It is random rather than derived from the profile ID so that
+// separate data directories never share keychain entries.
+func newPasswordRef() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate password reference: %w", err)
+	}
+	return "linkedin-profile-" + hex.EncodeToString(b), nil
+}
+
+// savePassword stores a profile's password and reports whether it went to
+// the credential store. With a credential store only the password's
+// reference is written to the database; without one, or if the credential
+// store fails, the password is encrypted into the password column instead.
+func (s *Store) savePassword(id int64, password string) (bool, error) {
+	db, done := s.conn()
+	defer done()
+	var ref string
+	if err := db.QueryRow("SELECT password_ref FROM linkedin_profiles WHERE id = ?", id).Scan(&ref); err != nil {
+		return false, fmt.Errorf("failed to get password reference: %w", err)
+	}
+
+	stored := false
+	if s.credentials != nil && password != "" {
+		if ref == "" {
+			newRef, err := newPasswordRef()
+			if err != nil {
+				return false, err
+			}
+			ref = newRef
+		}
+		stored = s.credentials.Set(ref, password) == nil
+	}
+	if stored {
+		password = ""
+	} else {
+		if ref != "" && s.credentials != nil {
+			s.credentials.Delete(ref)
+			ref = ""
+		}
+		if password != "" {
+			encrypted, err := s.encryptPassword(password)
+			if err != nil {
+				return false, err
+			}
+			password = encrypted
+		}
+	}
+
+	if _, err := db.Exec(
+		"UPDATE linkedin_profiles SET password = ?, password_ref = ? WHERE id = ?",
+		password, ref, id,
+	); err != nil {
+		return false, fmt.Errorf("failed to save password: %w", err)
+	}
+	return stored, nil
+}
+
+// resolvePassword decrypts a profile's password from the password column,
+// or fills it in from the credential store when the database only holds a
+// reference to it. A password in the password column always wins, e.g. one
+// restored by an import.
+func (s *Store) resolvePassword(profile *LinkedInProfile) error {
+	if profile.Password != "" {
+		password, err := s.decryptPassword(profile.Password)
+		if err != nil {
+			return err
+		}
+		profile.Password = password
+		return nil
+	}
+	if profile.passwordRef == "" || s.credentials == nil {
+		return nil
+	}
+	password, err := s.credentials.Get(profile.passwordRef)
+	if errors.Is(err, ErrCredentialNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read password from keychain: %w", err)
+	}
+	profile.Password = password
+	return nil
+}
+
+// SecurePasswords moves every password kept in the database into the
+// credential store, or without one, encrypts the ones still in plain text,
+// such as passwords saved before they were encrypted or imported. It
+// returns how many passwords it moved or encrypted.
+func (s *Store) SecurePasswords() (int, error) {
+	db, done := s.conn()
+	defer done()
+
+	rows, err := db.Query("SELECT id, password FROM linkedin_profiles WHERE password != ''")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stored passwords: %w", err)
+	}
+	passwords := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var password string
+		if err := rows.Scan(&id, &password); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan stored password: %w", err)
+		}
+		passwords[id] = password
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating stored passwords: %w", err)
+	}
+
+	secured := 0
+	for id, value := range passwords {
+		if s.credentials == nil && strings.HasPrefix(value, encryptedPasswordPrefix) {
+			continue // Already as secure as it gets without a credential store
+		}
+		password, err := s.decryptPassword(value)
+		if err != nil {
+			return secured, err
+		}
+		stored, err := s.savePassword(id, password)
+		if err != nil {
+			return secured, err
+		}
+		if stored || s.credentials == nil {
+			secured++
+		}
+	}
+	return secured, nil
+}
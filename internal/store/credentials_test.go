@@ -0,0 +1,223 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeCredentials is an in-memory CredentialStore
+type fakeCredentials struct {
+	secrets map[string]string
+	failSet bool
+}
+
+func newFakeCredentials() *fakeCredentials {
+	return &fakeCredentials{secrets: map[string]string{}}
+}
+
+func (c *fakeCredentials) Get(account string) (string, error) {
+	secret, ok := c.secrets[account]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (c *fakeCredentials) Set(account, secret string) error {
+	if c.failSet {
+		return errors.New("keychain locked")
+	}
+	c.secrets[account] = secret
+	return nil
+}
+
+func (c *fakeCredentials) Delete(account string) error {
+	delete(c.secrets, account)
+	return nil
+}
+
+// storedPassword returns the raw password and reference columns for a profile
+func storedPassword(t *testing.T, store *Store, id int64) (password, ref string) {
+	t.Helper()
+	if err := store.db.QueryRow("SELECT password, password_ref FROM linkedin_profiles WHERE id = ?", id).Scan(&password, &ref); err != nil {
+		t.Fatalf("failed to read password columns: %v", err)
+	}
+	return password, ref
+}
+
+func TestPasswordsKeptInCredentialStore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	credentials := newFakeCredentials()
+	store.SetCredentialStore(credentials)
+
+	profile, err := store.CreateLinkedInProfile("keychain@example.com", "secret1")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if profile.Password != "secret1" {
+		t.Errorf("expected password from keychain, got %q", profile.Password)
+	}
+	password, ref := storedPassword(t, store, profile.ID)
+	if password != "" || ref == "" {
+		t.Fatalf("expected only a reference in the database, got password %q ref %q", password, ref)
+	}
+	if credentials.secrets[ref] != "secret1" {
+		t.Errorf("expected keychain to hold the password, got %q", credentials.secrets[ref])
+	}
+
+	// Changing the password reuses the same keychain entry
+	newPassword := "secret2"
+	if _, err := store.PatchLinkedInProfile(profile.ID, LinkedInProfilePatch{Password: &newPassword}); err != nil {
+		t.Fatalf("failed to patch password: %v", err)
+	}
+	if _, patchedRef := storedPassword(t, store, profile.ID); patchedRef != ref {
+		t.Errorf("expected reference %q to be reused, got %q", ref, patchedRef)
+	}
+	profiles, err := store.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Password != "secret2" {
+		t.Errorf("expected listed profile to have the new password, got %+v", profiles)
+	}
+
+	updated, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:    "keychain@example.com",
+		Password: "secret3",
+	})
+	if err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+	if updated.Password != "secret3" || credentials.secrets[ref] != "secret3" {
+		t.Errorf("expected update to store the new password, got %q", updated.Password)
+	}
+
+	// Purging the profile removes its keychain entry
+	if err := store.DeleteLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("failed to delete LinkedIn profile: %v", err)
+	}
+	if _, err := store.PurgeDeletedProfiles(-time.Hour); err != nil {
+		t.Fatalf("failed to purge profiles: %v", err)
+	}
+	if len(credentials.secrets) != 0 {
+		t.Errorf("expected keychain entry to be removed, got %v", credentials.secrets)
+	}
+}
+
+func TestPasswordFallsBackToDatabase(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	// No keychain at all
+	plain, err := store.CreateLinkedInProfile("plain@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if plain.Password != "secret" {
+		t.Errorf("expected password %q, got %q", "secret", plain.Password)
+	}
+	if password, ref := storedPassword(t, store, plain.ID); !strings.HasPrefix(password, encryptedPasswordPrefix) || ref != "" {
+		t.Errorf("expected the password encrypted in the database, got password %q ref %q", password, ref)
+	}
+
+	// A keychain that refuses writes
+	credentials := newFakeCredentials()
+	credentials.failSet = true
+	store.SetCredentialStore(credentials)
+	locked, err := store.CreateLinkedInProfile("locked@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if locked.Password != "secret" {
+		t.Errorf("expected password %q, got %q", "secret", locked.Password)
+	}
+	if password, ref := storedPassword(t, store, locked.ID); !strings.HasPrefix(password, encryptedPasswordPrefix) || ref != "" {
+		t.Errorf("expected the password encrypted in the database, got password %q ref %q", password, ref)
+	}
+
+	// Once the keychain works, existing passwords move into it
+	credentials.failSet = false
+	moved, err := store.SecurePasswords()
+	if err != nil {
+		t.Fatalf("failed to move passwords: %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("expected 2 passwords moved, got %d", moved)
+	}
+	for _, id := range []int64{plain.ID, locked.ID} {
+		if password, _ := storedPassword(t, store, id); password != "" {
+			t.Errorf("expected profile %d password out of the database, got %q", id, password)
+		}
+		profile, err := store.GetLinkedInProfile(id)
+		if err != nil {
+			t.Fatalf("failed to get LinkedIn profile: %v", err)
+		}
+		if profile.Password != "secret" {
+			t.Errorf("expected profile %d password from keychain, got %q", id, profile.Password)
+		}
+	}
+}
+
+func TestPasswordsEncryptedWithoutCredentialStore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("plain@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	keyPath := filepath.Join(filepath.Dir(store.path), passwordKeyFile)
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatalf("expected a password key next to the database: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("expected the password key to be private, got %v", info.Mode().Perm())
+	}
+
+	// A password saved in plain text before passwords were encrypted
+	if _, err := store.db.Exec("UPDATE linkedin_profiles SET password = 'legacy' WHERE id = ?", profile.ID); err != nil {
+		t.Fatalf("failed to store a plain text password: %v", err)
+	}
+	got, err := store.GetLinkedInProfile(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to get LinkedIn profile: %v", err)
+	}
+	if got.Password != "legacy" {
+		t.Errorf("expected the plain text password to be read, got %q", got.Password)
+	}
+	secured, err := store.SecurePasswords()
+	if err != nil {
+		t.Fatalf("failed to secure passwords: %v", err)
+	}
+	if secured != 1 {
+		t.Errorf("expected 1 password encrypted, got %d", secured)
+	}
+	if secured, err := store.SecurePasswords(); err != nil || secured != 0 {
+		t.Errorf("expected encrypted passwords to be left alone, got %d (%v)", secured, err)
+	}
+	if password, _ := storedPassword(t, store, profile.ID); !strings.HasPrefix(password, encryptedPasswordPrefix) {
+		t.Errorf("expected the password encrypted in the database, got %q", password)
+	}
+
+	// Another store on the same data directory reads it with the same key
+	store.Close()
+	reopened, err := NewWithPath(store.path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+	got, err = reopened.GetLinkedInProfile(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to get LinkedIn profile after reopening: %v", err)
+	}
+	if got.Password != "legacy" {
+		t.Errorf("expected the password after reopening, got %q", got.Password)
+	}
+}
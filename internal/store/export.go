@@ -0,0 +1,385 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportProfiles serializes all LinkedIn profiles to JSON.
+// When includePasswords is false the password field is blanked.
+func (s *Store) ExportProfiles(includePasswords bool) ([]byte, error) {
+	profiles, err := s.ListLinkedInProfiles()
+	if err != nil {
+		return nil, err
+	}
+	if profiles == nil {
+		profiles = []*LinkedInProfile{}
+	}
+
+	if !includePasswords {
+		for _, profile := range profiles {
+			profile.Password = ""
+		}
+	}
+
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal profiles: %w", err)
+	}
+	return data, nil
+}
+
+// applicationsCSVHeader is the header row written by ExportApplicationsCSV
+var applicationsCSVHeader = []string{"job_id", "title", "company", "status", "applied_at", "job_url"}
+
+// ExportApplicationsCSV writes a profile's application history to w as
+// CSV, oldest first, one row per attempt. Rows are written as they're read
+// rather than loaded up front, so long histories don't sit in memory.
+func (s *Store) ExportApplicationsCSV(profileID int64, w io.Writer) error {
+	db, done := s.conn()
+	defer done()
+	rows, err := db.Query(
+		"SELECT job_id, title, company, status, applied_at FROM applications WHERE profile_id = ? ORDER BY applied_at, id",
+		profileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query applications: %w", err)
+	}
+	defer rows.Close()
+
+	out := csv.NewWriter(w)
+	if err := out.Write(applicationsCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for rows.Next() {
+		var jobID int
+		var title, company, status string
+		var appliedAt time.Time
+		if err := rows.Scan(&jobID, &title, &company, &status, &appliedAt); err != nil {
+			return fmt.Errorf("failed to scan application: %w", err)
+		}
+		if err := out.Write([]string{
+			strconv.Itoa(jobID), title, company, status,
+			appliedAt.UTC().Format(time.RFC3339), JobURL(jobID),
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read applications: %w", err)
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// ImportProfiles re-creates profiles from JSON produced by ExportProfiles.
+// Every profile is inserted as a new row, so IDs in the data never
+// overwrite existing profiles. Returns the number of profiles imported.
+func (s *Store) ImportProfiles(data []byte) (int, error) {
+	db, done := s.conn()
+	defer done()
+	var profiles []*LinkedInProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return 0, fmt.Errorf("failed to parse profiles: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	imported := 0
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+
+		if _, err := insertProfile(tx, profile); err != nil {
+			return 0, err
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit import: %w", err)
+	}
+	if _, err := s.SecurePasswords(); err != nil {
+		return imported, err
+	}
+
+	return imported, nil
+}
+
+// profileColumnValues returns the marshalled column values shared by
+// profile inserts and updates, in the order email, password, phone_number,
+// positions, locations, remote_only, profile_url, years_experience,
+// user_city, user_state, zip_code, desired_salary, cover_letter,
+// title_exclude_keywords, search_sort, posted_within, experience_levels,
+// job_types
+func profileColumnValues(profile *LinkedInProfile) ([]any, error) {
+	positionsJSON, err := json.Marshal(nonNil(profile.Positions))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal positions: %w", err)
+	}
+	locationsJSON, err := json.Marshal(nonNil(profile.Locations))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal locations: %w", err)
+	}
+	excludeJSON, err := json.Marshal(nonNil(profile.TitleExcludeKeywords))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal title exclude keywords: %w", err)
+	}
+	experienceJSON, err := json.Marshal(nonNil(profile.ExperienceLevels))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal experience levels: %w", err)
+	}
+	jobTypesJSON, err := json.Marshal(nonNil(profile.JobTypes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job types: %w", err)
+	}
+
+	remoteOnly := 0
+	if profile.RemoteOnly {
+		remoteOnly = 1
+	}
+
+	searchSort := profile.SearchSort
+	if searchSort == "" {
+		searchSort = SearchSortDate
+	}
+
+	return []any{
+		profile.Email, profile.Password, profile.PhoneNumber, string(positionsJSON), string(locationsJSON),
+		remoteOnly, profile.ProfileURL, profile.YearsExperience, profile.UserCity, profile.UserState,
+		profile.ZipCode, profile.DesiredSalary, profile.CoverLetter, string(excludeJSON), searchSort, profile.PostedWithin,
+		string(experienceJSON), string(jobTypesJSON),
+	}, nil
+}
+
+// insertProfile inserts profile as a new row and returns its ID
+func insertProfile(tx *sql.Tx, profile *LinkedInProfile) (int64, error) {
+	values, err := profileColumnValues(profile)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(
+		`INSERT INTO linkedin_profiles (
+			email, password, phone_number, positions, locations,
+			remote_only, profile_url, years_experience, user_city, user_state,
+			zip_code, desired_salary, cover_letter,
+			title_exclude_keywords, search_sort, posted_within,
+			experience_levels, job_types
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		values...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import LinkedIn profile %s: %w", profile.Email, err)
+	}
+	return result.LastInsertId()
+}
+
+// nonNil returns an empty slice in place of nil so it marshals as []
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// DataExportVersion is the document version written by ExportData. Bump it
+// whenever the layout changes in a way older readers can't handle.
+const DataExportVersion = 1
+
+// DataExport is the versioned document written by ExportData
+type DataExport struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	Profiles   []ProfileExport `json:"profiles"`
+}
+
+// ProfileExport is a profile together with its application history
+type ProfileExport struct {
+	LinkedInProfile
+	Applications []Application `json:"applications"`
+}
+
+// ImportResult reports what ImportData changed
+type ImportResult struct {
+	Created      int `json:"created"`
+	Updated      int `json:"updated"`
+	Skipped      int `json:"skipped"`
+	Applications int `json:"applications"`
+}
+
+// ExportData serializes every profile and its application history into a
+// single versioned JSON document. When includePasswords is false the
+// password field is blanked.
+func (s *Store) ExportData(includePasswords bool) ([]byte, error) {
+	profiles, err := s.ListLinkedInProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	export := DataExport{
+		Version:    DataExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Profiles:   []ProfileExport{},
+	}
+	for _, profile := range profiles {
+		applications, err := s.ListApplications(profile.ID, "", 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !includePasswords {
+			profile.Password = ""
+		}
+		export.Profiles = append(export.Profiles, ProfileExport{
+			LinkedInProfile: *profile,
+			Applications:    applications,
+		})
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+	return data, nil
+}
+
+// ImportData loads a document produced by ExportData. Profiles are matched
+// by email: new emails are created, and existing ones are updated only when
+// overwrite is set. An empty password never replaces a stored one.
+// Applications are merged into the matching profile, skipping any already
+// recorded for the same job at the same time.
+func (s *Store) ImportData(data []byte, overwrite bool) (*ImportResult, error) {
+	db, done := s.conn()
+	defer done()
+	var export DataExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	if export.Version < 1 || export.Version > DataExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d (expected 1-%d)", export.Version, DataExportVersion)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ImportResult{}
+	for i := range export.Profiles {
+		profile := &export.Profiles[i].LinkedInProfile
+		if profile.Email == "" {
+			return nil, fmt.Errorf("profile %d has no email", i)
+		}
+
+		var id int64
+		err := tx.QueryRow(
+			"SELECT id FROM linkedin_profiles WHERE email = ? AND deleted_at IS NULL ORDER BY id LIMIT 1",
+			profile.Email,
+		).Scan(&id)
+		switch {
+		case err == sql.ErrNoRows:
+			if id, err = insertProfile(tx, profile); err != nil {
+				return nil, err
+			}
+			result.Created++
+		case err != nil:
+			return nil, fmt.Errorf("failed to look up LinkedIn profile %s: %w", profile.Email, err)
+		case overwrite:
+			if err := updateProfile(tx, id, profile); err != nil {
+				return nil, err
+			}
+			result.Updated++
+		default:
+			result.Skipped++
+		}
+
+		for _, app := range export.Profiles[i].Applications {
+			inserted, err := mergeApplication(tx, id, app)
+			if err != nil {
+				return nil, err
+			}
+			if inserted {
+				result.Applications++
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+	if _, err := s.SecurePasswords(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// updateProfile overwrites the profile with the given ID, keeping the
+// stored password when profile.Password is empty
+func updateProfile(tx *sql.Tx, id int64, profile *LinkedInProfile) error {
+	values, err := profileColumnValues(profile)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(
+		`UPDATE linkedin_profiles SET
+			email = ?, password = COALESCE(NULLIF(?, ''), password), phone_number = ?, positions = ?, locations = ?,
+			remote_only = ?, profile_url = ?, years_experience = ?, user_city = ?, user_state = ?,
+			zip_code = ?, desired_salary = ?,
+			cover_letter = ?, title_exclude_keywords = ?, search_sort = ?, posted_within = ?,
+			experience_levels = ?, job_types = ?,
+			updated_at = CURRENT_TIMESTAMP
+		 WHERE id = ?`,
+		append(values, id)...,
+	); err != nil {
+		return fmt.Errorf("failed to update LinkedIn profile %s: %w", profile.Email, err)
+	}
+	return nil
+}
+
+// mergeApplication inserts app for profileID unless the same job was
+// already recorded at the same time. Reports whether a row was inserted.
+func mergeApplication(tx *sql.Tx, profileID int64, app Application) (bool, error) {
+	if app.Status == "" {
+		app.Status = ApplicationStatusApplied
+	}
+	if app.AppliedAt.IsZero() {
+		app.AppliedAt = time.Now()
+	}
+	// Match the CURRENT_TIMESTAMP format so duplicates compare equal
+	appliedAt := app.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+
+	result, err := tx.Exec(
+		`INSERT INTO applications (profile_id, job_id, title, company, status, description, reason, applied_at)
+		 SELECT ?, ?, ?, ?, ?, ?, ?, ?
+		 WHERE NOT EXISTS (
+			SELECT 1 FROM applications WHERE profile_id = ? AND job_id = ? AND applied_at = ?
+		 )`,
+		profileID, app.JobID, app.Title, app.Company, app.Status, app.Description, app.Reason, appliedAt,
+		profileID, app.JobID, appliedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to import application for job %d: %w", app.JobID, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
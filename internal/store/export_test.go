@@ -0,0 +1,248 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportImportProfiles(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("export@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if _, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:     "export@example.com",
+		Password:  "secret",
+		Positions: []string{"Software Engineer"},
+		Locations: []string{"Remote"},
+		UserCity:  "Austin",
+		UserState: "TX",
+	}); err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+
+	// Export without passwords
+	data, err := store.ExportProfiles(false)
+	if err != nil {
+		t.Fatalf("failed to export profiles: %v", err)
+	}
+
+	var exported []*LinkedInProfile
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+	if len(exported) != 1 {
+		t.Fatalf("expected 1 exported profile, got %d", len(exported))
+	}
+	if exported[0].Password != "" {
+		t.Error("expected password to be excluded from export")
+	}
+
+	// Export with passwords and import into the same store
+	data, err = store.ExportProfiles(true)
+	if err != nil {
+		t.Fatalf("failed to export profiles: %v", err)
+	}
+
+	count, err := store.ImportProfiles(data)
+	if err != nil {
+		t.Fatalf("failed to import profiles: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 imported profile, got %d", count)
+	}
+
+	profiles, err := store.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("expected colliding import to add a new row, got %d profiles", len(profiles))
+	}
+
+	for _, p := range profiles {
+		if p.ID == profile.ID {
+			continue
+		}
+		if p.Password != "secret" {
+			t.Errorf("expected imported password 'secret', got '%s'", p.Password)
+		}
+		if p.UserCity != "Austin" || len(p.Positions) != 1 {
+			t.Errorf("expected imported fields to round-trip, got %+v", p)
+		}
+	}
+
+	if _, err := store.ImportProfiles([]byte("not json")); err == nil {
+		t.Error("expected error importing invalid JSON")
+	}
+}
+
+func TestExportImportData(t *testing.T) {
+	source, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := source.CreateLinkedInProfile("data@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if _, err := source.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:     "data@example.com",
+		Password:  "secret",
+		Positions: []string{"Software Engineer"},
+		UserCity:  "Austin",
+	}); err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+	for _, app := range []Application{
+		{ProfileID: profile.ID, JobID: 1, Title: "Go Developer", Company: "Acme"},
+		{ProfileID: profile.ID, JobID: 2, Title: "Backend Engineer", Company: "Globex", Status: ApplicationStatusFailed},
+	} {
+		if _, err := source.RecordApplication(app); err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+	}
+
+	data, err := source.ExportData(true)
+	if err != nil {
+		t.Fatalf("failed to export data: %v", err)
+	}
+
+	target, err := NewInMemory()
+	if err != nil {
+		t.Fatalf("failed to create in-memory store: %v", err)
+	}
+	defer target.Close()
+
+	result, err := target.ImportData(data, false)
+	if err != nil {
+		t.Fatalf("failed to import data: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 0 || result.Applications != 2 {
+		t.Errorf("expected 1 created and 2 applications, got %+v", result)
+	}
+
+	profiles, err := target.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("expected 1 profile, got %d", len(profiles))
+	}
+	imported := profiles[0]
+	if imported.Password != "secret" || imported.UserCity != "Austin" || len(imported.Positions) != 1 {
+		t.Errorf("expected profile fields to round-trip, got %+v", imported)
+	}
+
+	apps, err := target.ListApplications(imported.ID, "", 0, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("expected 2 applications, got %d", len(apps))
+	}
+
+	// Re-importing the same document must not duplicate anything
+	result, err = target.ImportData(data, false)
+	if err != nil {
+		t.Fatalf("failed to re-import data: %v", err)
+	}
+	if result.Created != 0 || result.Skipped != 1 || result.Applications != 0 {
+		t.Errorf("expected re-import to skip everything, got %+v", result)
+	}
+
+	// Overwrite updates matching profiles but keeps passwords missing from the export
+	if _, err := source.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:    "data@example.com",
+		Password: "secret",
+		UserCity: "Denver",
+	}); err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+	data, err = source.ExportData(false)
+	if err != nil {
+		t.Fatalf("failed to export data: %v", err)
+	}
+	result, err = target.ImportData(data, true)
+	if err != nil {
+		t.Fatalf("failed to import data with overwrite: %v", err)
+	}
+	if result.Updated != 1 || result.Created != 0 {
+		t.Errorf("expected 1 updated profile, got %+v", result)
+	}
+
+	updated, err := target.GetLinkedInProfile(imported.ID)
+	if err != nil {
+		t.Fatalf("failed to get LinkedIn profile: %v", err)
+	}
+	if updated.UserCity != "Denver" {
+		t.Errorf("expected city 'Denver', got '%s'", updated.UserCity)
+	}
+	if updated.Password != "secret" {
+		t.Errorf("expected password to be kept, got '%s'", updated.Password)
+	}
+}
+
+func TestImportDataRejectsUnknownVersion(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	for _, data := range []string{
+		`{"profiles": []}`,
+		`{"version": 99, "profiles": []}`,
+		`not json`,
+	} {
+		if _, err := store.ImportData([]byte(data), false); err == nil {
+			t.Errorf("expected error importing %s", data)
+		}
+	}
+}
+
+func TestExportApplicationsCSV(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile := seedApplications(t, store)
+	if _, err := store.RecordApplication(Application{
+		ProfileID: profile.ID, JobID: 4, Title: `Engineer, "Platform"`, Company: "Initech", Status: ApplicationStatusFailed,
+	}); err != nil {
+		t.Fatalf("failed to record application: %v", err)
+	}
+	db, done := store.DB()
+	defer done()
+	if _, err := db.Exec("UPDATE applications SET applied_at = '2026-03-01 09:30:00' WHERE job_id = 4"); err != nil {
+		t.Fatalf("failed to backdate application: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := store.ExportApplicationsCSV(profile.ID, &buf); err != nil {
+		t.Fatalf("failed to export applications: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected a header and 4 rows, got %q", buf.String())
+	}
+	if lines[0] != "job_id,title,company,status,applied_at,job_url" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	// Oldest first, with quoting for commas and quotes
+	if want := `4,"Engineer, ""Platform""",Initech,failed,2026-03-01T09:30:00Z,https://www.linkedin.com/jobs/view/4`; lines[1] != want {
+		t.Errorf("expected row\n%s\ngot\n%s", want, lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "1,Software Engineer,Google,applied,") {
+		t.Errorf("unexpected row %q", lines[2])
+	}
+
+	// Other profiles export just the header
+	buf.Reset()
+	if err := store.ExportApplicationsCSV(profile.ID+1, &buf); err != nil {
+		t.Fatalf("failed to export applications: %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected only a header for a profile without applications, got %q", buf.String())
+	}
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Job queue statuses
+const (
+	QueueStatusPending = "pending"
+	QueueStatusDone    = "done"
+)
+
+// QueuedJob is a scraped job waiting to be applied to
+type QueuedJob struct {
+	ID        int64     `json:"id"`
+	ProfileID int64     `json:"profileId"`
+	JobID     int       `json:"jobId"`
+	Title     string    `json:"title"`
+	Company   string    `json:"company"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// queuedJobColumns lists the columns read by scanQueuedJob, in order
+const queuedJobColumns = `id, profile_id, job_id, title, company, status, created_at, updated_at`
+
+// scanQueuedJob reads a row selected with queuedJobColumns
+func scanQueuedJob(row rowScanner) (*QueuedJob, error) {
+	job := &QueuedJob{}
+	err := row.Scan(&job.ID, &job.ProfileID, &job.JobID, &job.Title, &job.Company,
+		&job.Status, &job.CreatedAt, &job.UpdatedAt)
+	return job, err
+}
+
+// EnqueueJobs adds jobs to a profile's queue as pending. Jobs already
+// queued for the profile, in any status, are left untouched.
+func (s *Store) EnqueueJobs(profileID int64, jobs []QueuedJob) error {
+	db, done := s.conn()
+	defer done()
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(
+		`INSERT INTO job_queue (profile_id, job_id, title, company) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(profile_id, job_id) DO NOTHING`,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prepare enqueue: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, job := range jobs {
+		if _, err := stmt.Exec(profileID, job.JobID, job.Title, job.Company); err != nil {
+			return fmt.Errorf("failed to enqueue job %d: %w", job.JobID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enqueued jobs: %w", err)
+	}
+	return nil
+}
+
+// NextPendingJob returns the profile's oldest pending job, or nil when the
+// queue is empty. The job stays pending until SetQueuedJobStatus is called,
+// so a run interrupted mid-job picks it up again.
+func (s *Store) NextPendingJob(profileID int64) (*QueuedJob, error) {
+	db, done := s.conn()
+	defer done()
+	job, err := scanQueuedJob(db.QueryRow(
+		`SELECT `+queuedJobColumns+` FROM job_queue
+		 WHERE profile_id = ? AND status = ?
+		 ORDER BY id LIMIT 1`,
+		profileID, QueueStatusPending,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next pending job: %w", err)
+	}
+	return job, nil
+}
+
+// SetQueuedJobStatus updates the status of a queued job
+func (s *Store) SetQueuedJobStatus(id int64, status string) error {
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec(
+		"UPDATE job_queue SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		status, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update queued job: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("queued job not found: %d", id)
+	}
+
+	return nil
+}
+
+// ListQueuedJobs returns a profile's queued jobs in queue order. An empty
+// status returns every status.
+func (s *Store) ListQueuedJobs(profileID int64, status string) ([]*QueuedJob, error) {
+	db, done := s.conn()
+	defer done()
+	query := `SELECT ` + queuedJobColumns + ` FROM job_queue WHERE profile_id = ?`
+	args := []any{profileID}
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*QueuedJob{}
+	for rows.Next() {
+		job, err := scanQueuedJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queued job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating queued jobs: %w", err)
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,98 @@
+package store
+
+import "testing"
+
+func TestEnqueueJobsIgnoresDuplicates(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("queue@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	other, err := store.CreateLinkedInProfile("other@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	if err := store.EnqueueJobs(profile.ID, []QueuedJob{
+		{JobID: 1, Title: "Go Developer", Company: "Acme"},
+		{JobID: 2, Title: "Backend Engineer"},
+		{JobID: 1, Title: "Go Developer (dup)"},
+	}); err != nil {
+		t.Fatalf("failed to enqueue jobs: %v", err)
+	}
+	if err := store.EnqueueJobs(profile.ID, []QueuedJob{{JobID: 2}, {JobID: 3}}); err != nil {
+		t.Fatalf("failed to enqueue jobs: %v", err)
+	}
+	// The same job for a different profile is a separate entry
+	if err := store.EnqueueJobs(other.ID, []QueuedJob{{JobID: 1}}); err != nil {
+		t.Fatalf("failed to enqueue jobs: %v", err)
+	}
+
+	jobs, err := store.ListQueuedJobs(profile.ID, "")
+	if err != nil {
+		t.Fatalf("failed to list queued jobs: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 queued jobs, got %d", len(jobs))
+	}
+	if jobs[0].Title != "Go Developer" || jobs[0].Company != "Acme" {
+		t.Errorf("expected first insert to win, got %+v", jobs[0])
+	}
+	for _, job := range jobs {
+		if job.Status != QueueStatusPending {
+			t.Errorf("expected pending status, got %q", job.Status)
+		}
+	}
+
+	if err := store.EnqueueJobs(profile.ID, nil); err != nil {
+		t.Errorf("expected enqueuing nothing to succeed, got %v", err)
+	}
+}
+
+func TestNextPendingJobOrder(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("queue@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	if job, err := store.NextPendingJob(profile.ID); err != nil || job != nil {
+		t.Fatalf("expected empty queue, got (%+v, %v)", job, err)
+	}
+
+	if err := store.EnqueueJobs(profile.ID, []QueuedJob{{JobID: 30}, {JobID: 10}, {JobID: 20}}); err != nil {
+		t.Fatalf("failed to enqueue jobs: %v", err)
+	}
+
+	// Jobs come out in the order they were queued, and a job stays next
+	// until its status changes
+	for _, want := range []int{30, 10, 20} {
+		job, err := store.NextPendingJob(profile.ID)
+		if err != nil {
+			t.Fatalf("failed to get next pending job: %v", err)
+		}
+		if job == nil || job.JobID != want {
+			t.Fatalf("expected job %d, got %+v", want, job)
+		}
+		if again, _ := store.NextPendingJob(profile.ID); again.ID != job.ID {
+			t.Fatalf("expected job %d to stay next until processed", want)
+		}
+		if err := store.SetQueuedJobStatus(job.ID, QueueStatusDone); err != nil {
+			t.Fatalf("failed to update queued job: %v", err)
+		}
+	}
+
+	if job, err := store.NextPendingJob(profile.ID); err != nil || job != nil {
+		t.Errorf("expected drained queue, got (%+v, %v)", job, err)
+	}
+	if done, _ := store.ListQueuedJobs(profile.ID, QueueStatusDone); len(done) != 3 {
+		t.Errorf("expected 3 done jobs, got %d", len(done))
+	}
+	if err := store.SetQueuedJobStatus(999, QueueStatusDone); err == nil {
+		t.Error("expected error updating missing queued job")
+	}
+}
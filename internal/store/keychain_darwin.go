@@ -0,0 +1,78 @@
+//go:build darwin
+
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// securityCmd is the macOS keychain command-line tool
+const securityCmd = "/usr/bin/security"
+
+// securityItemNotFound is the exit status security uses for a missing item
+const securityItemNotFound = 44
+
+// macKeychainPrefix marks values stored base64-encoded, so passwords with
+// spaces, quotes or non-ASCII characters survive the security command line
+const macKeychainPrefix = "base64:"
+
+// macKeychain stores credentials as generic passwords in the user's login
+// keychain
+type macKeychain struct{}
+
+func newKeychain() (CredentialStore, error) {
+	if _, err := os.Stat(securityCmd); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoKeychain, err)
+	}
+	return macKeychain{}, nil
+}
+
+func (macKeychain) Get(account string) (string, error) {
+	out, err := exec.Command(securityCmd, "find-generic-password", "-s", keychainService, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+			return "", ErrCredentialNotFound
+		}
+		return "", fmt.Errorf("failed to read keychain item: %w", err)
+	}
+
+	value := strings.TrimSuffix(string(out), "\n")
+	if encoded, ok := strings.CutPrefix(value, macKeychainPrefix); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode keychain item: %w", err)
+		}
+		return string(decoded), nil
+	}
+	return value, nil
+}
+
+func (macKeychain) Set(account, secret string) error {
+	// Passed on stdin rather than as arguments so the password never shows
+	// up in the process list
+	value := macKeychainPrefix + base64.StdEncoding.EncodeToString([]byte(secret))
+	cmd := exec.Command(securityCmd, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", keychainService, account, value))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to write keychain item: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (macKeychain) Delete(account string) error {
+	err := exec.Command(securityCmd, "delete-generic-password", "-s", keychainService, "-a", account).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete keychain item: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+//go:build linux
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Secret Service D-Bus names, see
+// https://specifications.freedesktop.org/secret-service-spec/latest/
+const (
+	secretServiceName       = "org.freedesktop.secrets"
+	secretServicePath       = dbus.ObjectPath("/org/freedesktop/secrets")
+	secretDefaultCollection = dbus.ObjectPath("/org/freedesktop/secrets/aliases/default")
+	secretServiceIface      = "org.freedesktop.Secret.Service"
+	secretCollectionIface   = "org.freedesktop.Secret.Collection"
+	secretItemIface         = "org.freedesktop.Secret.Item"
+	secretSessionIface      = "org.freedesktop.Secret.Session"
+	secretPromptIface       = "org.freedesktop.Secret.Prompt"
+
+	// noPrompt is the prompt path returned when no user interaction is needed
+	noPrompt = dbus.ObjectPath("/")
+)
+
+// secretPromptTimeout bounds how long an unlock prompt may stay open
+const secretPromptTimeout = 2 * time.Minute
+
+// secretValue is the Secret Service wire format of a stored secret
+type secretValue struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// secretServiceKeychain stores credentials through the freedesktop Secret
+// Service (GNOME Keyring, KWallet), the same store libsecret uses
+type secretServiceKeychain struct {
+	conn *dbus.Conn
+}
+
+func newKeychain() (CredentialStore, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoKeychain, err)
+	}
+	k := &secretServiceKeychain{conn: conn}
+
+	// Opening a session also starts the service if it is D-Bus activatable
+	session, err := k.openSession()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoKeychain, err)
+	}
+	k.closeSession(session)
+	return k, nil
+}
+
+func (k *secretServiceKeychain) service() dbus.BusObject {
+	return k.conn.Object(secretServiceName, secretServicePath)
+}
+
+// attributes identify an account's item in the collection
+func (k *secretServiceKeychain) attributes(account string) map[string]string {
+	return map[string]string{"service": keychainService, "account": account}
+}
+
+// openSession starts an unencrypted transfer session. The secret only
+// travels over the user's private session bus.
+func (k *secretServiceKeychain) openSession() (dbus.ObjectPath, error) {
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	err := k.service().Call(secretServiceIface+".OpenSession", 0, "plain", dbus.MakeVariant("")).Store(&output, &session)
+	if err != nil {
+		return "", fmt.Errorf("failed to open secret service session: %w", err)
+	}
+	return session, nil
+}
+
+func (k *secretServiceKeychain) closeSession(session dbus.ObjectPath) {
+	k.conn.Object(secretServiceName, session).Call(secretSessionIface+".Close", 0)
+}
+
+// unlock unlocks objects, prompting the user if the keyring requires it
+func (k *secretServiceKeychain) unlock(objects []dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+	var prompt dbus.ObjectPath
+	if err := k.service().Call(secretServiceIface+".Unlock", 0, objects).Store(&unlocked, &prompt); err != nil {
+		return fmt.Errorf("failed to unlock keyring: %w", err)
+	}
+	return k.prompt(prompt)
+}
+
+// prompt shows a Secret Service prompt and waits for the user to finish it
+func (k *secretServiceKeychain) prompt(path dbus.ObjectPath) error {
+	if path == noPrompt || path == "" {
+		return nil
+	}
+
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface(secretPromptIface),
+		dbus.WithMatchMember("Completed"),
+	}
+	if err := k.conn.AddMatchSignal(match...); err != nil {
+		return fmt.Errorf("failed to watch keyring prompt: %w", err)
+	}
+	defer k.conn.RemoveMatchSignal(match...)
+	signals := make(chan *dbus.Signal, 16)
+	k.conn.Signal(signals)
+	defer k.conn.RemoveSignal(signals)
+
+	if err := k.conn.Object(secretServiceName, path).Call(secretPromptIface+".Prompt", 0, "").Err; err != nil {
+		return fmt.Errorf("failed to show keyring prompt: %w", err)
+	}
+
+	timeout := time.After(secretPromptTimeout)
+	for {
+		select {
+		case signal := <-signals:
+			if signal.Path != path || signal.Name != secretPromptIface+".Completed" {
+				continue
+			}
+			if dismissed, ok := signal.Body[0].(bool); ok && dismissed {
+				return errors.New("keyring prompt dismissed")
+			}
+			return nil
+		case <-timeout:
+			return errors.New("timed out waiting for keyring prompt")
+		}
+	}
+}
+
+// search returns the unlocked items stored for account
+func (k *secretServiceKeychain) search(account string) ([]dbus.ObjectPath, error) {
+	var unlocked, locked []dbus.ObjectPath
+	if err := k.service().Call(secretServiceIface+".SearchItems", 0, k.attributes(account)).Store(&unlocked, &locked); err != nil {
+		return nil, fmt.Errorf("failed to search keyring: %w", err)
+	}
+	if len(locked) > 0 {
+		if err := k.unlock(locked); err != nil {
+			return nil, err
+		}
+		unlocked = append(unlocked, locked...)
+	}
+	return unlocked, nil
+}
+
+func (k *secretServiceKeychain) Get(account string) (string, error) {
+	items, err := k.search(account)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", ErrCredentialNotFound
+	}
+
+	session, err := k.openSession()
+	if err != nil {
+		return "", err
+	}
+	defer k.closeSession(session)
+
+	var secret secretValue
+	if err := k.conn.Object(secretServiceName, items[0]).Call(secretItemIface+".GetSecret", 0, session).Store(&secret); err != nil {
+		return "", fmt.Errorf("failed to read keyring item: %w", err)
+	}
+	return string(secret.Value), nil
+}
+
+func (k *secretServiceKeychain) Set(account, secret string) error {
+	if err := k.unlock([]dbus.ObjectPath{secretDefaultCollection}); err != nil {
+		return err
+	}
+	session, err := k.openSession()
+	if err != nil {
+		return err
+	}
+	defer k.closeSession(session)
+
+	properties := map[string]dbus.Variant{
+		"org.freedesktop.Secret.Item.Label":      dbus.MakeVariant("FoxyApply " + account),
+		"org.freedesktop.Secret.Item.Attributes": dbus.MakeVariant(k.attributes(account)),
+	}
+	value := secretValue{
+		Session:     session,
+		Parameters:  []byte{},
+		Value:       []byte(secret),
+		ContentType: "text/plain; charset=utf8",
+	}
+
+	var item, prompt dbus.ObjectPath
+	collection := k.conn.Object(secretServiceName, secretDefaultCollection)
+	if err := collection.Call(secretCollectionIface+".CreateItem", 0, properties, value, true).Store(&item, &prompt); err != nil {
+		return fmt.Errorf("failed to write keyring item: %w", err)
+	}
+	return k.prompt(prompt)
+}
+
+func (k *secretServiceKeychain) Delete(account string) error {
+	items, err := k.search(account)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		var prompt dbus.ObjectPath
+		if err := k.conn.Object(secretServiceName, item).Call(secretItemIface+".Delete", 0).Store(&prompt); err != nil {
+			return fmt.Errorf("failed to delete keyring item: %w", err)
+		}
+		if err := k.prompt(prompt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
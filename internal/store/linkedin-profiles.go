@@ -1,35 +1,129 @@
 package store
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // LinkedInProfile represents a user's LinkedIn profile
 type LinkedInProfile struct {
-	ID              int64     `json:"id"`
-	Email           string    `json:"email"`
-	Password        string    `json:"password"`
-	PhoneNumber     string    `json:"phoneNumber"`
-	Positions       []string  `json:"positions"`
-	Locations       []string  `json:"locations"`
-	RemoteOnly      bool      `json:"remoteOnly"`
-	ProfileURL      string    `json:"profileUrl"`
-	YearsExperience int       `json:"yearsExperience"`
-	UserCity        string    `json:"userCity"`
-	UserState       string    `json:"userState"`
-	ZipCode         string    `json:"zipCode"`
-	DesiredSalary   int       `json:"desiredSalary"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
+	ID                   int64     `json:"id"`
+	Email                string    `json:"email"`
+	Password             string    `json:"password"`
+	PhoneNumber          string    `json:"phoneNumber"`
+	Positions            []string  `json:"positions"`
+	Locations            []string  `json:"locations"`
+	RemoteOnly           bool      `json:"remoteOnly"`
+	ProfileURL           string    `json:"profileUrl"`
+	YearsExperience      int       `json:"yearsExperience"`
+	UserCity             string    `json:"userCity"`
+	UserState            string    `json:"userState"`
+	ZipCode              string    `json:"zipCode"`
+	DesiredSalary        int       `json:"desiredSalary"`
+	CoverLetter          string    `json:"coverLetter"`
+	TitleExcludeKeywords []string  `json:"titleExcludeKeywords"`
+	SearchSort           string    `json:"searchSort"`       // SearchSortDate or SearchSortRelevance
+	PostedWithin         string    `json:"postedWithin"`     // "", PostedWithinDay, PostedWithinWeek or PostedWithinMonth
+	ExperienceLevels     []string  `json:"experienceLevels"` // Experience* values; empty matches every level
+	JobTypes             []string  `json:"jobTypes"`         // JobType* values; empty matches every type
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+
+	passwordRef string // Credential store account holding the password, if any
+}
+
+// linkedInProfileColumns lists the columns read by scanLinkedInProfile, in order
+const linkedInProfileColumns = `id, email, password, password_ref, phone_number, positions, locations, remote_only,
+		        profile_url, years_experience, user_city, user_state, zip_code, desired_salary,
+		        cover_letter, title_exclude_keywords, search_sort, posted_within,
+		        experience_levels, job_types, created_at, updated_at`
+
+// Job search sort orders
+const (
+	SearchSortDate      = "date"
+	SearchSortRelevance = "relevance"
+)
+
+// Job search "date posted" windows. An empty value means any time.
+const (
+	PostedWithinDay   = "day"
+	PostedWithinWeek  = "week"
+	PostedWithinMonth = "month"
+)
+
+// Job search experience levels
+const (
+	ExperienceInternship = "internship"
+	ExperienceEntry      = "entry"
+	ExperienceAssociate  = "associate"
+	ExperienceMidSenior  = "mid-senior"
+	ExperienceDirector   = "director"
+	ExperienceExecutive  = "executive"
+)
+
+// Job search job types
+const (
+	JobTypeFullTime   = "full-time"
+	JobTypePartTime   = "part-time"
+	JobTypeContract   = "contract"
+	JobTypeTemporary  = "temporary"
+	JobTypeInternship = "internship"
+	JobTypeVolunteer  = "volunteer"
+	JobTypeOther      = "other"
+)
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanLinkedInProfile reads a profile row selected with linkedInProfileColumns
+func scanLinkedInProfile(row rowScanner) (*LinkedInProfile, error) {
+	profile := &LinkedInProfile{}
+	var positionsJSON, locationsJSON, excludeJSON, experienceJSON, jobTypesJSON string
+	var remoteOnly int
+
+	if err := row.Scan(
+		&profile.ID, &profile.Email, &profile.Password, &profile.passwordRef, &profile.PhoneNumber,
+		&positionsJSON, &locationsJSON, &remoteOnly,
+		&profile.ProfileURL, &profile.YearsExperience, &profile.UserCity, &profile.UserState,
+		&profile.ZipCode, &profile.DesiredSalary, &profile.CoverLetter, &excludeJSON, &profile.SearchSort, &profile.PostedWithin,
+		&experienceJSON, &jobTypesJSON, &profile.CreatedAt, &profile.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	// Parse JSON arrays
+	if err := json.Unmarshal([]byte(positionsJSON), &profile.Positions); err != nil {
+		profile.Positions = []string{}
+	}
+	if err := json.Unmarshal([]byte(locationsJSON), &profile.Locations); err != nil {
+		profile.Locations = []string{}
+	}
+	if err := json.Unmarshal([]byte(excludeJSON), &profile.TitleExcludeKeywords); err != nil {
+		profile.TitleExcludeKeywords = []string{}
+	}
+	if err := json.Unmarshal([]byte(experienceJSON), &profile.ExperienceLevels); err != nil {
+		profile.ExperienceLevels = []string{}
+	}
+	if err := json.Unmarshal([]byte(jobTypesJSON), &profile.JobTypes); err != nil {
+		profile.JobTypes = []string{}
+	}
+	profile.RemoteOnly = remoteOnly == 1
+
+	return profile, nil
 }
 
 // CreateLinkedInProfile creates a new LinkedIn profile
 func (s *Store) CreateLinkedInProfile(email, password string) (*LinkedInProfile, error) {
-	result, err := s.db.Exec(
-		"INSERT INTO linkedin_profiles (email, password) VALUES (?, ?)",
-		email, password,
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec(
+		"INSERT INTO linkedin_profiles (email, password) VALUES (?, '')",
+		email,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LinkedIn profile: %w", err)
@@ -39,51 +133,95 @@ func (s *Store) CreateLinkedInProfile(email, password string) (*LinkedInProfile,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get LinkedIn profile id: %w", err)
 	}
+	if _, err := s.savePassword(id, password); err != nil {
+		return nil, err
+	}
 
 	return s.GetLinkedInProfile(id)
 }
 
 // GetLinkedInProfile retrieves a LinkedIn profile by ID
 func (s *Store) GetLinkedInProfile(id int64) (*LinkedInProfile, error) {
-	profile := &LinkedInProfile{}
-	var positionsJSON, locationsJSON string
-	var remoteOnly int
-
-	err := s.db.QueryRow(
-		`SELECT id, email, password, phone_number, positions, locations, remote_only,
-		        profile_url, years_experience, user_city, user_state, created_at, updated_at
-		 FROM linkedin_profiles WHERE id = ?`,
+	db, done := s.conn()
+	defer done()
+	profile, err := scanLinkedInProfile(db.QueryRow(
+		`SELECT `+linkedInProfileColumns+`
+		 FROM linkedin_profiles WHERE id = ? AND deleted_at IS NULL`,
 		id,
-	).Scan(
-		&profile.ID, &profile.Email, &profile.Password, &profile.PhoneNumber,
-		&positionsJSON, &locationsJSON, &remoteOnly,
-		&profile.ProfileURL, &profile.YearsExperience, &profile.UserCity, &profile.UserState,
-		&profile.CreatedAt, &profile.UpdatedAt,
-	)
-
+	))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get LinkedIn profile: %w", err)
 	}
-
-	// Parse JSON arrays
-	if err := json.Unmarshal([]byte(positionsJSON), &profile.Positions); err != nil {
-		profile.Positions = []string{}
-	}
-	if err := json.Unmarshal([]byte(locationsJSON), &profile.Locations); err != nil {
-		profile.Locations = []string{}
+	if err := s.resolvePassword(profile); err != nil {
+		return nil, err
 	}
-	profile.RemoteOnly = remoteOnly == 1
 
 	return profile, nil
 }
 
 // ListLinkedInProfiles retrieves all LinkedIn profiles
 func (s *Store) ListLinkedInProfiles() ([]*LinkedInProfile, error) {
-	rows, err := s.db.Query(
-		`SELECT id, email, password, phone_number, positions, locations, remote_only,
-		        profile_url, years_experience, user_city, user_state, created_at, updated_at
-		 FROM linkedin_profiles ORDER BY updated_at DESC`,
-	)
+	return s.SearchLinkedInProfiles(ProfileFilter{})
+}
+
+// ListDeletedLinkedInProfiles retrieves the soft-deleted LinkedIn profiles
+// that can still be restored
+func (s *Store) ListDeletedLinkedInProfiles() ([]*LinkedInProfile, error) {
+	return s.SearchLinkedInProfiles(ProfileFilter{Deleted: true})
+}
+
+// CountProfiles returns how many LinkedIn profiles there are, leaving out
+// soft-deleted ones
+func (s *Store) CountProfiles() (int, error) {
+	db, done := s.conn()
+	defer done()
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM linkedin_profiles WHERE deleted_at IS NULL").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count LinkedIn profiles: %w", err)
+	}
+	return count, nil
+}
+
+// ProfileFilter narrows SearchLinkedInProfiles. Empty fields match
+// everything; substring matches are case-insensitive.
+type ProfileFilter struct {
+	EmailContains    string `json:"emailContains"`
+	PositionContains string `json:"positionContains"`
+	Limit            int    `json:"limit"` // <= 0 returns all rows
+	Offset           int    `json:"offset"`
+	Deleted          bool   `json:"deleted"` // Match soft-deleted profiles instead of live ones
+}
+
+// SearchLinkedInProfiles retrieves the LinkedIn profiles matching filter,
+// most recently updated first
+func (s *Store) SearchLinkedInProfiles(filter ProfileFilter) ([]*LinkedInProfile, error) {
+	db, done := s.conn()
+	defer done()
+	query := `SELECT ` + linkedInProfileColumns + `
+		 FROM linkedin_profiles WHERE deleted_at IS NULL`
+	if filter.Deleted {
+		query = `SELECT ` + linkedInProfileColumns + `
+		 FROM linkedin_profiles WHERE deleted_at IS NOT NULL`
+	}
+	var args []any
+
+	if filter.EmailContains != "" {
+		query += ` AND email LIKE ? ESCAPE '\'`
+		args = append(args, "%"+escapeLike(filter.EmailContains)+"%")
+	}
+	if filter.PositionContains != "" {
+		query += ` AND EXISTS (SELECT 1 FROM json_each(positions) WHERE value LIKE ? ESCAPE '\')`
+		args = append(args, "%"+escapeLike(filter.PositionContains)+"%")
+	}
+
+	query += ` ORDER BY updated_at DESC, id DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list LinkedIn profiles: %w", err)
 	}
@@ -91,28 +229,10 @@ func (s *Store) ListLinkedInProfiles() ([]*LinkedInProfile, error) {
 
 	var profiles []*LinkedInProfile
 	for rows.Next() {
-		profile := &LinkedInProfile{}
-		var positionsJSON, locationsJSON string
-		var remoteOnly int
-
-		if err := rows.Scan(
-			&profile.ID, &profile.Email, &profile.Password, &profile.PhoneNumber,
-			&positionsJSON, &locationsJSON, &remoteOnly,
-			&profile.ProfileURL, &profile.YearsExperience, &profile.UserCity, &profile.UserState,
-			&profile.CreatedAt, &profile.UpdatedAt,
-		); err != nil {
+		profile, err := scanLinkedInProfile(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan LinkedIn profile: %w", err)
 		}
-
-		// Parse JSON arrays
-		if err := json.Unmarshal([]byte(positionsJSON), &profile.Positions); err != nil {
-			profile.Positions = []string{}
-		}
-		if err := json.Unmarshal([]byte(locationsJSON), &profile.Locations); err != nil {
-			profile.Locations = []string{}
-		}
-		profile.RemoteOnly = remoteOnly == 1
-
 		profiles = append(profiles, profile)
 	}
 
@@ -120,25 +240,41 @@ func (s *Store) ListLinkedInProfiles() ([]*LinkedInProfile, error) {
 		return nil, fmt.Errorf("error iterating LinkedIn profiles: %w", err)
 	}
 
+	for _, profile := range profiles {
+		if err := s.resolvePassword(profile); err != nil {
+			return nil, err
+		}
+	}
+
 	return profiles, nil
 }
 
 // LinkedInProfileUpdate contains fields that can be updated on a profile
 type LinkedInProfileUpdate struct {
-	Email           string   `json:"email"`
-	Password        string   `json:"password"`
-	PhoneNumber     string   `json:"phoneNumber"`
-	Positions       []string `json:"positions"`
-	Locations       []string `json:"locations"`
-	RemoteOnly      bool     `json:"remoteOnly"`
-	ProfileURL      string   `json:"profileUrl"`
-	YearsExperience int      `json:"yearsExperience"`
-	UserCity        string   `json:"userCity"`
-	UserState       string   `json:"userState"`
+	Email                string   `json:"email"`
+	Password             string   `json:"password"`
+	PhoneNumber          string   `json:"phoneNumber"`
+	Positions            []string `json:"positions"`
+	Locations            []string `json:"locations"`
+	RemoteOnly           bool     `json:"remoteOnly"`
+	ProfileURL           string   `json:"profileUrl"`
+	YearsExperience      int      `json:"yearsExperience"`
+	UserCity             string   `json:"userCity"`
+	UserState            string   `json:"userState"`
+	ZipCode              string   `json:"zipCode"`
+	DesiredSalary        int      `json:"desiredSalary"`
+	CoverLetter          string   `json:"coverLetter"`
+	TitleExcludeKeywords []string `json:"titleExcludeKeywords"`
+	SearchSort           string   `json:"searchSort"`
+	PostedWithin         string   `json:"postedWithin"`
+	ExperienceLevels     []string `json:"experienceLevels"`
+	JobTypes             []string `json:"jobTypes"`
 }
 
 // UpdateLinkedInProfile updates an existing LinkedIn profile
 func (s *Store) UpdateLinkedInProfile(id int64, update LinkedInProfileUpdate) (*LinkedInProfile, error) {
+	db, done := s.conn()
+	defer done()
 	positionsJSON, err := json.Marshal(update.Positions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal positions: %w", err)
@@ -147,31 +283,289 @@ func (s *Store) UpdateLinkedInProfile(id int64, update LinkedInProfileUpdate) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal locations: %w", err)
 	}
+	excludeJSON, err := json.Marshal(nonNil(update.TitleExcludeKeywords))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal title exclude keywords: %w", err)
+	}
+	experienceJSON, err := json.Marshal(nonNil(update.ExperienceLevels))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal experience levels: %w", err)
+	}
+	jobTypesJSON, err := json.Marshal(nonNil(update.JobTypes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job types: %w", err)
+	}
 
 	remoteOnly := 0
 	if update.RemoteOnly {
 		remoteOnly = 1
 	}
 
-	_, err = s.db.Exec(
+	if update.SearchSort == "" {
+		update.SearchSort = SearchSortDate
+	}
+	if err := validateSearchSort(update.SearchSort); err != nil {
+		return nil, err
+	}
+	if err := validatePostedWithin(update.PostedWithin); err != nil {
+		return nil, err
+	}
+	if err := validateExperienceLevels(update.ExperienceLevels); err != nil {
+		return nil, err
+	}
+	if err := validateJobTypes(update.JobTypes); err != nil {
+		return nil, err
+	}
+
+	result, err := db.Exec(
 		`UPDATE linkedin_profiles SET
-			email = ?, password = ?, phone_number = ?, positions = ?, locations = ?,
+			email = ?, phone_number = ?, positions = ?, locations = ?,
 			remote_only = ?, profile_url = ?, years_experience = ?, user_city = ?, user_state = ?,
+			zip_code = ?, desired_salary = ?,
+			cover_letter = ?, title_exclude_keywords = ?, search_sort = ?, posted_within = ?,
+			experience_levels = ?, job_types = ?,
 			updated_at = CURRENT_TIMESTAMP
-		 WHERE id = ?`,
-		update.Email, update.Password, update.PhoneNumber, string(positionsJSON), string(locationsJSON),
-		remoteOnly, update.ProfileURL, update.YearsExperience, update.UserCity, update.UserState, id,
+		 WHERE id = ? AND deleted_at IS NULL`,
+		update.Email, update.PhoneNumber, string(positionsJSON), string(locationsJSON),
+		remoteOnly, update.ProfileURL, update.YearsExperience, update.UserCity, update.UserState,
+		update.ZipCode, update.DesiredSalary,
+		update.CoverLetter, string(excludeJSON), update.SearchSort, update.PostedWithin,
+		string(experienceJSON), string(jobTypesJSON), id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update LinkedIn profile: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected > 0 {
+		if _, err := s.savePassword(id, update.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetLinkedInProfile(id)
+}
+
+// validateSearchSort rejects unknown search sort orders
+func validateSearchSort(sort string) error {
+	if sort != SearchSortDate && sort != SearchSortRelevance {
+		return fmt.Errorf("invalid search sort: %s", sort)
+	}
+	return nil
+}
+
+// validatePostedWithin rejects unknown "date posted" windows
+func validatePostedWithin(within string) error {
+	switch within {
+	case "", PostedWithinDay, PostedWithinWeek, PostedWithinMonth:
+		return nil
+	}
+	return fmt.Errorf("invalid posted within window: %s", within)
+}
+
+// validateExperienceLevels rejects unknown experience levels
+func validateExperienceLevels(levels []string) error {
+	for _, level := range levels {
+		switch level {
+		case ExperienceInternship, ExperienceEntry, ExperienceAssociate,
+			ExperienceMidSenior, ExperienceDirector, ExperienceExecutive:
+		default:
+			return fmt.Errorf("invalid experience level: %s", level)
+		}
+	}
+	return nil
+}
+
+// validateJobTypes rejects unknown job types
+func validateJobTypes(types []string) error {
+	for _, jobType := range types {
+		switch jobType {
+		case JobTypeFullTime, JobTypePartTime, JobTypeContract, JobTypeTemporary,
+			JobTypeInternship, JobTypeVolunteer, JobTypeOther:
+		default:
+			return fmt.Errorf("invalid job type: %s", jobType)
+		}
+	}
+	return nil
+}
+
+// LinkedInProfilePatch holds the profile fields to change. Nil fields are
+// left as they are.
+type LinkedInProfilePatch struct {
+	Email                *string   `json:"email,omitempty"`
+	Password             *string   `json:"password,omitempty"`
+	PhoneNumber          *string   `json:"phoneNumber,omitempty"`
+	Positions            *[]string `json:"positions,omitempty"`
+	Locations            *[]string `json:"locations,omitempty"`
+	RemoteOnly           *bool     `json:"remoteOnly,omitempty"`
+	ProfileURL           *string   `json:"profileUrl,omitempty"`
+	YearsExperience      *int      `json:"yearsExperience,omitempty"`
+	UserCity             *string   `json:"userCity,omitempty"`
+	UserState            *string   `json:"userState,omitempty"`
+	ZipCode              *string   `json:"zipCode,omitempty"`
+	DesiredSalary        *int      `json:"desiredSalary,omitempty"`
+	CoverLetter          *string   `json:"coverLetter,omitempty"`
+	TitleExcludeKeywords *[]string `json:"titleExcludeKeywords,omitempty"`
+	SearchSort           *string   `json:"searchSort,omitempty"`
+	PostedWithin         *string   `json:"postedWithin,omitempty"`
+	ExperienceLevels     *[]string `json:"experienceLevels,omitempty"`
+	JobTypes             *[]string `json:"jobTypes,omitempty"`
+}
+
+// PatchLinkedInProfileFields updates only the given fields, keyed by their
+// JSON names in LinkedInProfile (e.g. "phoneNumber"). Unknown fields and
+// values of the wrong type are rejected before anything is written; null
+// values are ignored.
+func (s *Store) PatchLinkedInProfileFields(id int64, fields map[string]any) (*LinkedInProfile, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal profile fields: %w", err)
+	}
+
+	var patch LinkedInProfilePatch
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, fmt.Errorf("invalid profile fields: %w", err)
+	}
+	return s.PatchLinkedInProfile(id, patch)
+}
+
+// PatchLinkedInProfile updates only the fields set in patch. An empty
+// patch leaves the profile unchanged and just returns it.
+func (s *Store) PatchLinkedInProfile(id int64, patch LinkedInProfilePatch) (*LinkedInProfile, error) {
+	db, done := s.conn()
+	defer done()
+	var sets []string
+	var args []any
+	set := func(column string, value any) {
+		sets = append(sets, column+" = ?")
+		args = append(args, value)
+	}
+	setJSON := func(column string, values *[]string) error {
+		data, err := json.Marshal(nonNil(*values))
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", column, err)
+		}
+		set(column, string(data))
+		return nil
+	}
+
+	if patch.Email != nil {
+		set("email", *patch.Email)
+	}
+	if patch.Password != nil {
+		// Saved separately so it can go to the credential store, but still
+		// counts as a change
+		set("password", "")
+	}
+	if patch.PhoneNumber != nil {
+		set("phone_number", *patch.PhoneNumber)
+	}
+	if patch.Positions != nil {
+		if err := setJSON("positions", patch.Positions); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Locations != nil {
+		if err := setJSON("locations", patch.Locations); err != nil {
+			return nil, err
+		}
+	}
+	if patch.RemoteOnly != nil {
+		set("remote_only", boolToInt(*patch.RemoteOnly))
+	}
+	if patch.ProfileURL != nil {
+		set("profile_url", *patch.ProfileURL)
+	}
+	if patch.YearsExperience != nil {
+		set("years_experience", *patch.YearsExperience)
+	}
+	if patch.UserCity != nil {
+		set("user_city", *patch.UserCity)
+	}
+	if patch.UserState != nil {
+		set("user_state", *patch.UserState)
+	}
+	if patch.ZipCode != nil {
+		set("zip_code", *patch.ZipCode)
+	}
+	if patch.DesiredSalary != nil {
+		set("desired_salary", *patch.DesiredSalary)
+	}
+	if patch.CoverLetter != nil {
+		set("cover_letter", *patch.CoverLetter)
+	}
+	if patch.TitleExcludeKeywords != nil {
+		if err := setJSON("title_exclude_keywords", patch.TitleExcludeKeywords); err != nil {
+			return nil, err
+		}
+	}
+	if patch.SearchSort != nil {
+		if err := validateSearchSort(*patch.SearchSort); err != nil {
+			return nil, err
+		}
+		set("search_sort", *patch.SearchSort)
+	}
+	if patch.PostedWithin != nil {
+		if err := validatePostedWithin(*patch.PostedWithin); err != nil {
+			return nil, err
+		}
+		set("posted_within", *patch.PostedWithin)
+	}
+	if patch.ExperienceLevels != nil {
+		if err := validateExperienceLevels(*patch.ExperienceLevels); err != nil {
+			return nil, err
+		}
+		if err := setJSON("experience_levels", patch.ExperienceLevels); err != nil {
+			return nil, err
+		}
+	}
+	if patch.JobTypes != nil {
+		if err := validateJobTypes(*patch.JobTypes); err != nil {
+			return nil, err
+		}
+		if err := setJSON("job_types", patch.JobTypes); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sets) == 0 {
+		return s.GetLinkedInProfile(id)
+	}
+
+	result, err := db.Exec(
+		`UPDATE linkedin_profiles SET `+strings.Join(sets, ", ")+`, updated_at = CURRENT_TIMESTAMP
+		 WHERE id = ? AND deleted_at IS NULL`,
+		append(args, id)...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update LinkedIn profile: %w", err)
 	}
 
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return nil, fmt.Errorf("LinkedIn profile not found: %d", id)
+	}
+	if patch.Password != nil {
+		if _, err := s.savePassword(id, *patch.Password); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.GetLinkedInProfile(id)
 }
 
-// DeleteLinkedInProfile deletes a LinkedIn profile by ID
+// DeleteLinkedInProfile soft-deletes a LinkedIn profile by ID.
+// The row is kept until PurgeDeletedProfiles so it can be restored.
 func (s *Store) DeleteLinkedInProfile(id int64) error {
-	result, err := s.db.Exec("DELETE FROM linkedin_profiles WHERE id = ?", id)
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec(
+		"UPDATE linkedin_profiles SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL",
+		id,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to delete LinkedIn profile: %w", err)
 	}
@@ -187,3 +581,142 @@ func (s *Store) DeleteLinkedInProfile(id int64) error {
 
 	return nil
 }
+
+// DeleteLinkedInProfiles soft-deletes every profile in ids in a single
+// statement and returns how many it deleted. IDs that don't exist or are
+// already deleted are ignored.
+func (s *Store) DeleteLinkedInProfiles(ids []int64) (int, error) {
+	deleted, err := s.DeleteLinkedInProfilesReturningIDs(ids)
+	return len(deleted), err
+}
+
+// DeleteLinkedInProfilesReturningIDs is DeleteLinkedInProfiles returning
+// the IDs it deleted, so callers can report exactly which profiles went
+func (s *Store) DeleteLinkedInProfilesReturningIDs(ids []int64) ([]int64, error) {
+	db, done := s.conn()
+	defer done()
+	if len(ids) == 0 {
+		return []int64{}, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin delete: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(
+		`UPDATE linkedin_profiles SET deleted_at = CURRENT_TIMESTAMP
+		 WHERE deleted_at IS NULL AND id IN (`+placeholders+`)
+		 RETURNING id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete LinkedIn profiles: %w", err)
+	}
+	defer rows.Close()
+
+	deleted := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted profile id: %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted profiles: %w", err)
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit delete: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// RestoreLinkedInProfile undoes a soft delete
+func (s *Store) RestoreLinkedInProfile(id int64) error {
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec(
+		"UPDATE linkedin_profiles SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore LinkedIn profile: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("deleted LinkedIn profile not found: %d", id)
+	}
+
+	return nil
+}
+
+// PurgeDeletedProfiles permanently removes profiles soft-deleted more than
+// olderThan ago and returns the IDs it removed
+func (s *Store) PurgeDeletedProfiles(olderThan time.Duration) ([]int64, error) {
+	db, done := s.conn()
+	defer done()
+	cutoff := time.Now().UTC().Add(-olderThan).Format("2006-01-02 15:04:05")
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin purge: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(
+		`DELETE FROM linkedin_profiles WHERE deleted_at IS NOT NULL AND deleted_at <= ?
+		 RETURNING id, password_ref`,
+		cutoff,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted LinkedIn profiles: %w", err)
+	}
+	defer rows.Close()
+
+	purged := []int64{}
+	var refs []string
+	for rows.Next() {
+		var id int64
+		var ref string
+		if err := rows.Scan(&id, &ref); err != nil {
+			return nil, fmt.Errorf("failed to scan purged profile: %w", err)
+		}
+		purged = append(purged, id)
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating purged profiles: %w", err)
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	if s.credentials != nil {
+		for _, ref := range refs {
+			if err := s.credentials.Delete(ref); err != nil {
+				return purged, fmt.Errorf("failed to delete purged password: %w", err)
+			}
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMaintenance(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("maintenance@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if _, err := store.RecordApplication(Application{ProfileID: profile.ID, JobID: i, Title: "Engineer"}); err != nil {
+			t.Fatalf("failed to record application: %v", err)
+		}
+	}
+
+	if err := store.Maintenance(); err != nil {
+		t.Fatalf("maintenance failed: %v", err)
+	}
+
+	// TRUNCATE leaves an empty WAL behind
+	info, err := os.Stat(store.path + "-wal")
+	if err == nil && info.Size() != 0 {
+		t.Errorf("expected WAL to be truncated, got %d bytes", info.Size())
+	}
+
+	apps, err := store.ListApplications(profile.ID, "", 100, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if len(apps) != 50 {
+		t.Errorf("expected 50 applications after maintenance, got %d", len(apps))
+	}
+}
+
+func TestMaintenanceInMemory(t *testing.T) {
+	store, err := NewInMemory()
+	if err != nil {
+		t.Fatalf("failed to create in-memory store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.Maintenance(); err != nil {
+		t.Errorf("maintenance failed on in-memory store: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuestionAnswer is a profile's remembered answer to an application
+// question, reused whenever an employer asks it again
+type QuestionAnswer struct {
+	ID           int64     `json:"id"`
+	ProfileID    int64     `json:"profileId"`
+	QuestionHash string    `json:"questionHash"`
+	QuestionText string    `json:"questionText"`
+	InputType    string    `json:"inputType"`
+	Answer       string    `json:"answer"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// questionAnswerColumns lists the columns read by scanQuestionAnswer, in order
+const questionAnswerColumns = `id, profile_id, question_hash, question_text, input_type, answer, created_at, updated_at`
+
+// scanQuestionAnswer reads a row selected with questionAnswerColumns
+func scanQuestionAnswer(row rowScanner) (*QuestionAnswer, error) {
+	qa := &QuestionAnswer{}
+	err := row.Scan(&qa.ID, &qa.ProfileID, &qa.QuestionHash, &qa.QuestionText,
+		&qa.InputType, &qa.Answer, &qa.CreatedAt, &qa.UpdatedAt)
+	return qa, err
+}
+
+// NormalizeQuestion lowercases question, collapses its whitespace and drops
+// the trailing punctuation LinkedIn adds to required fields, so the same
+// question worded with different spacing matches
+func NormalizeQuestion(question string) string {
+	q := strings.Join(strings.Fields(strings.ToLower(question)), " ")
+	return strings.TrimRight(q, "?*: ")
+}
+
+// normalizeInputType lowercases an input type like "text" or "textarea"
+func normalizeInputType(inputType string) string {
+	return strings.ToLower(strings.TrimSpace(inputType))
+}
+
+// QuestionHash identifies a question by the type of field it's asked in
+// and its normalized text, so a number field and a text field with the
+// same label keep separate answers
+func QuestionHash(question, inputType string) string {
+	sum := sha256.Sum256([]byte(normalizeInputType(inputType) + "|" + NormalizeQuestion(question)))
+	return hex.EncodeToString(sum[:])
+}
+
+// SaveQuestionAnswer remembers answer to question, asked in a field of
+// inputType, for a profile, replacing any answer already stored for the
+// same normalized question and type
+func (s *Store) SaveQuestionAnswer(profileID int64, question, inputType, answer string) (*QuestionAnswer, error) {
+	db, done := s.conn()
+	defer done()
+	if NormalizeQuestion(question) == "" {
+		return nil, fmt.Errorf("question is required")
+	}
+	hash := QuestionHash(question, inputType)
+	if _, err := db.Exec(
+		`INSERT INTO question_answers (profile_id, question_hash, question_text, input_type, answer) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(profile_id, question_hash) DO UPDATE SET
+			question_text = excluded.question_text, answer = excluded.answer, updated_at = CURRENT_TIMESTAMP`,
+		profileID, hash, strings.TrimSpace(question), normalizeInputType(inputType), answer,
+	); err != nil {
+		return nil, fmt.Errorf("failed to save question answer: %w", err)
+	}
+
+	qa, err := scanQuestionAnswer(db.QueryRow(
+		`SELECT `+questionAnswerColumns+` FROM question_answers WHERE profile_id = ? AND question_hash = ?`,
+		profileID, hash,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question answer: %w", err)
+	}
+	return qa, nil
+}
+
+// GetQuestionAnswer returns the profile's stored answer to question, asked
+// in a field of inputType, or nil if it has never been answered
+func (s *Store) GetQuestionAnswer(profileID int64, question, inputType string) (*QuestionAnswer, error) {
+	db, done := s.conn()
+	defer done()
+	qa, err := scanQuestionAnswer(db.QueryRow(
+		`SELECT `+questionAnswerColumns+` FROM question_answers WHERE profile_id = ? AND question_hash = ?`,
+		profileID, QuestionHash(question, inputType),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question answer: %w", err)
+	}
+	return qa, nil
+}
+
+// ListQuestionAnswers returns a profile's stored answers ordered by question
+func (s *Store) ListQuestionAnswers(profileID int64) ([]*QuestionAnswer, error) {
+	db, done := s.conn()
+	defer done()
+	rows, err := db.Query(
+		`SELECT `+questionAnswerColumns+` FROM question_answers WHERE profile_id = ?
+		 ORDER BY question_text COLLATE NOCASE, id`,
+		profileID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list question answers: %w", err)
+	}
+	defer rows.Close()
+
+	answers := []*QuestionAnswer{}
+	for rows.Next() {
+		qa, err := scanQuestionAnswer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan question answer: %w", err)
+		}
+		answers = append(answers, qa)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating question answers: %w", err)
+	}
+
+	return answers, nil
+}
+
+// UpdateQuestionAnswer replaces a stored answer
+func (s *Store) UpdateQuestionAnswer(id int64, answer string) (*QuestionAnswer, error) {
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec(
+		"UPDATE question_answers SET answer = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		answer, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update question answer: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return nil, fmt.Errorf("question answer not found: %d", id)
+	}
+
+	qa, err := scanQuestionAnswer(db.QueryRow(
+		`SELECT `+questionAnswerColumns+` FROM question_answers WHERE id = ?`, id,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question answer: %w", err)
+	}
+	return qa, nil
+}
+
+// DeleteQuestionAnswer forgets a stored answer, so the question is worked
+// out afresh next time it's asked
+func (s *Store) DeleteQuestionAnswer(id int64) error {
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec("DELETE FROM question_answers WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete question answer: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("question answer not found: %d", id)
+	}
+
+	return nil
+}
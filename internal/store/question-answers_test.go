@@ -0,0 +1,94 @@
+package store
+
+import "testing"
+
+func TestQuestionAnswerCRUD(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("answers@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	missing, err := store.GetQuestionAnswer(profile.ID, "Are you authorized to work in the US?", "text")
+	if err != nil {
+		t.Fatalf("failed to get question answer: %v", err)
+	}
+	if missing != nil {
+		t.Fatalf("expected no answer yet, got %+v", missing)
+	}
+
+	saved, err := store.SaveQuestionAnswer(profile.ID, "Are you authorized to work in the US?", "Text", "Yes")
+	if err != nil {
+		t.Fatalf("failed to save question answer: %v", err)
+	}
+	if saved.QuestionHash != QuestionHash("are you authorized to work in the us", "text") || saved.InputType != "text" {
+		t.Errorf("expected hash of the normalized question and type, got %+v", saved)
+	}
+
+	// Spacing, case and the required marker don't change the question
+	found, err := store.GetQuestionAnswer(profile.ID, "  are you AUTHORIZED to work\nin the US? *", "text")
+	if err != nil {
+		t.Fatalf("failed to get question answer: %v", err)
+	}
+	if found == nil || found.ID != saved.ID || found.Answer != "Yes" {
+		t.Fatalf("expected saved answer to match, got %+v", found)
+	}
+
+	// The same question in another kind of field has its own answer
+	if other, err := store.GetQuestionAnswer(profile.ID, "Are you authorized to work in the US?", "textarea"); err != nil || other != nil {
+		t.Fatalf("expected no answer for a textarea, got %+v, %v", other, err)
+	}
+
+	// Saving the same question again replaces the answer
+	resaved, err := store.SaveQuestionAnswer(profile.ID, "Are you authorized to work in the US", "text", "No")
+	if err != nil {
+		t.Fatalf("failed to save question answer: %v", err)
+	}
+	if resaved.ID != saved.ID || resaved.Answer != "No" {
+		t.Errorf("expected answer %d to be replaced, got %+v", saved.ID, resaved)
+	}
+
+	if _, err := store.SaveQuestionAnswer(profile.ID, "How did you hear about us?", "textarea", "LinkedIn"); err != nil {
+		t.Fatalf("failed to save question answer: %v", err)
+	}
+	if _, err := store.SaveQuestionAnswer(profile.ID, " ? ", "text", "Nothing"); err == nil {
+		t.Error("expected a blank question to be rejected")
+	}
+
+	answers, err := store.ListQuestionAnswers(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to list question answers: %v", err)
+	}
+	if len(answers) != 2 || answers[0].QuestionText != "Are you authorized to work in the US" {
+		t.Fatalf("expected 2 answers ordered by question, got %+v", answers)
+	}
+
+	updated, err := store.UpdateQuestionAnswer(saved.ID, "Yes, no sponsorship needed")
+	if err != nil {
+		t.Fatalf("failed to update question answer: %v", err)
+	}
+	if updated.Answer != "Yes, no sponsorship needed" {
+		t.Errorf("expected updated answer, got %q", updated.Answer)
+	}
+
+	if err := store.DeleteQuestionAnswer(saved.ID); err != nil {
+		t.Fatalf("failed to delete question answer: %v", err)
+	}
+	if err := store.DeleteQuestionAnswer(saved.ID); err == nil {
+		t.Error("expected deleting a missing answer to fail")
+	}
+	if _, err := store.UpdateQuestionAnswer(saved.ID, "Yes"); err == nil {
+		t.Error("expected updating a missing answer to fail")
+	}
+
+	// Answers belong to their profile
+	other, err := store.CreateLinkedInProfile("other@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if qa, err := store.GetQuestionAnswer(other.ID, "How did you hear about us?", "textarea"); err != nil || qa != nil {
+		t.Errorf("expected no answer for another profile, got %+v, %v", qa, err)
+	}
+}
@@ -0,0 +1,261 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Resume is a resume file a profile can upload, picked per job by keywords
+type Resume struct {
+	ID        int64     `json:"id"`
+	ProfileID int64     `json:"profileId"`
+	Label     string    `json:"label"`
+	Path      string    `json:"path"`
+	Keywords  []string  `json:"keywords"`  // Job title words this resume targets
+	IsDefault bool      `json:"isDefault"` // Used when no keywords match
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// resumeColumns lists the columns read by scanResume, in order
+const resumeColumns = `id, profile_id, label, path, keywords, is_default, created_at, updated_at`
+
+// scanResume reads a row selected with resumeColumns
+func scanResume(row rowScanner) (*Resume, error) {
+	resume := &Resume{}
+	var keywordsJSON string
+	var isDefault int
+
+	if err := row.Scan(
+		&resume.ID, &resume.ProfileID, &resume.Label, &resume.Path,
+		&keywordsJSON, &isDefault, &resume.CreatedAt, &resume.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(keywordsJSON), &resume.Keywords); err != nil {
+		resume.Keywords = []string{}
+	}
+	resume.IsDefault = isDefault == 1
+
+	return resume, nil
+}
+
+// CreateResume adds a resume to resume.ProfileID. Marking it as the default
+// clears the flag on the profile's other resumes.
+func (s *Store) CreateResume(resume Resume) (*Resume, error) {
+	db, done := s.conn()
+	defer done()
+	if resume.Path == "" {
+		return nil, fmt.Errorf("resume path is required")
+	}
+	keywordsJSON, err := json.Marshal(nonNil(resume.Keywords))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal keywords: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if resume.IsDefault {
+		if err := clearDefaultResume(tx, resume.ProfileID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := tx.Exec(
+		"INSERT INTO resumes (profile_id, label, path, keywords, is_default) VALUES (?, ?, ?, ?, ?)",
+		resume.ProfileID, resume.Label, resume.Path, string(keywordsJSON), boolToInt(resume.IsDefault),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resume: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resume id: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit resume: %w", err)
+	}
+
+	return s.GetResume(id)
+}
+
+// GetResume retrieves a resume by ID
+func (s *Store) GetResume(id int64) (*Resume, error) {
+	db, done := s.conn()
+	defer done()
+	resume, err := scanResume(db.QueryRow(
+		`SELECT `+resumeColumns+` FROM resumes WHERE id = ?`, id,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resume: %w", err)
+	}
+	return resume, nil
+}
+
+// ListResumes returns a profile's resumes, the default first
+func (s *Store) ListResumes(profileID int64) ([]*Resume, error) {
+	db, done := s.conn()
+	defer done()
+	rows, err := db.Query(
+		`SELECT `+resumeColumns+` FROM resumes WHERE profile_id = ?
+		 ORDER BY is_default DESC, id`,
+		profileID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resumes: %w", err)
+	}
+	defer rows.Close()
+
+	resumes := []*Resume{}
+	for rows.Next() {
+		resume, err := scanResume(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan resume: %w", err)
+		}
+		resumes = append(resumes, resume)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating resumes: %w", err)
+	}
+
+	return resumes, nil
+}
+
+// UpdateResume replaces a resume's label, path, keywords and default flag
+func (s *Store) UpdateResume(id int64, update Resume) (*Resume, error) {
+	db, done := s.conn()
+	defer done()
+	if update.Path == "" {
+		return nil, fmt.Errorf("resume path is required")
+	}
+	keywordsJSON, err := json.Marshal(nonNil(update.Keywords))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal keywords: %w", err)
+	}
+
+	existing, err := s.GetResume(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if update.IsDefault {
+		if err := clearDefaultResume(tx, existing.ProfileID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(
+		`UPDATE resumes SET label = ?, path = ?, keywords = ?, is_default = ?,
+			updated_at = CURRENT_TIMESTAMP
+		 WHERE id = ?`,
+		update.Label, update.Path, string(keywordsJSON), boolToInt(update.IsDefault), id,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update resume: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit resume: %w", err)
+	}
+
+	return s.GetResume(id)
+}
+
+// DeleteResume removes a resume by ID
+func (s *Store) DeleteResume(id int64) error {
+	db, done := s.conn()
+	defer done()
+	result, err := db.Exec("DELETE FROM resumes WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete resume: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("resume not found: %d", id)
+	}
+
+	return nil
+}
+
+// clearDefaultResume unsets the default flag on all of a profile's resumes
+func clearDefaultResume(tx *sql.Tx, profileID int64) error {
+	if _, err := tx.Exec("UPDATE resumes SET is_default = 0 WHERE profile_id = ?", profileID); err != nil {
+		return fmt.Errorf("failed to clear default resume: %w", err)
+	}
+	return nil
+}
+
+// boolToInt converts a bool to SQLite's 0/1 representation
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// SelectResume picks the resume whose keywords best match jobTitle,
+// scoring one point per keyword found in the title as whole words
+// (case-insensitive). Ties go to the earlier resume. With no match it falls
+// back to the default resume, then the first one; it returns nil only when
+// resumes is empty.
+func SelectResume(resumes []*Resume, jobTitle string) *Resume {
+	if len(resumes) == 0 {
+		return nil
+	}
+
+	title := " " + strings.Join(titleWords(jobTitle), " ") + " "
+	var best *Resume
+	bestScore := 0
+	for _, resume := range resumes {
+		score := 0
+		for _, keyword := range resume.Keywords {
+			words := titleWords(keyword)
+			if len(words) > 0 && strings.Contains(title, " "+strings.Join(words, " ")+" ") {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = resume, score
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	for _, resume := range resumes {
+		if resume.IsDefault {
+			return resume
+		}
+	}
+	return resumes[0]
+}
+
+// titleWords lowercases s and splits it into words, keeping characters
+// like + and # that appear in names such as C++ and C#
+func titleWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+}
@@ -0,0 +1,108 @@
+package store
+
+import "testing"
+
+func TestResumeCRUD(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("resume@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	backend, err := store.CreateResume(Resume{
+		ProfileID: profile.ID,
+		Label:     "Backend",
+		Path:      "/resumes/backend.pdf",
+		Keywords:  []string{"backend", "go"},
+		IsDefault: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create resume: %v", err)
+	}
+	if !backend.IsDefault || len(backend.Keywords) != 2 {
+		t.Errorf("expected default resume with 2 keywords, got %+v", backend)
+	}
+
+	devops, err := store.CreateResume(Resume{
+		ProfileID: profile.ID,
+		Label:     "DevOps",
+		Path:      "/resumes/devops.pdf",
+		Keywords:  []string{"devops", "sre"},
+		IsDefault: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create resume: %v", err)
+	}
+
+	resumes, err := store.ListResumes(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to list resumes: %v", err)
+	}
+	if len(resumes) != 2 {
+		t.Fatalf("expected 2 resumes, got %d", len(resumes))
+	}
+	if resumes[0].ID != devops.ID || resumes[1].IsDefault {
+		t.Errorf("expected only the newest default to be kept, got %+v, %+v", resumes[0], resumes[1])
+	}
+
+	updated, err := store.UpdateResume(backend.ID, Resume{
+		Label:    "Backend (Go)",
+		Path:     "/resumes/backend-v2.pdf",
+		Keywords: []string{"golang"},
+	})
+	if err != nil {
+		t.Fatalf("failed to update resume: %v", err)
+	}
+	if updated.Label != "Backend (Go)" || updated.Path != "/resumes/backend-v2.pdf" || updated.ProfileID != profile.ID {
+		t.Errorf("expected update to apply, got %+v", updated)
+	}
+
+	if _, err := store.CreateResume(Resume{ProfileID: profile.ID}); err == nil {
+		t.Error("expected error creating resume without a path")
+	}
+
+	if err := store.DeleteResume(devops.ID); err != nil {
+		t.Fatalf("failed to delete resume: %v", err)
+	}
+	if err := store.DeleteResume(devops.ID); err == nil {
+		t.Error("expected error deleting missing resume")
+	}
+	if resumes, _ := store.ListResumes(profile.ID); len(resumes) != 1 {
+		t.Errorf("expected 1 resume after delete, got %d", len(resumes))
+	}
+}
+
+func TestSelectResume(t *testing.T) {
+	backend := &Resume{ID: 1, Keywords: []string{"backend", "go", "golang"}}
+	devops := &Resume{ID: 2, Keywords: []string{"devops", "site reliability", "sre"}}
+	general := &Resume{ID: 3, IsDefault: true}
+	resumes := []*Resume{backend, devops, general}
+
+	tests := []struct {
+		title string
+		want  int64
+	}{
+		{"Senior Backend Engineer (Go)", 1},
+		{"DevOps Engineer", 2},
+		{"Site Reliability Engineer", 2},
+		{"Backend DevOps Engineer - SRE", 2}, // two matches beat one
+		{"Backend / DevOps Engineer", 1},     // ties go to the earlier resume
+		{"Google Frontend Engineer", 3},      // "go" must be a whole word
+		{"Product Designer", 3},
+	}
+	for _, tt := range tests {
+		if got := SelectResume(resumes, tt.title); got == nil || got.ID != tt.want {
+			t.Errorf("SelectResume(%q) = %+v, want resume %d", tt.title, got, tt.want)
+		}
+	}
+
+	// Without a default, an unmatched title gets the first resume
+	if got := SelectResume([]*Resume{backend, devops}, "Designer"); got != backend {
+		t.Errorf("expected first resume as fallback, got %+v", got)
+	}
+	if got := SelectResume(nil, "Backend"); got != nil {
+		t.Errorf("expected nil with no resumes, got %+v", got)
+	}
+}
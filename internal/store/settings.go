@@ -0,0 +1,134 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// Setting keys
+const (
+	SettingChromeVersion     = "chrome_version"
+	SettingHeadless          = "headless"
+	SettingMaxApplications   = "max_applications"
+	SettingApplyDelaySeconds = "apply_delay_seconds"
+	SettingFollowCompany     = "follow_company"
+	SettingSpoofLocation     = "spoof_location"
+	SettingStartMinimized    = "start_minimized"
+
+	// SettingApplicationsPerHour caps how many applications are submitted
+	// each hour. 0 disables the limit.
+	SettingApplicationsPerHour = "applications_per_hour"
+
+	// SettingMaintenanceIntervalHours sets how often the app runs
+	// Store.Maintenance in the background. 0 disables it.
+	SettingMaintenanceIntervalHours = "maintenance_interval_hours"
+
+	// SettingRateLimitedUntil holds the RFC 3339 time a LinkedIn rate-limit
+	// cooldown ends, so it survives restarts. It is stored per profile
+	// under ProfileSetting.
+	SettingRateLimitedUntil = "rate_limited_until"
+
+	// SettingMaxConcurrentBrowsers caps how many profiles apply at once
+	SettingMaxConcurrentBrowsers = "max_concurrent_browsers"
+
+	// SettingMaxConsecutiveFailures stops an apply run after this many
+	// failed applications in a row. 0 uses the browser default.
+	SettingMaxConsecutiveFailures = "max_consecutive_failures"
+
+	// SettingManualLogin has the user sign in to LinkedIn by hand instead
+	// of the app submitting their credentials
+	SettingManualLogin = "manual_login"
+
+	// SettingManualLoginMinutes is how long to wait for a manual sign-in.
+	// 0 uses the browser default.
+	SettingManualLoginMinutes = "manual_login_minutes"
+
+	// SettingHumanTyping types into fields character by character with
+	// random pauses instead of all at once
+	SettingHumanTyping = "human_typing"
+
+	// SettingSessionTimeoutMinutes stops an apply run after this many
+	// minutes, not counting time paused. 0 means no limit.
+	SettingSessionTimeoutMinutes = "session_timeout_minutes"
+
+	// SettingPosterPreference limits applying to jobs with a hiring team
+	// listed ("recruiter") or without one ("company"). Empty applies to both.
+	SettingPosterPreference = "poster_preference"
+
+	// SettingMaxPerCompany skips a company's postings once this many
+	// applications went to it. 0 means no limit.
+	SettingMaxPerCompany = "max_per_company"
+
+	// SettingCompanyLimitDays also counts SettingMaxPerCompany against
+	// applications from this many days back. 0 counts the current run only.
+	SettingCompanyLimitDays = "company_limit_days"
+
+	// SettingApplyCheckpoint holds the JSON ApplyCheckpoint of a profile's
+	// unfinished apply run. It is stored per profile under ProfileSetting.
+	SettingApplyCheckpoint = "apply_checkpoint"
+)
+
+// ProfileSetting returns the key under which a per-profile setting is
+// stored for profileID
+func ProfileSetting(key string, profileID int64) string {
+	return fmt.Sprintf("%s:%d", key, profileID)
+}
+
+// GetSetting returns the value stored under key. The bool reports whether
+// the setting exists.
+func (s *Store) GetSetting(key string) (string, bool, error) {
+	db, done := s.conn()
+	defer done()
+	var value string
+	err := db.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// SetSetting stores value under key, replacing any existing value
+func (s *Store) SetSetting(key, value string) error {
+	db, done := s.conn()
+	defer done()
+	if _, err := db.Exec(
+		`INSERT INTO settings (key, value) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP`,
+		key, value,
+	); err != nil {
+		return fmt.Errorf("failed to set setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetBoolSetting returns the setting parsed as a bool, or def if it is
+// missing or not a valid bool
+func (s *Store) GetBoolSetting(key string, def bool) (bool, error) {
+	value, ok, err := s.GetSetting(key)
+	if err != nil || !ok {
+		return def, err
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return def, nil
+	}
+	return b, nil
+}
+
+// GetIntSetting returns the setting parsed as an int, or def if it is
+// missing or not a valid int
+func (s *Store) GetIntSetting(key string, def int) (int, error) {
+	value, ok, err := s.GetSetting(key)
+	if err != nil || !ok {
+		return def, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import "testing"
+
+func TestSettings(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if _, ok, err := store.GetSetting(SettingChromeVersion); err != nil || ok {
+		t.Fatalf("expected missing setting, got ok=%v err=%v", ok, err)
+	}
+
+	if err := store.SetSetting(SettingChromeVersion, "131.0.6778.85"); err != nil {
+		t.Fatalf("failed to set setting: %v", err)
+	}
+	value, ok, err := store.GetSetting(SettingChromeVersion)
+	if err != nil || !ok || value != "131.0.6778.85" {
+		t.Errorf("expected stored version, got %q ok=%v err=%v", value, ok, err)
+	}
+}
+
+func TestSettingsOverwrite(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if err := store.SetSetting(SettingHeadless, "false"); err != nil {
+		t.Fatalf("failed to set setting: %v", err)
+	}
+	if err := store.SetSetting(SettingHeadless, "true"); err != nil {
+		t.Fatalf("failed to overwrite setting: %v", err)
+	}
+	value, ok, err := store.GetSetting(SettingHeadless)
+	if err != nil || !ok || value != "true" {
+		t.Errorf("expected overwritten value 'true', got %q ok=%v err=%v", value, ok, err)
+	}
+}
+
+func TestTypedSettings(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	// Missing settings fall back to the default
+	if b, err := store.GetBoolSetting(SettingHeadless, true); err != nil || !b {
+		t.Errorf("expected default true, got %v, %v", b, err)
+	}
+	if n, err := store.GetIntSetting(SettingMaxApplications, 25); err != nil || n != 25 {
+		t.Errorf("expected default 25, got %d, %v", n, err)
+	}
+
+	if err := store.SetSetting(SettingHeadless, "true"); err != nil {
+		t.Fatalf("failed to set setting: %v", err)
+	}
+	if err := store.SetSetting(SettingMaxApplications, "10"); err != nil {
+		t.Fatalf("failed to set setting: %v", err)
+	}
+	if b, err := store.GetBoolSetting(SettingHeadless, false); err != nil || !b {
+		t.Errorf("expected true, got %v, %v", b, err)
+	}
+	if n, err := store.GetIntSetting(SettingMaxApplications, 0); err != nil || n != 10 {
+		t.Errorf("expected 10, got %d, %v", n, err)
+	}
+
+	// Unparseable values fall back to the default
+	if err := store.SetSetting(SettingApplyDelaySeconds, "soon"); err != nil {
+		t.Fatalf("failed to set setting: %v", err)
+	}
+	if n, err := store.GetIntSetting(SettingApplyDelaySeconds, 2); err != nil || n != 2 {
+		t.Errorf("expected default 2 for invalid value, got %d, %v", n, err)
+	}
+}
+
+func TestProfileSettings(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	if err := store.SetSetting(ProfileSetting(SettingRateLimitedUntil, 1), "2025-01-01T00:00:00Z"); err != nil {
+		t.Fatalf("failed to set setting: %v", err)
+	}
+	if _, ok, err := store.GetSetting(ProfileSetting(SettingRateLimitedUntil, 2)); err != nil || ok {
+		t.Errorf("expected other profile's setting to be missing, got ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := store.GetSetting(SettingRateLimitedUntil); err != nil || ok {
+		t.Errorf("expected shared setting to be missing, got ok=%v err=%v", ok, err)
+	}
+}
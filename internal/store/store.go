@@ -8,13 +8,24 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
 )
 
 // Store handles all database operations
 type Store struct {
-	db *sql.DB
+	gate        opGate // guards db, which Restore swaps
+	db          *sql.DB
+	path        string
+	opts        Options
+	credentials CredentialStore // nil keeps passwords in the database, encrypted
+
+	keyMu       sync.Mutex
+	passwordKey []byte // guarded by keyMu; loaded by passwordCipher
 }
 
 // New creates a new Store with SQLite database
@@ -24,31 +35,67 @@ func New() (*Store, error) {
 		return nil, fmt.Errorf("failed to get database path: %w", err)
 	}
 
+	return NewWithPath(dbPath)
+}
+
+// Options tunes the SQLite connection pool. Zero values use the defaults.
+type Options struct {
+	BusyTimeout  time.Duration // How long a connection waits on a lock (0 = DefaultBusyTimeout)
+	MaxOpenConns int           // Maximum open connections (0 = DefaultMaxOpenConns)
+}
+
+// Connection defaults used when Options leaves them unset
+const (
+	DefaultBusyTimeout  = 5 * time.Second
+	DefaultMaxOpenConns = 4
+)
+
+// NewWithPath creates a Store with a specific database path (useful for testing)
+func NewWithPath(dbPath string) (*Store, error) {
+	return NewWithOptions(dbPath, Options{})
+}
+
+// NewWithOptions creates a Store at dbPath with a tuned connection pool
+func NewWithOptions(dbPath string, opts Options) (*Store, error) {
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := openDB(dbPath, true, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
-	// Enable WAL mode for better concurrent access
-	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+	store := &Store{db: db, path: dbPath, opts: opts}
+
+	// Run migrations
+	if err := store.migrate(); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	// Enable foreign keys
-	if _, err := db.Exec("PRAGMA foreign_keys=ON"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	return store, nil
+}
+
+// memoryDBCounter gives each in-memory store its own database name
+var memoryDBCounter atomic.Int64
+
+// NewInMemory creates a Store backed by an in-memory SQLite database with
+// migrations applied. Each call returns an independent database that lives
+// until the Store is closed.
+func NewInMemory() (*Store, error) {
+	// A named shared-cache database lets every pooled connection see the
+	// same data; plain :memory: would give each connection its own DB.
+	dsn := fmt.Sprintf("file:foxyapply-mem-%d?mode=memory&cache=shared", memoryDBCounter.Add(1))
+
+	db, err := openDB(dsn, false, Options{})
+	if err != nil {
+		return nil, err
 	}
 
 	store := &Store{db: db}
 
-	// Run migrations
 	if err := store.migrate(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
@@ -57,40 +104,151 @@ func New() (*Store, error) {
 	return store, nil
 }
 
-// NewWithPath creates a Store with a specific database path (useful for testing)
-func NewWithPath(dbPath string) (*Store, error) {
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
-		return nil, fmt.Errorf("failed to create data directory: %w", err)
+// openDB opens the SQLite database at dsn and applies connection pragmas.
+// WAL is skipped for in-memory databases, which don't support it.
+func openDB(dsn string, wal bool, opts Options) (*sql.DB, error) {
+	busyTimeout := opts.BusyTimeout
+	if busyTimeout <= 0 {
+		busyTimeout = DefaultBusyTimeout
+	}
+	maxOpenConns := opts.MaxOpenConns
+	if maxOpenConns <= 0 {
+		maxOpenConns = DefaultMaxOpenConns
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	// Per-connection settings go in the DSN so every pooled connection gets
+	// them. Immediate transactions take the write lock up front, so the busy
+	// timeout applies instead of failing on a read-to-write upgrade.
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	dsn += sep + fmt.Sprintf("_pragma=busy_timeout(%d)&_pragma=foreign_keys(1)&_txlock=immediate", busyTimeout.Milliseconds())
+
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db.SetMaxOpenConns(maxOpenConns)
 
-	store := &Store{db: db}
-
-	if err := store.migrate(); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	// Enable WAL mode for better concurrent access
+	if wal {
+		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
+		}
 	}
 
-	return store, nil
+	return db, nil
 }
 
 // Close closes the database connection
 func (s *Store) Close() error {
-	return s.db.Close()
+	db, done := s.conn()
+	defer done()
+	return db.Close()
+}
+
+// DB returns the underlying database connection for advanced queries and a
+// done func to call once they finish. Restore swaps the connection after
+// every caller is done, so it mustn't be kept past done.
+func (s *Store) DB() (*sql.DB, func()) {
+	return s.conn()
+}
+
+// conn returns the current database connection for one operation and a
+// done func to call when the operation finishes. Restore waits for every
+// operation to finish before it swaps the connection.
+func (s *Store) conn() (*sql.DB, func()) {
+	s.gate.enter()
+	return s.db, s.gate.leave
+}
+
+// opGate lets Store operations run side by side while holding off a
+// Restore until none are running, and holds off new operations while the
+// Restore runs. Unlike sync.RWMutex, a waiting Restore doesn't block new
+// operations, so an operation can call other Store methods.
+type opGate struct {
+	mu       sync.Mutex
+	changed  *sync.Cond // Broadcast when active drops to zero or a swap ends
+	active   int
+	swapping bool
+}
+
+// wait blocks until the gate changes; g.mu must be held
+func (g *opGate) wait() {
+	if g.changed == nil {
+		g.changed = sync.NewCond(&g.mu)
+	}
+	g.changed.Wait()
 }
 
-// DB returns the underlying database connection (for advanced queries)
-func (s *Store) DB() *sql.DB {
-	return s.db
+// broadcast wakes every waiter; g.mu must be held
+func (g *opGate) broadcast() {
+	if g.changed != nil {
+		g.changed.Broadcast()
+	}
 }
 
-// getDBPath returns the platform-specific database path
+// enter starts an operation, waiting out a swap in progress
+func (g *opGate) enter() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for g.swapping {
+		g.wait()
+	}
+	g.active++
+}
+
+// leave ends an operation started with enter
+func (g *opGate) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.active == 0 {
+		g.broadcast()
+	}
+}
+
+// swap waits until no operations are running and holds off new ones until
+// the returned func is called
+func (g *opGate) swap() func() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for g.swapping || g.active > 0 {
+		g.wait()
+	}
+	g.swapping = true
+	return func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.swapping = false
+		g.broadcast()
+	}
+}
+
+// DataDirEnv names the environment variable that overrides the data
+// directory, for portable installs or keeping several accounts apart
+const DataDirEnv = "FOXYAPPLY_DATA_DIR"
+
+// DataDirOverride returns the data directory set through DataDirEnv, or ""
+// when the platform default should be used
+func DataDirOverride() string {
+	return strings.TrimSpace(os.Getenv(DataDirEnv))
+}
+
+// NewInDataDir creates a Store whose database lives under dataDir
+func NewInDataDir(dataDir string) (*Store, error) {
+	return NewWithPath(filepath.Join(dataDir, "data.db"))
+}
+
+// getDBPath returns the platform-specific database path, or one under
+// DataDirEnv when it is set
 func getDBPath() (string, error) {
+	if dir := DataDirOverride(); dir != "" {
+		return filepath.Join(dir, "data.db"), nil
+	}
+
 	var baseDir string
 
 	switch runtime.GOOS {
@@ -127,57 +285,230 @@ func getDBPath() (string, error) {
 	return filepath.Join(baseDir, "data.db"), nil
 }
 
-// migrate runs database migrations
+// Migration is a single all-or-nothing schema change. Up runs inside a
+// transaction together with the schema_version bookkeeping.
+type Migration struct {
+	Version int
+	Up      func(tx *sql.Tx) error
+}
+
+// execSQL returns a migration step that runs the given statements in order
+func execSQL(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// migrations is the ordered list of schema migrations. Versions match the
+// slice positions used before the Migration type existed, so databases
+// created by older releases are recognised correctly. Only ever append.
+var migrations = []Migration{
+	// Create schema_version table
+	{Version: 0, Up: execSQL(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY
+	)`)},
+
+	// Create linkedin_profiles table
+	{Version: 1, Up: execSQL(`CREATE TABLE IF NOT EXISTS linkedin_profiles (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		email TEXT NOT NULL,
+		password TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)},
+
+	// Add new columns to linkedin_profiles
+	{Version: 2, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN phone_number TEXT DEFAULT ''`)},
+	{Version: 3, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN positions TEXT DEFAULT '[]'`)},
+	{Version: 4, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN locations TEXT DEFAULT '[]'`)},
+	{Version: 5, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN remote_only INTEGER DEFAULT 0`)},
+	{Version: 6, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN profile_url TEXT DEFAULT ''`)},
+	{Version: 7, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN years_experience INTEGER DEFAULT 0`)},
+	{Version: 8, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN user_city TEXT DEFAULT ''`)},
+	{Version: 9, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN user_state TEXT DEFAULT ''`)},
+
+	// Cover letter and free-text answer template
+	{Version: 10, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN cover_letter TEXT DEFAULT ''`)},
+
+	// Soft-delete support
+	{Version: 11, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN deleted_at DATETIME`)},
+
+	// Job title exclusion keywords
+	{Version: 12, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN title_exclude_keywords TEXT DEFAULT '[]'`)},
+
+	// Application history
+	{Version: 13, Up: execSQL(`CREATE TABLE IF NOT EXISTS applications (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		profile_id INTEGER NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+		job_id INTEGER NOT NULL,
+		title TEXT DEFAULT '',
+		company TEXT DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'applied',
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)},
+
+	// Key/value app settings
+	{Version: 14, Up: execSQL(`CREATE TABLE IF NOT EXISTS settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)},
+
+	// Job search sort order and freshness filter
+	{Version: 15, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN search_sort TEXT DEFAULT 'date'`)},
+	{Version: 16, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN posted_within TEXT DEFAULT ''`)},
+
+	// Per-profile resumes chosen by job title keywords
+	{Version: 17, Up: execSQL(`CREATE TABLE IF NOT EXISTS resumes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		profile_id INTEGER NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+		label TEXT NOT NULL DEFAULT '',
+		path TEXT NOT NULL,
+		keywords TEXT DEFAULT '[]',
+		is_default INTEGER DEFAULT 0,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)},
+
+	// Scraped jobs waiting to be applied to
+	{Version: 18, Up: execSQL(`CREATE TABLE IF NOT EXISTS job_queue (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		profile_id INTEGER NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+		job_id INTEGER NOT NULL,
+		title TEXT DEFAULT '',
+		company TEXT DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'pending',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(profile_id, job_id)
+	)`)},
+
+	// Zip code and desired salary, which the profile struct already carried
+	{Version: 19, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN zip_code TEXT DEFAULT ''`)},
+	{Version: 20, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN desired_salary INTEGER DEFAULT 0`)},
+
+	// Job search experience level and job type filters
+	{Version: 21, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN experience_levels TEXT DEFAULT '[]'`)},
+	{Version: 22, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN job_types TEXT DEFAULT '[]'`)},
+
+	// Job description text scraped while applying
+	{Version: 23, Up: execSQL(`ALTER TABLE applications ADD COLUMN description TEXT DEFAULT ''`)},
+	// Where a profile's password is kept: password_ref names its credential
+	// store entry, or is empty when the encrypted password is in the
+	// password column
+	{Version: 24, Up: execSQL(`ALTER TABLE linkedin_profiles ADD COLUMN password_ref TEXT NOT NULL DEFAULT ''`)},
+
+	// Remembered answers to employer questions
+	{Version: 25, Up: execSQL(`CREATE TABLE IF NOT EXISTS question_answers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		profile_id INTEGER NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+		question_hash TEXT NOT NULL,
+		question_text TEXT NOT NULL,
+		input_type TEXT NOT NULL DEFAULT '',
+		answer TEXT NOT NULL DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(profile_id, question_hash)
+	)`)},
+
+	// Why a skipped job was skipped
+	{Version: 26, Up: execSQL(`ALTER TABLE applications ADD COLUMN reason TEXT DEFAULT ''`)},
+
+	// Application lookups by job and by status. A job can have several
+	// attempts, so (profile_id, job_id) isn't unique.
+	{Version: 27, Up: execSQL(
+		`CREATE INDEX IF NOT EXISTS idx_applications_profile_job ON applications(profile_id, job_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_applications_profile_status ON applications(profile_id, status)`,
+	)},
+}
+
+// migrate runs database migrations, then sets up the optional indexes
 func (s *Store) migrate() error {
-	migrations := []string{
-		// Migration 1: Create schema_version table
-		`CREATE TABLE IF NOT EXISTS schema_version (
-			version INTEGER PRIMARY KEY
-		)`,
-
-		// Migration 2: Create linkedin_profiles table
-
-		`CREATE TABLE IF NOT EXISTS linkedin_profiles (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			email TEXT NOT NULL,
-			password TEXT NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)`,
-
-		// Migration 3: Add new columns to linkedin_profiles
-		`ALTER TABLE linkedin_profiles ADD COLUMN phone_number TEXT DEFAULT ''`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN positions TEXT DEFAULT '[]'`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN locations TEXT DEFAULT '[]'`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN remote_only INTEGER DEFAULT 0`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN profile_url TEXT DEFAULT ''`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN years_experience INTEGER DEFAULT 0`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN user_city TEXT DEFAULT ''`,
-		`ALTER TABLE linkedin_profiles ADD COLUMN user_state TEXT DEFAULT ''`,
-	}
-
-	for i, migration := range migrations {
-		// Check if migration already applied
-		var count int
-		err := s.db.QueryRow("SELECT COUNT(*) FROM schema_version WHERE version = ?", i).Scan(&count)
-		if err != nil && i > 0 {
-			// Table might not exist for first migration
-			return fmt.Errorf("migration %d failed: %w", i, err)
+	if err := s.applyMigrations(migrations); err != nil {
+		return err
+	}
+	// Search falls back to LIKE queries without the index, so a failure
+	// isn't fatal; it's tried again on the next start
+	_ = s.ensureApplicationsFTS()
+	return nil
+}
+
+// applyMigrations applies every migration newer than the highest recorded
+// version. Each one runs with its schema_version record in a single
+// transaction, so a failure never leaves a migration half-applied.
+func (s *Store) applyMigrations(migrations []Migration) error {
+	current, err := s.schemaVersion()
+	if err != nil {
+		return err
+	}
+
+	last := -1
+	for _, m := range migrations {
+		if m.Version <= last {
+			return fmt.Errorf("migration %d is out of order", m.Version)
 		}
+		last = m.Version
 
-		if count > 0 {
+		if m.Version <= current {
 			continue // Already applied
 		}
 
-		// Apply migration
-		if _, err := s.db.Exec(migration); err != nil {
-			return fmt.Errorf("migration %d failed: %w", i, err)
+		if err := s.applyMigration(m); err != nil {
+			return err
 		}
+	}
 
-		// Record migration
-		if _, err := s.db.Exec("INSERT INTO schema_version (version) VALUES (?)", i); err != nil {
-			return fmt.Errorf("failed to record migration %d: %w", i, err)
-		}
+	return nil
+}
+
+// schemaVersion returns the highest applied migration version, or -1 for a
+// fresh database
+func (s *Store) schemaVersion() (int, error) {
+	db, done := s.conn()
+	defer done()
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY
+	)`); err != nil {
+		return 0, fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if !version.Valid {
+		return -1, nil
+	}
+	return int(version.Int64), nil
+}
+
+// applyMigration runs a single migration and records it atomically
+func (s *Store) applyMigration(m Migration) error {
+	db, done := s.conn()
+	defer done()
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %w", m.Version, err)
+	}
+	defer tx.Rollback()
+
+	// Apply migration
+	if err := m.Up(tx); err != nil {
+		return fmt.Errorf("migration %d failed: %w", m.Version, err)
+	}
+
+	// Record migration
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", m.Version); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d: %w", m.Version, err)
 	}
 
 	return nil
@@ -1,9 +1,13 @@
 package store
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 )
 
 func setupTestStore(t *testing.T) (*Store, func()) {
@@ -38,10 +42,134 @@ func TestNewStore(t *testing.T) {
 		t.Fatal("store should not be nil")
 	}
 
-	if store.DB() == nil {
+	db, done := store.DB()
+	defer done()
+	if db == nil {
 		t.Fatal("database connection should not be nil")
 	}
 }
+
+func TestConcurrentReadsAndWrites(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("concurrent@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	const workers, perWorker = 8, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker*2)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				app := Application{ProfileID: profile.ID, JobID: w*perWorker + i, Title: "Engineer"}
+				if _, err := store.RecordApplication(app); err != nil {
+					errs <- err
+				}
+				if err := store.SetSetting(fmt.Sprintf("worker_%d", w), fmt.Sprint(i)); err != nil {
+					errs <- err
+				}
+				if _, err := store.ListApplications(profile.ID, "", 10, 0); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent access failed: %v", err)
+	}
+
+	apps, err := store.ListApplications(profile.ID, "", workers*perWorker, 0)
+	if err != nil {
+		t.Fatalf("failed to list applications: %v", err)
+	}
+	if len(apps) != workers*perWorker {
+		t.Errorf("expected %d applications, got %d", workers*perWorker, len(apps))
+	}
+}
+
+func TestDataDirOverride(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv(DataDirEnv, dataDir)
+
+	dbPath, err := getDBPath()
+	if err != nil {
+		t.Fatalf("failed to get db path: %v", err)
+	}
+	if want := filepath.Join(dataDir, "data.db"); dbPath != want {
+		t.Errorf("expected db path %s, got %s", want, dbPath)
+	}
+
+	dir, err := GetDataDir()
+	if err != nil {
+		t.Fatalf("failed to get data dir: %v", err)
+	}
+	if dir != dataDir {
+		t.Errorf("expected data dir %s, got %s", dataDir, dir)
+	}
+
+	store, err := New()
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+	if _, err := os.Stat(filepath.Join(dataDir, "data.db")); err != nil {
+		t.Errorf("expected database under data dir: %v", err)
+	}
+}
+
+func TestNewInMemory(t *testing.T) {
+	first, err := NewInMemory()
+	if err != nil {
+		t.Fatalf("failed to create in-memory store: %v", err)
+	}
+	defer first.Close()
+
+	second, err := NewInMemory()
+	if err != nil {
+		t.Fatalf("failed to create in-memory store: %v", err)
+	}
+	defer second.Close()
+
+	if _, err := first.CreateLinkedInProfile("memory@example.com", "password123"); err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	profiles, err := first.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Errorf("expected 1 profile, got %d", len(profiles))
+	}
+
+	// In-memory stores are isolated from each other
+	profiles, err = second.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("expected second store to be empty, got %d profiles", len(profiles))
+	}
+
+	var foreignKeys int
+	db, done := first.DB()
+	defer done()
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		t.Fatalf("failed to read foreign_keys pragma: %v", err)
+	}
+	if foreignKeys != 1 {
+		t.Error("expected foreign keys to be enabled")
+	}
+}
+
 func TestLinkedInProfiles(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
@@ -72,16 +200,18 @@ func TestLinkedInProfiles(t *testing.T) {
 
 	// Test UpdateLinkedInProfile
 	updated, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
-		Email:           "test2@example.com",
-		Password:        "newpassword",
-		PhoneNumber:     "555-1234",
-		Positions:       []string{"Software Engineer", "Backend Developer"},
-		Locations:       []string{"San Francisco", "Remote"},
-		RemoteOnly:      true,
-		ProfileURL:      "https://linkedin.com/in/testuser",
-		YearsExperience: 5,
-		UserCity:        "San Francisco",
-		UserState:       "CA",
+		Email:                "test2@example.com",
+		Password:             "newpassword",
+		PhoneNumber:          "555-1234",
+		Positions:            []string{"Software Engineer", "Backend Developer"},
+		Locations:            []string{"San Francisco", "Remote"},
+		RemoteOnly:           true,
+		ProfileURL:           "https://linkedin.com/in/testuser",
+		YearsExperience:      5,
+		UserCity:             "San Francisco",
+		UserState:            "CA",
+		CoverLetter:          "Dear hiring manager",
+		TitleExcludeKeywords: []string{"senior", "clearance"},
 	})
 	if err != nil {
 		t.Fatalf("failed to update LinkedIn profile: %v", err)
@@ -111,6 +241,14 @@ func TestLinkedInProfiles(t *testing.T) {
 		t.Errorf("expected yearsExperience 5, got %d", updated.YearsExperience)
 	}
 
+	if updated.CoverLetter != "Dear hiring manager" {
+		t.Errorf("expected coverLetter 'Dear hiring manager', got '%s'", updated.CoverLetter)
+	}
+
+	if len(updated.TitleExcludeKeywords) != 2 || updated.TitleExcludeKeywords[1] != "clearance" {
+		t.Errorf("expected titleExcludeKeywords to have 2 items, got %v", updated.TitleExcludeKeywords)
+	}
+
 	// Test DeleteLinkedInProfile
 	err = store.DeleteLinkedInProfile(profile.ID)
 	if err != nil {
@@ -122,3 +260,507 @@ func TestLinkedInProfiles(t *testing.T) {
 		t.Error("expected error when getting deleted LinkedIn profile")
 	}
 }
+
+func TestMigrationRollsBackOnFailure(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	version := migrations[len(migrations)-1].Version + 1
+	failing := append(append([]Migration{}, migrations...), Migration{
+		Version: version,
+		Up: execSQL(
+			`ALTER TABLE linkedin_profiles ADD COLUMN partial_column TEXT DEFAULT ''`,
+			`ALTER TABLE missing_table ADD COLUMN other TEXT`,
+		),
+	})
+
+	if err := store.applyMigrations(failing); err == nil {
+		t.Fatal("expected failing migration to return an error")
+	}
+
+	var count int
+	db, done := store.DB()
+	defer done()
+	if err := db.QueryRow(
+		"SELECT COUNT(*) FROM pragma_table_info('linkedin_profiles') WHERE name = 'partial_column'",
+	).Scan(&count); err != nil {
+		t.Fatalf("failed to inspect table info: %v", err)
+	}
+	if count != 0 {
+		t.Error("expected partial_column to be rolled back")
+	}
+
+	if err := db.QueryRow(
+		"SELECT COUNT(*) FROM schema_version WHERE version = ?", version,
+	).Scan(&count); err != nil {
+		t.Fatalf("failed to query schema_version: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected migration %d not to be recorded", version)
+	}
+
+	// The original migrations should still apply cleanly afterwards
+	if err := store.migrate(); err != nil {
+		t.Fatalf("expected re-running migrations to succeed: %v", err)
+	}
+}
+
+func TestMigrationsAreIdempotent(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	// setupTestStore already migrated once
+	if err := store.migrate(); err != nil {
+		t.Fatalf("expected second migrate to succeed: %v", err)
+	}
+
+	version, err := store.schemaVersion()
+	if err != nil {
+		t.Fatalf("failed to read schema version: %v", err)
+	}
+	if want := migrations[len(migrations)-1].Version; version != want {
+		t.Errorf("expected schema version %d, got %d", want, version)
+	}
+
+	var count int
+	db, done := store.DB()
+	defer done()
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&count); err != nil {
+		t.Fatalf("failed to count schema_version rows: %v", err)
+	}
+	if count != len(migrations) {
+		t.Errorf("expected %d schema_version rows, got %d", len(migrations), count)
+	}
+}
+
+func TestMigrationsRejectOutOfOrderVersions(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	noop := execSQL()
+	err := store.applyMigrations([]Migration{{Version: 0, Up: noop}, {Version: 0, Up: noop}})
+	if err == nil {
+		t.Error("expected duplicate migration versions to be rejected")
+	}
+}
+
+func TestSoftDeleteLinkedInProfile(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("soft@example.com", "password123")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+
+	if err := store.DeleteLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("failed to delete LinkedIn profile: %v", err)
+	}
+
+	// Soft-deleted profiles are hidden
+	profiles, err := store.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("expected deleted profile to be hidden from list, got %d", len(profiles))
+	}
+	if err := store.DeleteLinkedInProfile(profile.ID); err == nil {
+		t.Error("expected error deleting an already deleted profile")
+	}
+	deleted, err := store.ListDeletedLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list deleted LinkedIn profiles: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != profile.ID {
+		t.Errorf("expected deleted profile to be listed, got %v", deleted)
+	}
+
+	// Restore brings it back
+	if err := store.RestoreLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("failed to restore LinkedIn profile: %v", err)
+	}
+	restored, err := store.GetLinkedInProfile(profile.ID)
+	if err != nil {
+		t.Fatalf("expected restored profile to be visible: %v", err)
+	}
+	if restored.Email != "soft@example.com" {
+		t.Errorf("expected email 'soft@example.com', got '%s'", restored.Email)
+	}
+	if err := store.RestoreLinkedInProfile(profile.ID); err == nil {
+		t.Error("expected error restoring a profile that isn't deleted")
+	}
+
+	// Purge respects the age cutoff
+	if err := store.DeleteLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("failed to delete LinkedIn profile: %v", err)
+	}
+	if purged, err := store.PurgeDeletedProfiles(24 * time.Hour); err != nil || len(purged) != 0 {
+		t.Fatalf("expected nothing to be purged, got %v (%v)", purged, err)
+	}
+	if err := store.RestoreLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("expected recently deleted profile to survive purge: %v", err)
+	}
+
+	if err := store.DeleteLinkedInProfile(profile.ID); err != nil {
+		t.Fatalf("failed to delete LinkedIn profile: %v", err)
+	}
+	if purged, err := store.PurgeDeletedProfiles(0); err != nil || !slices.Equal(purged, []int64{profile.ID}) {
+		t.Fatalf("expected profile %d to be purged, got %v (%v)", profile.ID, purged, err)
+	}
+	if err := store.RestoreLinkedInProfile(profile.ID); err == nil {
+		t.Error("expected purged profile to be gone")
+	}
+}
+
+func TestDeleteLinkedInProfiles(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	var ids []int64
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		profile, err := store.CreateLinkedInProfile(email, "password")
+		if err != nil {
+			t.Fatalf("failed to create LinkedIn profile: %v", err)
+		}
+		ids = append(ids, profile.ID)
+	}
+
+	// Empty slice is a no-op
+	count, err := store.DeleteLinkedInProfiles(nil)
+	if err != nil || count != 0 {
+		t.Fatalf("expected no-op for empty ids, got %d, %v", count, err)
+	}
+
+	// Unknown IDs are ignored
+	deleted, err := store.DeleteLinkedInProfilesReturningIDs([]int64{ids[0], ids[1], 99999})
+	if err != nil {
+		t.Fatalf("failed to delete LinkedIn profiles: %v", err)
+	}
+	slices.Sort(deleted)
+	if !slices.Equal(deleted, ids[:2]) {
+		t.Errorf("expected %v deleted, got %v", ids[:2], deleted)
+	}
+
+	profiles, err := store.ListLinkedInProfiles()
+	if err != nil {
+		t.Fatalf("failed to list LinkedIn profiles: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].ID != ids[2] {
+		t.Errorf("expected only profile %d to remain, got %+v", ids[2], profiles)
+	}
+
+	// Already-deleted profiles aren't counted again
+	count, err = store.DeleteLinkedInProfiles(ids)
+	if err != nil {
+		t.Fatalf("failed to delete LinkedIn profiles: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected only profile %d to be deleted, got %d deleted", ids[2], count)
+	}
+}
+
+func TestCountProfiles(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	count := func() int {
+		t.Helper()
+		n, err := store.CountProfiles()
+		if err != nil {
+			t.Fatalf("failed to count LinkedIn profiles: %v", err)
+		}
+		return n
+	}
+
+	if n := count(); n != 0 {
+		t.Errorf("expected no profiles, got %d", n)
+	}
+	var ids []int64
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		profile, err := store.CreateLinkedInProfile(email, "password")
+		if err != nil {
+			t.Fatalf("failed to create LinkedIn profile: %v", err)
+		}
+		ids = append(ids, profile.ID)
+	}
+	if n := count(); n != 3 {
+		t.Errorf("expected 3 profiles, got %d", n)
+	}
+
+	// Soft-deleted profiles aren't counted until restored
+	if err := store.DeleteLinkedInProfile(ids[0]); err != nil {
+		t.Fatalf("failed to delete LinkedIn profile: %v", err)
+	}
+	if n := count(); n != 2 {
+		t.Errorf("expected 2 profiles after a delete, got %d", n)
+	}
+	if err := store.RestoreLinkedInProfile(ids[0]); err != nil {
+		t.Fatalf("failed to restore LinkedIn profile: %v", err)
+	}
+	if n := count(); n != 3 {
+		t.Errorf("expected 3 profiles after a restore, got %d", n)
+	}
+}
+
+func TestSearchPreferences(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("search@example.com", "password")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	if profile.SearchSort != SearchSortDate || profile.PostedWithin != "" {
+		t.Errorf("expected date sort with no freshness filter by default, got %q/%q", profile.SearchSort, profile.PostedWithin)
+	}
+
+	updated, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:        "search@example.com",
+		Password:     "password",
+		SearchSort:   SearchSortRelevance,
+		PostedWithin: PostedWithinWeek,
+	})
+	if err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+	if updated.SearchSort != SearchSortRelevance || updated.PostedWithin != PostedWithinWeek {
+		t.Errorf("expected relevance/week, got %q/%q", updated.SearchSort, updated.PostedWithin)
+	}
+
+	if _, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:        "search@example.com",
+		PostedWithin: "fortnight",
+	}); err == nil {
+		t.Error("expected error for invalid posted within window")
+	}
+	if _, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:            "search@example.com",
+		ExperienceLevels: []string{ExperienceEntry, "senior"},
+	}); err == nil {
+		t.Error("expected error for invalid experience level")
+	}
+	if _, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:    "search@example.com",
+		JobTypes: []string{"freelance"},
+	}); err == nil {
+		t.Error("expected error for invalid job type")
+	}
+}
+
+func TestSearchLinkedInProfiles(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	seed := []struct {
+		email     string
+		positions []string
+	}{
+		{"alice@example.com", []string{"Software Engineer", "Backend Developer"}},
+		{"bob@test.org", []string{"Data Scientist"}},
+		{"carol@example.com", []string{"Frontend Engineer"}},
+	}
+	for _, p := range seed {
+		profile, err := store.CreateLinkedInProfile(p.email, "password")
+		if err != nil {
+			t.Fatalf("failed to create LinkedIn profile: %v", err)
+		}
+		if _, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+			Email:     p.email,
+			Password:  "password",
+			Positions: p.positions,
+		}); err != nil {
+			t.Fatalf("failed to update LinkedIn profile: %v", err)
+		}
+	}
+
+	emails := func(profiles []*LinkedInProfile) map[string]bool {
+		m := map[string]bool{}
+		for _, p := range profiles {
+			m[p.Email] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		filter ProfileFilter
+		want   []string
+	}{
+		{"empty filter", ProfileFilter{}, []string{"alice@example.com", "bob@test.org", "carol@example.com"}},
+		{"email substring", ProfileFilter{EmailContains: "EXAMPLE"}, []string{"alice@example.com", "carol@example.com"}},
+		{"position substring", ProfileFilter{PositionContains: "engineer"}, []string{"alice@example.com", "carol@example.com"}},
+		{"both", ProfileFilter{EmailContains: "alice", PositionContains: "backend"}, []string{"alice@example.com"}},
+		{"wildcards are literal", ProfileFilter{EmailContains: "%"}, nil},
+		{"no match", ProfileFilter{PositionContains: "Chef"}, nil},
+	}
+	for _, tt := range tests {
+		profiles, err := store.SearchLinkedInProfiles(tt.filter)
+		if err != nil {
+			t.Fatalf("%s: failed to search profiles: %v", tt.name, err)
+		}
+		got := emails(profiles)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for _, email := range tt.want {
+			if !got[email] {
+				t.Errorf("%s: expected %s in results, got %v", tt.name, email, got)
+			}
+		}
+	}
+
+	// Pagination walks every profile exactly once
+	seen := map[string]bool{}
+	for offset := 0; offset < 3; offset += 2 {
+		page, err := store.SearchLinkedInProfiles(ProfileFilter{Limit: 2, Offset: offset})
+		if err != nil {
+			t.Fatalf("failed to page profiles: %v", err)
+		}
+		for _, p := range page {
+			if seen[p.Email] {
+				t.Errorf("profile %s returned twice", p.Email)
+			}
+			seen[p.Email] = true
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected pagination to return 3 profiles, got %d", len(seen))
+	}
+}
+
+func TestPatchLinkedInProfile(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("patch@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	original, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:            "patch@example.com",
+		Password:         "secret",
+		PhoneNumber:      "555-0100",
+		Positions:        []string{"Software Engineer"},
+		Locations:        []string{"Remote"},
+		UserCity:         "Austin",
+		UserState:        "TX",
+		ZipCode:          "78701",
+		DesiredSalary:    150000,
+		SearchSort:       SearchSortRelevance,
+		ExperienceLevels: []string{ExperienceEntry, ExperienceAssociate},
+		JobTypes:         []string{JobTypeFullTime},
+	})
+	if err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+	if original.ZipCode != "78701" || original.DesiredSalary != 150000 {
+		t.Fatalf("expected zip and salary to be stored, got %+v", original)
+	}
+	if fmt.Sprint(original.ExperienceLevels) != "[entry associate]" || fmt.Sprint(original.JobTypes) != "[full-time]" {
+		t.Fatalf("expected search filters to be stored, got %v %v", original.ExperienceLevels, original.JobTypes)
+	}
+
+	phone := "555-0199"
+	patched, err := store.PatchLinkedInProfile(profile.ID, LinkedInProfilePatch{PhoneNumber: &phone})
+	if err != nil {
+		t.Fatalf("failed to patch LinkedIn profile: %v", err)
+	}
+	if patched.PhoneNumber != phone {
+		t.Errorf("expected phone %q, got %q", phone, patched.PhoneNumber)
+	}
+
+	// Everything else is untouched
+	patched.PhoneNumber = original.PhoneNumber
+	patched.UpdatedAt = original.UpdatedAt
+	if fmt.Sprintf("%+v", patched) != fmt.Sprintf("%+v", original) {
+		t.Errorf("expected other fields unchanged:\n got %+v\nwant %+v", patched, original)
+	}
+
+	// Zero values are applied when explicitly set
+	empty := []string{}
+	remote := true
+	zero := 0
+	patched, err = store.PatchLinkedInProfile(profile.ID, LinkedInProfilePatch{
+		Positions:     &empty,
+		RemoteOnly:    &remote,
+		DesiredSalary: &zero,
+	})
+	if err != nil {
+		t.Fatalf("failed to patch LinkedIn profile: %v", err)
+	}
+	if len(patched.Positions) != 0 || !patched.RemoteOnly || patched.DesiredSalary != 0 {
+		t.Errorf("expected explicit zero values to apply, got %+v", patched)
+	}
+	if patched.UserCity != "Austin" || patched.ZipCode != "78701" || patched.SearchSort != SearchSortRelevance {
+		t.Errorf("expected unpatched fields to survive, got %+v", patched)
+	}
+
+	if _, err := store.PatchLinkedInProfile(profile.ID, LinkedInProfilePatch{}); err != nil {
+		t.Errorf("expected empty patch to succeed, got %v", err)
+	}
+
+	invalid := "newest"
+	if _, err := store.PatchLinkedInProfile(profile.ID, LinkedInProfilePatch{SearchSort: &invalid}); err == nil {
+		t.Error("expected error for invalid search sort")
+	}
+	if _, err := store.PatchLinkedInProfile(999, LinkedInProfilePatch{PhoneNumber: &phone}); err == nil {
+		t.Error("expected error patching missing profile")
+	}
+}
+
+func TestPatchLinkedInProfileFields(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	profile, err := store.CreateLinkedInProfile("fields@example.com", "secret")
+	if err != nil {
+		t.Fatalf("failed to create LinkedIn profile: %v", err)
+	}
+	original, err := store.UpdateLinkedInProfile(profile.ID, LinkedInProfileUpdate{
+		Email:     "fields@example.com",
+		Password:  "secret",
+		Positions: []string{"Software Engineer"},
+		Locations: []string{"Remote"},
+		UserCity:  "Austin",
+	})
+	if err != nil {
+		t.Fatalf("failed to update LinkedIn profile: %v", err)
+	}
+
+	patched, err := store.PatchLinkedInProfileFields(profile.ID, map[string]any{"userCity": "Denver"})
+	if err != nil {
+		t.Fatalf("failed to patch LinkedIn profile: %v", err)
+	}
+	if patched.UserCity != "Denver" {
+		t.Errorf("expected city 'Denver', got %q", patched.UserCity)
+	}
+
+	// Everything else is untouched
+	patched.UserCity = original.UserCity
+	patched.UpdatedAt = original.UpdatedAt
+	if fmt.Sprintf("%+v", patched) != fmt.Sprintf("%+v", original) {
+		t.Errorf("expected other fields unchanged:\n got %+v\nwant %+v", patched, original)
+	}
+
+	for name, fields := range map[string]map[string]any{
+		"unknown field": {"userCity": "Boston", "favoriteColor": "blue"},
+		"column name":   {"user_city": "Boston"},
+		"wrong type":    {"yearsExperience": "lots"},
+		"invalid value": {"searchSort": "random"},
+		"invalid level": {"experienceLevels": []string{"junior"}},
+		"invalid type":  {"jobTypes": []string{"gig"}},
+	} {
+		if _, err := store.PatchLinkedInProfileFields(profile.ID, fields); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	current, err := store.GetLinkedInProfile(profile.ID)
+	if err != nil {
+		t.Fatalf("failed to get LinkedIn profile: %v", err)
+	}
+	if current.UserCity != "Denver" {
+		t.Errorf("expected rejected patches to change nothing, got city %q", current.UserCity)
+	}
+}